@@ -2,37 +2,64 @@ package main
 
 import (
 	"bufio"
+	"context"
+	"errors"
+	"flag"
 	"fmt"
 	"io"
+	"io/fs"
 	"log"
-	"net"
 	"os"
+	"path"
+	"path/filepath"
 	"strconv"
 	"strings"
+
+	"github.com/UriNoHi/Distributed-file-system-DFS-/dfs"
+	"github.com/UriNoHi/Distributed-file-system-DFS-/protocolo"
 )
 
-var conn net.Conn
-var err error
-var reader *bufio.Reader
+// cliente hace todo el trabajo; este programa solo lee los comandos y muestra los resultados
+var cliente *dfs.Client
 var readerCommand *bufio.Reader
 
 func main() {
+	legacy := flag.Bool("legacy", false, "usar el protocolo de frames en lugar de gRPC")
+	cert := flag.String("cert", "", "certificado PEM del cliente, para clusters con mTLS")
+	key := flag.String("key", "", "clave privada PEM del certificado")
+	ca := flag.String("ca", "", "autoridades PEM con las que se verifica al cluster, si usa TLS")
+	usuario := flag.String("usuario", "", "usuario con el que se hacen los pedidos; por defecto, el del sistema")
+	flag.Parse()
+
 	namenode := "localhost:8080"
-	if len(os.Args) < 2 {
+	if flag.NArg() < 1 {
 		log.Println("[WARN] No se proporcionó la dirección del Namenode. Usando por defecto: ", namenode)
-	} else{
-		namenode := os.Args[1] //ip:puerto del namenode
+	} else {
+		namenode = strings.TrimSpace(flag.Arg(0)) //ip:puerto del namenode
 		log.Println("[INFO] Se proporcionó la dirección del Namenode: ", namenode)
 	}
-	namenodeAddr := strings.TrimSpace(os.Args[1])
 	setupLog()
 
-	conn, err = net.Dial("tcp", namenodeAddr)
+	configTLS, err := protocolo.ConfigTLS(*cert, *key, *ca)
+	if err != nil {
+		log.Println("[ERROR] Configuración de TLS inválida:", err)
+		os.Exit(1)
+	}
+	cliente, err = dfs.DialWithOptions(context.Background(), namenode, &dfs.Options{Legacy: *legacy, TLS: configTLS, User: *usuario})
 	if err != nil {
 		log.Println("[ERROR] No se pudo conectar al Namenode: \n", err)
 		os.Exit(1)
 	}
-	defer conn.Close()
+	defer cliente.Close()
+
+	// Argumento opcional: cantidad de bloques que se transfieren en paralelo
+	if flag.NArg() > 1 {
+		cliente.ParallelTransfers, err = strconv.Atoi(flag.Arg(1))
+		if err != nil || cliente.ParallelTransfers < 1 {
+			log.Println("[ERROR] Cantidad de transferencias en paralelo inválida:", flag.Arg(1))
+			os.Exit(1)
+		}
+	}
 
 	log.Printf("Conectado al Namenode %s\n", namenode)
 
@@ -42,46 +69,218 @@ func main() {
 
 		input, _ := readerCommand.ReadString('\n')
 		input = strings.TrimSpace(input)
-		splitCommand := strings.Split(input, " ")
+		// Fields y no Split, así los espacios de más no dejan argumentos vacíos
+		splitCommand := strings.Fields(input)
 
 		if len(splitCommand) == 0 {
 			input = "DEFAULT"
+			splitCommand = []string{input}
 		}
 		log.Println("Comando ingresado:", input)
 
 		switch splitCommand[0] {
 		case "put":
+			// usage: put <local-file> [remote-path] [n] [block-size]
 			if len(splitCommand) < 2 {
 				usage("put")
+				continue
+			}
+			argumentos := splitCommand[2:]
+			remoto := "/" + filepath.Base(splitCommand[1])
+			// Se sigue aceptando "put <local-file> <n>" sin ruta remota
+			if len(argumentos) > 0 {
+				if _, err := strconv.Atoi(argumentos[0]); err != nil {
+					remoto = rutaRemota(argumentos[0])
+					argumentos = argumentos[1:]
+				}
+			}
+			replicacion := 0 // 0 = la replicación por defecto del Namenode
+			if len(argumentos) > 0 {
+				replicacion, err = strconv.Atoi(argumentos[0])
+				if err != nil || replicacion < 1 {
+					usage("put")
+					continue
+				}
 			}
-			put(splitCommand[1])
+			var blockSize int64 // 0 = el tamaño de bloque por defecto del Namenode
+			if len(argumentos) > 1 {
+				blockSize, err = parsearBytes(argumentos[1])
+				if err != nil || blockSize < 1 {
+					usage("put")
+					continue
+				}
+			}
+			put(splitCommand[1], remoto, replicacion, blockSize)
 
 		case "get":
-			// usage: get <remote-path>
+			// usage: get <remote-path> [local-file]
 			if len(splitCommand) < 2 {
 				usage("get")
+				continue
+			}
+			remoto := rutaRemota(splitCommand[1])
+			local := path.Base(remoto)
+			if len(splitCommand) > 2 {
+				local = splitCommand[2]
 			}
-			get(splitCommand[1])
+			get(remoto, local)
+
+		case "cat":
+			// usage: cat <remote-path> [--offset N] [--length N]
+			if len(splitCommand) < 2 {
+				usage("cat")
+				continue
+			}
+			var offset int64
+			largo := int64(-1) // -1 = hasta el final del archivo
+			valido := len(splitCommand)%2 == 0
+			for i := 2; valido && i < len(splitCommand); i += 2 {
+				valor, err := parsearBytes(splitCommand[i+1])
+				switch {
+				case err != nil || valor < 0:
+					valido = false
+				case splitCommand[i] == "--offset":
+					offset = valor
+				case splitCommand[i] == "--length":
+					largo = valor
+				default:
+					valido = false
+				}
+			}
+			if !valido {
+				usage("cat")
+				continue
+			}
+			cat(rutaRemota(splitCommand[1]), offset, largo)
 
 		case "info":
 			// usage: info <path>
 			if len(splitCommand) < 2 {
 				usage("info")
+				continue
 			}
-			info(splitCommand[1])
+			info(rutaRemota(splitCommand[1]))
 
 		case "ls":
 			// usage: ls [path]
+			ruta := "/"
 			if len(splitCommand) > 1 {
-				usage("ls")
+				ruta = rutaRemota(splitCommand[1])
 			}
-			ls()
+			ls(ruta)
 
 		case "rm":
-			if len(splitCommand) < 2 {
+			// usage: rm [-r] <remote-path>
+			if len(splitCommand) > 2 && splitCommand[1] == "-r" {
+				rm(rutaRemota(splitCommand[2]), true)
+			} else if len(splitCommand) == 2 {
+				rm(rutaRemota(splitCommand[1]), false)
+			} else {
 				usage("rm")
 			}
-			rm(splitCommand[1])
+
+		case "mkdir":
+			// usage: mkdir [-p] <remote-path>
+			if len(splitCommand) > 2 && splitCommand[1] == "-p" {
+				mkdir(rutaRemota(splitCommand[2]), true)
+			} else if len(splitCommand) == 2 {
+				mkdir(rutaRemota(splitCommand[1]), false)
+			} else {
+				usage("mkdir")
+			}
+
+		case "rmdir":
+			// usage: rmdir <remote-path>
+			if len(splitCommand) < 2 {
+				usage("rmdir")
+				continue
+			}
+			rmdir(rutaRemota(splitCommand[1]))
+
+		case "mv":
+			// usage: mv <remote-src> <remote-dst>
+			if len(splitCommand) < 3 {
+				usage("mv")
+				continue
+			}
+			mv(rutaRemota(splitCommand[1]), rutaRemota(splitCommand[2]))
+
+		case "setrep":
+			// usage: setrep <remote-path> <n>
+			if len(splitCommand) < 3 {
+				usage("setrep")
+				continue
+			}
+			replicacion, err := strconv.Atoi(splitCommand[2])
+			if err != nil || replicacion < 1 {
+				usage("setrep")
+				continue
+			}
+			setrep(rutaRemota(splitCommand[1]), replicacion)
+
+		case "chmod":
+			// usage: chmod <modo octal> <remote-path>
+			if len(splitCommand) < 3 {
+				usage("chmod")
+				continue
+			}
+			modo, err := strconv.ParseUint(splitCommand[1], 8, 32)
+			if err != nil || modo > 0777 {
+				usage("chmod")
+				continue
+			}
+			chmod(rutaRemota(splitCommand[2]), fs.FileMode(modo))
+
+		case "chown":
+			// usage: chown <usuario>[:grupo] <remote-path>
+			if len(splitCommand) < 3 {
+				usage("chown")
+				continue
+			}
+			owner, group, _ := strings.Cut(splitCommand[1], ":")
+			if owner == "" && group == "" {
+				usage("chown")
+				continue
+			}
+			chown(rutaRemota(splitCommand[2]), owner, group)
+
+		case "setquota", "setspacequota":
+			// usage: setquota <remote-dir> <n> | setspacequota <remote-dir> <tamaño>
+			if len(splitCommand) < 3 {
+				usage(splitCommand[0])
+				continue
+			}
+			var cuota int64
+			var err error
+			if splitCommand[0] == "setquota" {
+				cuota, err = strconv.ParseInt(splitCommand[2], 10, 64)
+			} else {
+				cuota, err = parsearBytes(splitCommand[2])
+			}
+			if err != nil || cuota < 0 {
+				usage(splitCommand[0])
+				continue
+			}
+			setQuota(splitCommand[0], rutaRemota(splitCommand[1]), cuota)
+
+		case "count":
+			// usage: count [-q] <remote-path>
+			argumentos := splitCommand[1:]
+			conCuotas := len(argumentos) > 0 && argumentos[0] == "-q"
+			if conCuotas {
+				argumentos = argumentos[1:]
+			}
+			if len(argumentos) < 1 {
+				usage("count")
+				continue
+			}
+			count(rutaRemota(argumentos[0]), conCuotas)
+
+		case "nodes":
+			nodesInfo()
+
+		case "fsck":
+			fsckInfo()
 
 		case "exit":
 			log.Println("Cerrando cliente...")
@@ -98,327 +297,328 @@ func main() {
 func usage(cmd string) {
 	switch cmd {
 	case "put":
-		log.Println("uso del comando: put <local-file>")
+		log.Println("uso del comando: put <local-file> [remote-path] [replicacion] [tamaño de bloque, ej. 8M]")
 
 	case "get":
-		log.Println("uso del comando: get <local-file> ")
+		log.Println("uso del comando: get <remote-path> [local-file]")
+
+	case "cat":
+		log.Println("uso del comando: cat <remote-path> [--offset N] [--length N], N acepta sufijos K, M o G")
 
 	case "info":
-		log.Println("uso del comando: info <local-file>")
+		log.Println("uso del comando: info <remote-path>")
 
 	case "ls":
-		log.Println("uso del comando: ls , sin argumentos")
+		log.Println("uso del comando: ls [remote-path]")
 
-	default:
-		log.Println("Usage:")
-		log.Println("  put <local-path>    Upload a file")
-		log.Println("  get <remote-path>   Download a file")
-		log.Println("  info <path>         Show info about a file")
-		log.Println("  ls                  List files in the metadata")
-	}
+	case "rm":
+		log.Println("uso del comando: rm [-r] <remote-path>")
 
-}
+	case "mkdir":
+		log.Println("uso del comando: mkdir [-p] <remote-path>")
 
-func put(fileName string) {
-	log.Println("Ejecutando comando put con argumentos:", fileName)
+	case "rmdir":
+		log.Println("uso del comando: rmdir <remote-path>")
 
-	//Abro el archivo local
-	file := abrirArchivoLocal(fileName)
-	if file == nil {
-		return
-	}
+	case "mv":
+		log.Println("uso del comando: mv <remote-src> <remote-dst>")
 
-	//Lo parto en bloques de 1KB
-	buffers := [][]byte{}
-	cantBlocks := 0
+	case "setrep":
+		log.Println("uso del comando: setrep <remote-file> <replicacion>")
 
-	buffers, cantBlocks = particionarArchivoEnBloques(file)
-	defer file.Close()
+	case "chmod":
+		log.Println("uso del comando: chmod <modo octal, ej. 750> <remote-path>")
 
-	//Consulta al Namenode dónde guardar cada bloque
-	toSend :=
-		"put " + //comando <put>
-			fileName + " " + //archivo que quiero guardar
-			fmt.Sprint(cantBlocks) + //número de bloques del archivo
-			"\n"
-	sendToNamenode(toSend)
+	case "chown":
+		log.Println("uso del comando: chown <usuario>[:grupo] <remote-path>, con :grupo solo cambia el grupo")
 
-	//Recibe la lista de Datanodes asignados. (response)
-	response := responseFromNamenode()
+	case "setquota":
+		log.Println("uso del comando: setquota <remote-dir> <cantidad de archivos y directorios>, 0 saca la cuota")
 
-	//Enviar los bloques a los Datanodes asignados
-	dataNodes := strings.Split(response, ",")
+	case "setspacequota":
+		log.Println("uso del comando: setspacequota <remote-dir> <bytes con réplicas, ej. 1G>, 0 saca la cuota")
 
-	storeDataNodes(dataNodes, buffers, fileName, cantBlocks)
+	case "count":
+		log.Println("uso del comando: count [-q] <remote-path>")
 
-}
+	case "nodes":
+		log.Println("uso del comando: nodes , sin argumentos")
+
+	case "fsck":
+		log.Println("uso del comando: fsck , sin argumentos")
 
-func get(fileName string) {
-	log.Println("Ejecutando comando get con argumentos:", fileName)
+	default:
+		log.Println("Usage:")
+		log.Println("  put <local-path> [remote-path] [n] [size]  Upload a file with n replicas per block")
+		log.Println("  get <remote-path> [local-path]      Download a file")
+		log.Println("  cat <path> [--offset N] [--length N]  Print a file or a byte range of it")
+		log.Println("  info <path>         Show info about a file")
+		log.Println("  ls [path]           List a directory")
+		log.Println("  mkdir [-p] <path>   Create a directory")
+		log.Println("  rmdir <path>        Remove an empty directory")
+		log.Println("  rm [-r] <path>      Remove a file or a directory tree")
+		log.Println("  mv <src> <dst>      Move or rename a file or directory")
+		log.Println("  setrep <path> <n>   Change the replicas per block of a file")
+		log.Println("  chmod <mode> <path> Change the permissions of a file or directory")
+		log.Println("  chown <user>[:group] <path>  Change the owner and group")
+		log.Println("  setquota <dir> <n>  Limit the files and directories under a directory")
+		log.Println("  setspacequota <dir> <size>  Limit the bytes, counting replicas, under a directory")
+		log.Println("  count [-q] <path>   Count directories, files and bytes, with -q also the quotas")
+		log.Println("  nodes               Show DataNode liveness")
+		log.Println("  fsck                Check reported blocks against the metadata")
+	}
+
+}
 
-	toSend := "get " + fileName + "\n"
-	sendToNamenode(toSend)
-	response := responseFromNamenode()
+func put(fileName string, remoto string, replicacion int, blockSize int64) {
+	log.Println("Ejecutando comando put con argumentos:", fileName, remoto)
+	opts := &dfs.WriteOptions{Replication: replicacion, BlockSize: blockSize}
+	if err := cliente.Put(context.Background(), fileName, remoto, opts); err != nil {
+		log.Println("[ERROR]", err)
+		return
+	}
+	log.Printf("Archivo %s guardado en %s\n", fileName, remoto)
+}
 
-	listOfDataNodes := strings.Split(response, ",")
-	log.Println("Lista de DataNodos: ", listOfDataNodes)
+func get(fileName string, local string) {
+	log.Println("Ejecutando comando get con argumentos:", fileName, local)
+	if err := cliente.Get(context.Background(), fileName, local); err != nil {
+		log.Println("[ERROR]", err)
+		return
+	}
+	log.Printf("Archivo %s guardado en %s\n", fileName, local)
+}
 
-	buffer := readDataNodes(listOfDataNodes, fileName)
+// cat muestra el rango pedido del archivo; solo se bajan los bloques que lo tocan
+func cat(fileName string, offset int64, largo int64) {
+	log.Println("Ejecutando comando cat con argumentos:", fileName, offset, largo)
+	reader, err := cliente.Open(context.Background(), fileName)
+	if err != nil {
+		log.Println("[ERROR]", err)
+		return
+	}
+	defer reader.Close()
 
-	createLocalFile(buffer, fileName)
+	if largo < 0 || largo > reader.Size()-offset {
+		largo = max(0, reader.Size()-offset)
+	}
+	buffer := make([]byte, max(1, min(largo, 1<<20)))
+	if _, err := io.CopyBuffer(os.Stdout, io.NewSectionReader(reader, offset, largo), buffer); err != nil {
+		fmt.Println()
+		log.Println("[ERROR]", err)
+		return
+	}
+	fmt.Println()
 }
 
 func info(file string) {
 	log.Println("Ejecutando comando info con argumentos:", file)
-	sendToNamenode("info " + file + "\n")
-	response := responseFromNamenode()
+	bloques, err := cliente.Blocks(context.Background(), file)
+	if err != nil {
+		log.Println("[ERROR]", err)
+		return
+	}
 
 	log.Println(" ===== Información del archivo: " + file + " ===== ")
-	//quiero separarlos por coma y mostrarlos en líneas separadas
-	splitInfo := strings.Split(response, ",")
-	for i, info := range splitInfo {
-		toPrint := "Bloque " + strconv.Itoa(i) + " en datanode: " + info
+	if stat, err := cliente.Stat(context.Background(), file); err == nil {
+		log.Printf("Permisos: %s %s %s\n", stat.Mode, stat.Owner, stat.Group)
+	}
+	for i, info := range bloques {
+		toPrint := "Bloque " + strconv.Itoa(i) + " (" + info.Name + ") en datanodes: " + strings.Join(info.Replicas, ", ")
 		log.Println(toPrint)
 	}
 }
 
-func ls() {
-	log.Println("Ejecutando comando ls")
-	sendToNamenode("ls\n")
-	response := responseFromNamenode()
-	log.Println(" ===== Contenido del metadata ===== ")
-	splitFiles := strings.Split(response, ",")
-	for _, file := range splitFiles {
-		log.Println("-	", file)
+func ls(ruta string) {
+	log.Println("Ejecutando comando ls con argumentos:", ruta)
+	entradas, err := cliente.List(context.Background(), ruta)
+	if err != nil {
+		log.Println("[ERROR]", err)
+		return
+	}
+	log.Println(" ===== Contenido de " + ruta + " ===== ")
+	for _, entrada := range entradas {
+		fecha := entrada.ModTime.Format("2006-01-02 15:04")
+		if entrada.IsDir() {
+			log.Printf("%s %-8s %-10s %10s %s %s/\n", entrada.Mode, entrada.Owner, entrada.Group, "-", fecha, entrada.Name)
+		} else {
+			log.Printf("%s %-8s %-10s %10d %s %s (replicacion %d)\n", entrada.Mode, entrada.Owner, entrada.Group, entrada.Size, fecha, entrada.Name, entrada.Replication)
+		}
 	}
 }
 
-func rm(fileName string) {
-	log.Println("Ejecutando comando rm")
-	sendToNamenode("rm " + fileName + "\n")
-	response := responseFromNamenode()
-	listOfDataNodes := strings.Split(response, ",")
-	removeDataNodes(listOfDataNodes, fileName)
-	log.Println("Archivo eliminado del DFS: ", fileName)
+func mkdir(ruta string, padres bool) {
+	log.Println("Ejecutando comando mkdir con argumentos:", ruta)
+	crear := cliente.Mkdir
+	if padres {
+		crear = cliente.MkdirAll
+	}
+	if err := crear(context.Background(), ruta); err != nil {
+		log.Println("[ERROR]", err)
+		return
+	}
+	log.Println("Directorio creado:", ruta)
 }
 
-func abrirArchivoLocal(nameFile string) *os.File {
-	file, err := os.Open(nameFile)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "no se pudo abrir el archivo %s: %v\n", nameFile, err)
-		os.Exit(1)
+func rmdir(ruta string) {
+	log.Println("Ejecutando comando rmdir con argumentos:", ruta)
+	if err := cliente.RemoveDir(context.Background(), ruta); err != nil {
+		log.Println("[ERROR]", err)
+		return
 	}
-	//defer file.Close()
-	return file
+	log.Println("Directorio eliminado:", ruta)
 }
 
-func particionarArchivoEnBloques(file *os.File) ([][]byte, int) {
-	buffers := [][]byte{}
-	cantBlocks := 0
-	buffer := make([]byte, 1024) // 1KB
-	for {
-		blockSize, err := file.Read(buffer)
-		if err != nil && err != io.EOF {
-			log.Println("[ERROR] Error leyendo:", err)
-			break
-		}
-		if blockSize == 0 {
-			break
-		}
-
-		block := make([]byte, blockSize)
-		copy(block, buffer[:blockSize])
-		log.Printf("\n Bloque %d leído, tamaño %d \n ========================================", cantBlocks, blockSize)
-
-		log.Println("[DEBUG] Tamaño esperado:", blockSize)
-		log.Println("[DEBUG] Tamaño recibido real:", len(block))
+func setrep(fileName string, replicacion int) {
+	log.Println("Ejecutando comando setrep con argumentos:", fileName, replicacion)
+	if err := cliente.SetReplication(context.Background(), fileName, replicacion); err != nil {
+		log.Println("[ERROR]", err)
+		return
+	}
+	log.Printf("Replicación de %s cambiada a %d, el Namenode ajusta las réplicas en segundo plano\n", fileName, replicacion)
+}
 
-		buffers = append(buffers, block)
-		cantBlocks++
+func chmod(ruta string, modo fs.FileMode) {
+	log.Printf("Ejecutando comando chmod con argumentos: %o %s\n", modo, ruta)
+	if err := cliente.Chmod(context.Background(), ruta, modo); err != nil {
+		log.Println("[ERROR]", err)
+		return
 	}
-	return buffers, cantBlocks
+	log.Printf("Permisos de %s cambiados a %o\n", ruta, modo)
 }
 
-func sendToNamenode(message string) {
-	log.Println("\nComando que mando a Namenode: ", message)
-	_, err = conn.Write([]byte(message))
-	if err != nil {
-		log.Println("[ERROR] Error al enviar:", err)
+func chown(ruta string, owner string, group string) {
+	log.Println("Ejecutando comando chown con argumentos:", owner, group, ruta)
+	if err := cliente.Chown(context.Background(), ruta, owner, group); err != nil {
+		log.Println("[ERROR]", err)
 		return
 	}
+	log.Printf("Dueño de %s cambiado a %s:%s\n", ruta, owner, group)
 }
 
-func responseFromNamenode() string {
-	reader := bufio.NewReader(conn)
-	response, err := reader.ReadString('\n')
+func setQuota(op string, ruta string, cuota int64) {
+	log.Println("Ejecutando comando", op, "con argumentos:", ruta, cuota)
+	var err error
+	if op == "setquota" {
+		err = cliente.SetQuota(context.Background(), ruta, cuota)
+	} else {
+		err = cliente.SetSpaceQuota(context.Background(), ruta, cuota)
+	}
 	if err != nil {
-		log.Println("[ERROR] Error al recibir respuesta:", err)
-		os.Exit(1)
+		log.Println("[ERROR]", err)
+		return
 	}
-	log.Println("Respuesta del Namenode: \n", response)
-	return response
+	if cuota == 0 {
+		log.Printf("Cuota de %s sacada\n", ruta)
+		return
+	}
+	log.Printf("Cuota de %s cambiada a %d\n", ruta, cuota)
 }
 
-func storeDataNodes(dataNodes []string, buffers [][]byte, fileName string, cantBlocks int) {
-
-	for i := 0; i < cantBlocks; i++ {
-		dnAddress := strings.TrimSpace(dataNodes[i])
-		log.Printf("Enviando bloque %d al Datanode %s\n", i, dnAddress)
-
-		dataNode, err := net.Dial("tcp", dnAddress)
-		if err != nil {
-			log.Println("[ERROR] Error al conectar con el Datanode:", err)
-			return
-		}
-		//defer dataNode.Close()
-
-		//Primero envio argumentos
-		argumentos := "store " + fileName + "_block_" + fmt.Sprint(i) + " " + strconv.Itoa(len(buffers[i])) + "\n"
-		dataNode.Write([]byte(argumentos))
-
-		//Luego envio el bloque de datos
-		//toSave := string(buffers[i]) //+ "\n"
-		dataNode.Write(buffers[i])
-
-		log.Printf("Bloque %d enviado al Datanode \n", i)
-
-		dataNode.Close()
+// count muestra las columnas de hdfs dfs -count: con -q primero las cuotas y lo que
+// queda de cada una, "none" e "inf" si no tiene
+func count(ruta string, conCuotas bool) {
+	log.Println("Ejecutando comando count con argumentos:", ruta)
+	resumen, err := cliente.Count(context.Background(), ruta)
+	if err != nil {
+		log.Println("[ERROR]", err)
+		return
 	}
-	for i := cantBlocks; i < (cantBlocks * 2); i++ {
-		//Envio al Backup
-		indexNode := i - cantBlocks
-		dnBackupAddress := strings.TrimSpace(dataNodes[i])
-		log.Printf("Enviando bloque de recuperacion %d al Datanode %s\n", indexNode, dnBackupAddress)
-
-		dataBackupNode, err := net.Dial("tcp", dnBackupAddress)
-		if err != nil {
-			log.Println("[ERROR] Error al conectar con el Datanode:", err)
-			return
-		}
-		defer dataBackupNode.Close()
-
-		//Primero envio argumentos
-		argumentosB := "store " + fileName + "_backup_block_" + fmt.Sprint(indexNode) + " " + strconv.Itoa(len(buffers[indexNode])) + "\n"
-		dataBackupNode.Write([]byte(argumentosB))
-
-		//Luego envio el bloque de datos
-		toSave := string(buffers[indexNode]) + "\n"
-		dataBackupNode.Write([]byte(toSave))
-
-		log.Printf("Bloque %d enviado al Datanode \n", indexNode)
-		dataBackupNode.Close()
+	columnas := fmt.Sprintf("%12d %12d %18d %s", resumen.DirCount, resumen.FileCount, resumen.Length, ruta)
+	if !conCuotas {
+		log.Printf("%12s %12s %18s %s\n", "DIR_COUNT", "FILE_COUNT", "CONTENT_SIZE", "PATHNAME")
+		log.Println(columnas)
+		return
+	}
+	cuota, queda := "none", "inf"
+	if resumen.Quota > 0 {
+		cuota = strconv.FormatInt(resumen.Quota, 10)
+		queda = strconv.FormatInt(resumen.Quota-resumen.DirCount-resumen.FileCount, 10)
 	}
+	cuotaEspacio, quedaEspacio := "none", "inf"
+	if resumen.SpaceQuota > 0 {
+		cuotaEspacio = strconv.FormatInt(resumen.SpaceQuota, 10)
+		quedaEspacio = strconv.FormatInt(resumen.SpaceQuota-resumen.SpaceConsumed, 10)
+	}
+	log.Printf("%12s %12s %15s %15s %12s %12s %18s %s\n", "QUOTA", "REM_QUOTA", "SPACE_QUOTA", "REM_SPACE_QUOTA", "DIR_COUNT", "FILE_COUNT", "CONTENT_SIZE", "PATHNAME")
+	log.Printf("%12s %12s %15s %15s %s\n", cuota, queda, cuotaEspacio, quedaEspacio, columnas)
 }
 
-func readDataNodes(dataNodes []string, fileName string) []byte {
-	buffer := make([]byte, 1024) // 1KB
-	//limpiar buffer antes de usar
-	buffer = []byte{}
-	for i, dn := range dataNodes {
-		dnAddress := strings.TrimSpace(dn)
-		log.Printf("Conectando al Datanode %s para leer el bloque %s\n", dnAddress, strconv.Itoa(i))
-		dataNode, err := net.Dial("tcp", dnAddress)
-		if err != nil {
-			log.Println("[ERROR] Error al conectar con el Datanode:", err)
-			//os.Exit(1)
-			recuperateFromAnotherNode(i, dataNodes, fileName, &buffer)
-			continue
-		}
-		defer dataNode.Close()
-
-		toRead := "read " + fileName + "_block_" + strconv.Itoa(i) + "\n"
-
-		log.Println("\nComando que mando a Datanode: ", toRead)
-		dataNode.Write([]byte(toRead))
-
-		reader = bufio.NewReader(dataNode)
-
-		sizeStr, err := reader.ReadString('\n')
-		if err != nil {
-			log.Println("[ERROR] Error al leer tamaño del bloque:", err)
-			return nil
-		}
-
-		sizeStr = strings.TrimSpace(sizeStr)
-		blockSize, _ := strconv.Atoi(sizeStr)
-
-		block := make([]byte, blockSize)
-		var n int
-		n, err = io.ReadFull(reader, block)
-		if err != nil {
-			log.Println("[ERROR] Error al leer bloque:", err)
-			return nil
-		}
-		log.Printf("[DEBUG] Tamaño recibido de  ReadFull: ", n)
-
-		log.Printf("[DEBUG] recibido %d bytes, tamaño declarado %d", len(block), blockSize)
-		if len(block) != blockSize {
-			log.Println("[WARN] mismatch tamaño")
-		}
-
-		log.Printf("[DEBUG] primeros bytes: %x", block[:min(16, len(block))])
-
-		buffer = append(buffer, block...)
-
-		//log.Printf("Bloque recibido del Datanode %s: %s\n", dnAddress, string(block))
-
+func nodesInfo() {
+	log.Println("Ejecutando comando nodes")
+	nodos, err := cliente.Nodes(context.Background())
+	if err != nil {
+		log.Println("[ERROR]", err)
+		return
+	}
+	log.Println(" ===== Estado de los DataNodes ===== ")
+	for _, node := range nodos {
+		log.Println("-	", node)
 	}
-	//log.Printf("Archivo completo recibido: %s\n", string(buffer))
-
-	return buffer
 }
 
-func createLocalFile(buffer []byte, fileName string) {
-	localFile, err := os.Create(fileName)
+func fsckInfo() {
+	log.Println("Ejecutando comando fsck")
+	problemas, err := cliente.Fsck(context.Background())
 	if err != nil {
-		log.Println("[ERROR] Error creando archivo local:", err)
+		log.Println("[ERROR]", err)
 		return
 	}
-	defer localFile.Close()
+	log.Println(" ===== Resultado de fsck ===== ")
+	for _, problema := range problemas {
+		log.Println("-	", problema)
+	}
+}
 
-	if err := os.WriteFile(localFile.Name(), buffer, 0644); err != nil {
-		log.Println("[ERROR] Error writing file:", err)
+func rm(fileName string, recursivo bool) {
+	log.Println("Ejecutando comando rm")
+	borrar := cliente.Remove
+	if recursivo {
+		borrar = cliente.RemoveAll
+	}
+	if err := borrar(context.Background(), fileName); err != nil {
+		log.Println("[ERROR]", err)
+		return
 	}
+	log.Println("Eliminado del DFS: ", fileName)
 }
 
-func recuperateFromAnotherNode(failedIndex int, dataNodes []string, fileName string, buffer *[]byte) {
-	log.Println("Recuperando bloque desde otro Datanode...")
-	for i, dn := range dataNodes {
-		if i == failedIndex {
-			continue
-		}
-		dnAddress := strings.TrimSpace(dn)
-		log.Printf("Intentando leer bloque desde el Datanode %s\n", dnAddress)
-		dataNode, err := net.Dial("tcp", dnAddress)
-		if err != nil {
-			log.Println("[ERROR] Error al conectar con el Datanode:", err)
-			continue
-		}
-		defer dataNode.Close()
+func mv(origen string, destino string) {
+	log.Println("Ejecutando comando mv con argumentos:", origen, destino)
+	if err := cliente.Rename(context.Background(), origen, destino); err != nil {
+		log.Println("[ERROR]", err)
+		return
+	}
+	log.Printf("%s movido a %s\n", origen, destino)
+}
 
-		toRead := "read " + fileName + "_backup_block_" + strconv.Itoa(failedIndex) + "\n"
-		log.Println("\n[RECOVER] Comando que mando a Datanode: ", toRead)
-		dataNode.Write([]byte(toRead))
-		reader = bufio.NewReader(dataNode)
+// parsearBytes acepta un tamaño en bytes, con sufijo K, M o G opcional: "512", "64K", "8M"
+func parsearBytes(texto string) (int64, error) {
+	if texto == "" {
+		return 0, errors.New("tamaño vacío")
+	}
+	multiplicador := int64(1)
+	switch strings.ToUpper(texto[len(texto)-1:]) {
+	case "K":
+		multiplicador = 1 << 10
+	case "M":
+		multiplicador = 1 << 20
+	case "G":
+		multiplicador = 1 << 30
+	}
+	if multiplicador > 1 {
+		texto = texto[:len(texto)-1]
+	}
+	valor, err := strconv.ParseInt(texto, 10, 64)
+	return valor * multiplicador, err
+}
 
-		sizeStr, err := reader.ReadString('\n')
-		if err != nil {
-			log.Println("[ERROR] Error al leer tamaño del bloque:", err)
-			return
-		}
-		sizeStr = strings.TrimSpace(sizeStr)
-		blockSize, _ := strconv.Atoi(sizeStr)
-		block := make([]byte, blockSize)
-		_, err = io.ReadFull(reader, block)
-		if err != nil {
-			log.Println("[ERROR] Error al leer bloque:", err)
-			return
-		}
-		*buffer = append(*buffer, block...)
-		log.Printf("Bloque recuperado del Datanode %s: %s\n", dnAddress, string(block))
-		return
+// rutaRemota convierte una ruta relativa en absoluta desde la raíz del DFS
+func rutaRemota(ruta string) string {
+	if !strings.HasPrefix(ruta, "/") {
+		ruta = "/" + ruta
 	}
-	log.Println("No se pudo recuperar el bloque desde ningún Datanode.")
+	return path.Clean(ruta)
 }
 
 func setupLog() {
@@ -432,21 +632,3 @@ func setupLog() {
 	log.SetOutput(mw)
 	log.SetFlags(log.LstdFlags | log.Lshortfile) // fecha, hora y línea de código
 }
-
-func removeDataNodes(dataNodes []string, fileName string) {
-	for i, dn := range dataNodes {
-		dnAddress := strings.TrimSpace(dn)
-		log.Printf("Conectando al Datanode %s para eliminar los bloques\n", dnAddress)
-		dataNode, err := net.Dial("tcp", dnAddress)
-		if err != nil {
-			log.Println("[ERROR] Error al conectar con el Datanode:", err)
-			return
-		}
-		defer dataNode.Close()
-
-		toDelete := "rm " + fileName + "_block_" + strconv.Itoa(i) + "\n"
-		log.Println("\nComando que mando a Datanode: ", toDelete)
-		dataNode.Write([]byte(toDelete))
-		dataNode.Close()
-	}
-}
@@ -1,23 +1,94 @@
 package main
 
 import (
-	"bufio"
+	"crypto/tls"
+	"flag"
 	"fmt"
 	"io"
 	"log"
 	"net"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
+
+	"github.com/UriNoHi/Distributed-file-system-DFS-/protocolo"
 )
 
+// Carpeta donde se guardan los bloques. Conviene una por DataNode si corren varios en la misma máquina.
+var dirBloques = "blocks"
+
+// Con -legacy el DataNode habla el protocolo de frames en lugar de gRPC, con el Namenode,
+// con los clientes y con los otros DataNodes. Todo el cluster tiene que usar el mismo.
+var legacy bool
+
+// secretoDelCluster firma las credenciales con las que este DataNode se identifica ante
+// el Namenode (ver protocolo.FirmarCredencial)
+var secretoDelCluster []byte
+
+// configTLS es la configuración de TLS para escuchar y para conectarse con el Namenode y
+// los otros DataNodes; nil si el cluster no usa TLS
+var configTLS *tls.Config
+
 func main() {
-	cmd := os.Args[1]
+	flag.BoolVar(&legacy, "legacy", false, "usar el protocolo de frames en lugar de gRPC")
+	cert := flag.String("cert", "", "certificado PEM del DataNode, para escuchar y conectarse con TLS")
+	key := flag.String("key", "", "clave privada PEM del certificado")
+	ca := flag.String("ca", "", "autoridades PEM en las que se confía; exige certificado del otro lado (mTLS)")
+	secreto := flag.String("secreto", "secreto", "archivo con el secreto del cluster, copiado del Namenode")
+	flag.Parse()
+	if flag.NArg() < 1 {
+		fmt.Println("uso: Datanode [-legacy] [-cert archivo -key archivo] [-ca archivo] [-secreto archivo] <puerto> [namenode] [dirección anunciada] [carpeta de bloques] [KB/s del escáner]")
+		return
+	}
+	cmd := flag.Arg(0)
 	setupLog()
 	log.Println("Iniciando Datanode en el puerto ", cmd)
 
 	ip_port :=":" + cmd
 
+	// Argumentos opcionales: dirección del Namenode, dirección anunciada de este DataNode,
+	// carpeta de bloques y velocidad del escáner de bloques
+	namenodeAddr := "localhost:8080"
+	if flag.NArg() > 1 {
+		namenodeAddr = strings.TrimSpace(flag.Arg(1))
+	}
+	miDireccion := "localhost:" + cmd
+	if flag.NArg() > 2 {
+		miDireccion = strings.TrimSpace(flag.Arg(2))
+	}
+	if flag.NArg() > 3 {
+		dirBloques = strings.TrimSpace(flag.Arg(3))
+	}
+	// Velocidad del escáner de bloques en KB/s, 0 lo desactiva
+	velocidadEscaneo := velocidadEscaneoPorDefecto
+	if flag.NArg() > 4 {
+		velocidad, err := strconv.Atoi(strings.TrimSpace(flag.Arg(4)))
+		if err != nil || velocidad < 0 {
+			log.Println("[ERROR] Velocidad de escaneo inválida:", flag.Arg(4))
+			return
+		}
+		velocidadEscaneo = velocidad
+	}
+	var err error
+	configTLS, err = protocolo.ConfigTLSDeNodo(*cert, *key, *ca)
+	if err != nil {
+		log.Println("[ERROR] Configuración de TLS inválida:", err)
+		return
+	}
+	if configTLS != nil {
+		log.Printf("[INFO] Usando TLS (mTLS: %t)\n", *ca != "")
+	}
+	secretoDelCluster, err = protocolo.LeerSecreto(*secreto)
+	if err != nil {
+		log.Println("[ERROR] No se pudo leer el secreto del cluster, sin él el Namenode no acepta este DataNode:", err)
+		return
+	}
+	if err := os.MkdirAll(dirBloques, 0755); err != nil {
+		log.Println("[ERROR] No se pudo crear la carpeta de bloques:", err)
+		return
+	}
+
 	socket, err := net.Listen("tcp", ip_port)
 	if err != nil {
 		log.Println("[ERROR] Error starting TCP server:", err)
@@ -27,6 +98,25 @@ func main() {
 	fmt.Println("Datanode is listening on port ", cmd)
 	log.Println("Datanode is listening on port ", cmd)
 
+	descartarReplicasViejas()
+
+	storageID := obtenerStorageID(cmd)
+	registrarEnNamenode(namenodeAddr, miDireccion, storageID)
+
+	go enviarHeartbeats(namenodeAddr, miDireccion, storageID)
+	go escanearBloques(velocidadEscaneo)
+
+	if !legacy {
+		log.Println("[INFO] Atendiendo pedidos con gRPC")
+		if err := servirRPC(socket, miDireccion); err != nil {
+			log.Println("[ERROR] Error en el servidor gRPC:", err)
+		}
+		return
+	}
+	log.Println("[INFO] Atendiendo pedidos con el protocolo de frames (-legacy)")
+	if configTLS != nil {
+		socket = tls.NewListener(socket, configTLS)
+	}
 	for {
 		coneccion, err := socket.Accept()
 		if err != nil {
@@ -35,102 +125,310 @@ func main() {
 		}
 		log.Println("[INFO] Client connected:", coneccion.RemoteAddr())
 
-		go handleConnection(coneccion)
+		go handleConnection(coneccion, miDireccion)
 	}
 }
 
-func handleConnection(coneccion net.Conn) {
+func handleConnection(coneccion net.Conn, miDireccion string) {
 	defer coneccion.Close()
-	// Crear lector para leer del cliente
-	reader := bufio.NewReader(coneccion)
+
+	conexion, err := protocolo.Aceptar(coneccion)
+	if err != nil {
+		log.Printf("[WARNING] Saludo inválido de %s: %v\n", coneccion.RemoteAddr(), err)
+		return
+	}
 	for {
-		// Leer mensaje del cliente (hasta \n)
-		argumentos, err := reader.ReadString('\n')
-		//n, err := io.ReadFull(reader, make([]byte, 1024))
+		pedido, err := conexion.LeerPedido()
 		if err != nil {
+			if err != io.EOF {
+				log.Printf("[ERROR] Error leyendo el pedido de %s: %v\n", coneccion.RemoteAddr(), err)
+			}
 			log.Println("[WARNING] Cliente desconectado:", coneccion.RemoteAddr())
 			return
 		}
-		if len(argumentos) == 0 {
-			continue
-		}
 
-		log.Printf("[INFO] Comando recibido de Cliente: %s", argumentos)
-
-		parts := strings.Split(strings.TrimSpace(string(argumentos)), " ")
-
-		if len(parts) < 2 {
-			//log.Println("Argumentos inválido recibido:", argumentos)
+		log.Printf("[INFO] Pedido %d recibido de %s: %s %s\n", pedido.ID, coneccion.RemoteAddr(), pedido.Op, pedido.Cuerpo)
+		// Solo vuelve con error si la conexión ya no se puede seguir usando
+		if err := atenderPedido(conexion, pedido, miDireccion); err != nil {
+			log.Printf("[ERROR] Pedido %d (%s): %v\n", pedido.ID, pedido.Op, err)
 			return
 		}
+	}
+}
 
-		cmd := parts[0]
-		fileName := parts[1]
+// canal es por donde se contesta un pedido: una conexión del protocolo de frames o una
+// llamada gRPC (llamadaRPC)
+type canal interface {
+	Datos() io.Reader
+	EscritorDeDatos() io.Writer
+	Responder(pedido *protocolo.Pedido, cuerpo any) error
+	ResponderError(pedido *protocolo.Pedido, err error) error
+}
 
-		switch cmd {
-		case "store":
-			blockSizeSTR := parts[2]
-			blockSize, _ := strconv.Atoi(blockSizeSTR)
-			log.Println("[INFO] Partes del comando: ", parts)
+func atenderPedido(conexion canal, pedido *protocolo.Pedido, miDireccion string) error {
+	switch pedido.Op {
+	case protocolo.OpStore:
+		// Después del pedido vienen los datos y los checksums de cada chunk. Si hay siguientes,
+		// el bloque se reenvía por el pipeline y se responde con las réplicas que lo guardaron.
+		var pedidoStore protocolo.PedidoStore
+		if err := pedido.Leer(&pedidoStore); err != nil {
+			return conexion.ResponderError(pedido, err)
+		}
+		if !nombreDeBloqueValido(pedidoStore.Bloque) || pedidoStore.Size < 0 {
+			log.Println("[ERROR] Pedido store inválido:", string(pedido.Cuerpo))
+			// Los datos que siguen se descartan al leer el próximo pedido
+			return conexion.ResponderError(pedido, protocolo.Errorf(protocolo.CodigoInvalido, "bloque o tamaño invalido"))
+		}
+		if err := verificarToken(pedidoStore.Token, pedidoStore.Bloque, protocolo.AccesoEscribir); err != nil {
+			return conexion.ResponderError(pedido, err)
+		}
+		guardado, durables, err := store(pedidoStore.Bloque, pedidoStore.Size, conexion.Datos(), pedidoStore.Siguientes, pedidoStore.Token)
+		if err != nil {
+			return fmt.Errorf("error al leer bloque de datos: %w", err)
+		}
+		if guardado {
+			durables = append([]string{miDireccion}, durables...)
+		}
+		return conexion.Responder(pedido, protocolo.RespuestaStore{Durables: durables})
 
-			buffer := make([]byte, blockSize)
-			_, err = io.ReadFull(reader, buffer)
-			if err != nil {
-				log.Println("[ERROR] Error al leer bloque de datos:", err)
-				return
-			}
+	case protocolo.OpReadBlock:
+		var pedidoRead protocolo.PedidoRead
+		if err := pedido.Leer(&pedidoRead); err != nil {
+			return conexion.ResponderError(pedido, err)
+		}
+		if !nombreDeBloqueValido(pedidoRead.Bloque) {
+			return conexion.ResponderError(pedido, errBloqueInvalido)
+		}
+		if err := verificarToken(pedidoRead.Token, pedidoRead.Bloque, protocolo.AccesoLeer); err != nil {
+			return conexion.ResponderError(pedido, err)
+		}
+		return read(conexion, pedido, pedidoRead)
 
-			store(fileName, buffer)
-		case "read":
-			read(parts[1], coneccion)
+	case protocolo.OpRmBlock:
+		var pedidoRm protocolo.PedidoBloque
+		if err := pedido.Leer(&pedidoRm); err != nil {
+			return conexion.ResponderError(pedido, err)
+		}
+		if !nombreDeBloqueValido(pedidoRm.Bloque) {
+			return conexion.ResponderError(pedido, errBloqueInvalido)
+		}
+		if err := verificarToken(pedidoRm.Token, pedidoRm.Bloque, protocolo.AccesoBorrar); err != nil {
+			return conexion.ResponderError(pedido, err)
+		}
+		if err := remove(pedidoRm.Bloque); err != nil {
+			return conexion.ResponderError(pedido, errorDeBloque(err))
+		}
+		return conexion.Responder(pedido, nil)
 
-		case "rm":
-			remove(fileName)
-		default:
-			log.Println("DEFAULT")
+	case protocolo.OpReplicate:
+		// Lo manda el Namenode. Se contesta apenas se acepta; el destino avisa al Namenode
+		// con su blockreceived cuando termina de guardarlo.
+		var pedidoReplicate protocolo.PedidoReplicate
+		if err := pedido.Leer(&pedidoReplicate); err != nil {
+			return conexion.ResponderError(pedido, err)
+		}
+		if !nombreDeBloqueValido(pedidoReplicate.Bloque) || !nombreDeBloqueValido(pedidoReplicate.BloqueDestino) || pedidoReplicate.Destino == "" {
+			log.Println("[ERROR] Pedido replicate inválido:", string(pedido.Cuerpo))
+			return conexion.ResponderError(pedido, errBloqueInvalido)
 		}
+		// Un token de lectura no alcanza: con él cualquier cliente podría mandar el bloque a
+		// donde quisiera. El de replicar solo lo da el Namenode. El token de destino lo
+		// verifica el DataNode que recibe la copia.
+		if err := verificarToken(pedidoReplicate.Token, pedidoReplicate.Bloque, protocolo.AccesoReplicar); err != nil {
+			return conexion.ResponderError(pedido, err)
+		}
+		if err := conexion.Responder(pedido, nil); err != nil {
+			return err
+		}
+		go replicate(pedidoReplicate.Bloque, pedidoReplicate.Destino, pedidoReplicate.BloqueDestino, pedidoReplicate.TokenDestino)
+		return nil
+
+	default:
+		return conexion.ResponderError(pedido, protocolo.Errorf(protocolo.CodigoInvalido, "operación desconocida: %q", pedido.Op))
 	}
 }
 
-func store(filename string, data []byte) {
-	//creo un archivo y lo guardo en la carpeta blocks/
+// store recibe el bloque de origen y lo guarda en la carpeta de bloques sin tenerlo entero
+// en memoria, mientras lo reenvía a la réplica siguiente del pipeline. Devuelve si se guardó
+// acá y las réplicas siguientes que confirmaron que lo guardaron. Solo devuelve error si
+// falla la lectura de origen; un bloque que llega dañado se descarta y la conexión se puede
+// seguir usando. El token se reenvía con el bloque a las réplicas siguientes.
+func store(filename string, size int64, origen io.Reader, siguientes []string, token string) (bool, []string, error) {
+	reenvio := abrirReenvio(filename, size, siguientes, token)
+	guardado, err := recibirBloque(filename, size, origen, reenvio)
+	if reenvio == nil {
+		return guardado, nil, err
+	}
+	if err != nil {
+		// La réplica siguiente recibe un bloque incompleto y lo descarta
+		reenvio.cerrar()
+		return false, nil, err
+	}
+	return guardado, reenvio.esperarAck(), nil
+}
+
+// recibirBloque lee el bloque y sus checksums de origen, copiándolos también al reenvío si hay,
+// y devuelve si quedó guardado en este DataNode
+func recibirBloque(filename string, size int64, origen io.Reader, reenvio *reenvio) (bool, error) {
 	log.Println("[INFO]	==> STORE en Datanode:", filename)
 
-	file, err := os.Create("blocks/" + filename)
-	if err != nil {
-		log.Println("[ERROR] Error creando archivo:", err)
-		return
+	// Si ya hay una réplica más nueva del mismo bloque, esta llegó tarde y no sirve
+	descartar := false
+	id, genStamp, conID := protocolo.ParsearBloque(filename)
+	if conID {
+		for otra, otroGenStamp := range replicasDelBloque(id) {
+			if otroGenStamp > genStamp {
+				log.Printf("[WARNING] Se descarta %s, ya existe la réplica más nueva %s\n", filename, otra)
+				descartar = true
+			}
+		}
+	}
+
+	// Se recibe en un archivo temporal y se renombra después de verificar los checksums,
+	// así nunca se sirve ni se escanea un bloque a medio escribir
+	var destino io.Writer = io.Discard
+	var file *os.File
+	temporal := filepath.Join(dirBloques, "."+filename+".tmp")
+	if !descartar {
+		var err error
+		file, err = os.Create(temporal)
+		if err != nil {
+			log.Println("[ERROR] Error creando archivo:", err)
+			descartar = true
+		} else {
+			defer os.Remove(temporal)
+			defer file.Close()
+			destino = file
+		}
+	}
+
+	// Aunque se descarte, se leen los datos para dejar la conexión lista para el próximo comando
+	calculador := protocolo.NuevoCalculadorDeChecksums()
+	escritores := []io.Writer{destino, calculador}
+	if reenvio != nil {
+		escritores = append(escritores, reenvio)
+	}
+	if _, err := io.CopyN(io.MultiWriter(escritores...), origen, size); err != nil {
+		return false, err
+	}
+	checksums := make([]byte, protocolo.CantidadDeChecksums(size)*protocolo.BytesDeChecksum)
+	if _, err := io.ReadFull(origen, checksums); err != nil {
+		return false, fmt.Errorf("error al leer los checksums del bloque: %w", err)
+	}
+	// La réplica siguiente verifica los checksums por su cuenta
+	if reenvio != nil {
+		reenvio.Write(checksums)
+	}
+	if descartar {
+		return false, nil
+	}
+
+	// Un bloque que llegó dañado no se guarda; el Namenode lo va a re-replicar
+	if err := protocolo.CompararChecksums(calculador.Checksums(), checksums); err != nil {
+		log.Printf("[ERROR] El bloque %s llegó dañado: %v\n", filename, err)
+		return false, nil
+	}
+	if err := file.Sync(); err != nil {
+		log.Println("[ERROR] Error guardando el bloque:", err)
+		return false, nil
+	}
+	if err := os.WriteFile(rutaDeChecksums(filename), checksums, 0644); err != nil {
+		log.Println("[ERROR] Error guardando los checksums:", err)
+		return false, nil
+	}
+	if err := os.Rename(temporal, filepath.Join(dirBloques, filename)); err != nil {
+		log.Println("[ERROR] Error guardando el bloque:", err)
+		return false, nil
 	}
-	file.WriteString(string(data))
 	log.Println("[INFO]	====> Archivo guardado:", filename)
-	defer file.Close()
+
+	reportarBloque(protocolo.OpBlockReceived, filename)
+
+	// Las réplicas con un generation stamp anterior quedaron viejas
+	if conID {
+		for otra, otroGenStamp := range replicasDelBloque(id) {
+			if otroGenStamp < genStamp {
+				remove(otra)
+			}
+		}
+	}
+	return true, nil
 }
 
-func read(filename string, coneccion net.Conn) {
-	//abro el archivo de la carpeta blocks/
-	log.Println("[INFO] READ en Datanode:", filename)
-	file, err := os.Open("blocks/" + filename)
+// read responde los chunks enteros que cubren el rango pedido, desde inicio, así el cliente
+// los puede verificar con sus checksums y recortar lo que sobra. Sin largo es el bloque
+// entero. Después de los datos van los checksums de lo enviado.
+func read(conexion canal, pedido *protocolo.Pedido, rango protocolo.PedidoRead) error {
+	filename := rango.Bloque
+	//abro el archivo de la carpeta de bloques
+	log.Println("[INFO] READ en Datanode:", filename, rango.Offset, rango.Largo)
+	file, blockSize, guardados, err := abrirBloque(filename)
 	if err != nil {
-		log.Println("\n[ERROR] Error abriendo archivo:", err)
-		return
+		log.Printf("[ERROR] No se puede servir el bloque %s: %v\n", filename, err)
+		return conexion.ResponderError(pedido, errorDeBloque(err))
 	}
 	defer file.Close()
 
-	buffer := make([]byte, 1024) // 1KB
-	//leo el contenido
+	offset, largo := rango.Offset, rango.Largo
+	if largo < 0 {
+		largo = blockSize - offset
+	}
+	if offset < 0 || offset > blockSize || largo < 0 {
+		return conexion.ResponderError(pedido, protocolo.Errorf(protocolo.CodigoInvalido, "rango invalido"))
+	}
+	largo = min(largo, blockSize-offset)
+	inicio := offset / protocolo.BytesPorChecksum * protocolo.BytesPorChecksum
+	fin := min(blockSize, protocolo.CantidadDeChecksums(offset+largo)*protocolo.BytesPorChecksum)
+	if guardados != nil {
+		if int64(len(guardados)) != protocolo.CantidadDeChecksums(blockSize)*protocolo.BytesDeChecksum {
+			log.Printf("[ERROR] No se puede servir el bloque %s: checksums incompletos\n", filename)
+			marcarSospechoso(filename)
+			return conexion.ResponderError(pedido, protocolo.Errorf(protocolo.CodigoBloqueCorrupto, "checksums incompletos"))
+		}
+		guardados = guardados[inicio/protocolo.BytesPorChecksum*protocolo.BytesDeChecksum : protocolo.CantidadDeChecksums(fin)*protocolo.BytesDeChecksum]
+	}
+	if _, err := file.Seek(inicio, io.SeekStart); err != nil {
+		return conexion.ResponderError(pedido, err)
+	}
+	if err := conexion.Responder(pedido, protocolo.RespuestaRead{Inicio: inicio, Largo: fin - inicio}); err != nil {
+		return err
+	}
+	return enviarChunks(conexion, pedido, filename, file, inicio, fin-inicio, guardados)
+}
 
-	n, err := file.Read(buffer)
-	if err != nil && err != io.EOF {
-		log.Println("[ERROR] Error leyendo:", err)
-		return
+// enviarChunks manda largo bytes del bloque desde la posición actual de file, calculando sus
+// checksums mientras pasan, y después los checksums guardados de esos chunks. Si el bloque
+// está dañado, en lugar de los checksums manda un error y el cliente prueba otra réplica.
+func enviarChunks(conexion canal, pedido *protocolo.Pedido, filename string, file *os.File, inicio int64, largo int64, guardados []byte) error {
+	datos := conexion.EscritorDeDatos()
+	calculador := protocolo.NuevoCalculadorDeChecksums()
+	if _, err := io.CopyN(datos, io.TeeReader(file, calculador), largo); err != nil {
+		return fmt.Errorf("error enviando el bloque: %w", err)
+	}
+	calculados := calculador.Checksums()
+	if guardados == nil {
+		log.Printf("[WARNING] El bloque %s no tiene checksums guardados, se envían los calculados\n", filename)
+		guardados = calculados
 	}
+	if err := protocolo.CompararChecksums(calculados, guardados); err != nil {
+		log.Printf("[ERROR] No se puede servir el bloque %s (desde el byte %d): %v\n", filename, inicio, err)
+		// El escáner lo vuelve a revisar y, si sigue dañado, lo pone en cuarentena
+		marcarSospechoso(filename)
+		return conexion.ResponderError(pedido, protocolo.Errorf(protocolo.CodigoBloqueCorrupto, "bloque dañado: %v", err))
+	}
+	_, err := datos.Write(guardados)
+	return err
+}
 
-	// Primero envío el tamaño del bloque
-	size := []byte(fmt.Sprintf("%d\n", n))
-	coneccion.Write(size)
+// errBloqueInvalido se contesta a los nombres que podrían escribir fuera de la carpeta de bloques
+var errBloqueInvalido = protocolo.Errorf(protocolo.CodigoInvalido, "nombre de bloque invalido")
 
-	// Luego envío exactamente los bytes leídos
-	coneccion.Write(buffer[:n])
+// errorDeBloque convierte un error al abrir o borrar un bloque en la respuesta al pedido
+func errorDeBloque(err error) error {
+	if os.IsNotExist(err) {
+		return protocolo.Errorf(protocolo.CodigoNoExiste, "no existe el bloque")
+	}
+	return err
 }
 
 func setupLog() {
@@ -145,12 +443,14 @@ func setupLog() {
 	log.SetFlags(log.LstdFlags | log.Lshortfile) // fecha, hora y línea de código
 }
 
-func remove(fileName string) {
+func remove(fileName string) error {
 	log.Println("[INFO] RM en Datanode:", fileName)
-	err := os.Remove("blocks/" + fileName)
-	if err != nil {
+	if err := borrarBloque(fileName); err != nil {
 		log.Println("[ERROR] Error eliminando archivo:", err)
-		return
+		return err
 	}
 	log.Println("[INFO] Archivo eliminado:", fileName)
+
+	reportarBloque(protocolo.OpBlockDeleted, fileName)
+	return nil
 }
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/UriNoHi/Distributed-file-system-DFS-/protocolo"
+)
+
+// nombreDeBloqueValido rechaza nombres que podrían escribir fuera de la carpeta de bloques
+// o pisar el archivo de checksums de otro bloque
+func nombreDeBloqueValido(nombre string) bool {
+	return nombre != "" && !strings.HasPrefix(nombre, ".") && !strings.ContainsAny(nombre, `/\`) && !esArchivoDeChecksums(nombre)
+}
+
+// replicasDelBloque devuelve las réplicas guardadas de un ID con su generation stamp
+func replicasDelBloque(id int64) map[string]int64 {
+	replicas := map[string]int64{}
+	nombres, _ := filepath.Glob(filepath.Join(dirBloques, fmt.Sprintf("blk_%d_*", id)))
+	for _, nombre := range nombres {
+		nombre = filepath.Base(nombre)
+		if otroID, genStamp, ok := protocolo.ParsearBloque(nombre); ok && otroID == id {
+			replicas[nombre] = genStamp
+		}
+	}
+	return replicas
+}
+
+// descartarReplicasViejas borra, de cada ID, las réplicas con un generation stamp
+// anterior al más nuevo que haya en la carpeta. Se llama al iniciar, antes del
+// primer reporte completo, así el Namenode nunca ve las dos versiones.
+func descartarReplicasViejas() {
+	masNuevo := map[int64]int64{}
+	for _, bloque := range listarBloques() {
+		if id, genStamp, ok := protocolo.ParsearBloque(bloque); ok && genStamp > masNuevo[id] {
+			masNuevo[id] = genStamp
+		}
+	}
+	for _, bloque := range listarBloques() {
+		if id, genStamp, ok := protocolo.ParsearBloque(bloque); ok && genStamp < masNuevo[id] {
+			log.Printf("[WARNING] Réplica vieja %s, hay una con generation stamp %d\n", bloque, masNuevo[id])
+			if err := borrarBloque(bloque); err != nil {
+				log.Println("[ERROR] Error eliminando réplica vieja:", err)
+			}
+		}
+	}
+}
+
+// borrarBloque borra la réplica y su archivo de checksums, que puede no existir
+func borrarBloque(fileName string) error {
+	if err := os.Remove(filepath.Join(dirBloques, fileName)); err != nil {
+		return err
+	}
+	if err := os.Remove(rutaDeChecksums(fileName)); err != nil && !os.IsNotExist(err) {
+		log.Println("[ERROR] Error eliminando checksums:", err)
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/UriNoHi/Distributed-file-system-DFS-/protocolo"
+)
+
+// Los checksums de cada chunk del bloque (ver protocolo.CalculadorDeChecksums) se guardan
+// al lado, en "<bloque>.meta".
+const extensionChecksums = ".meta"
+
+func esArchivoDeChecksums(nombre string) bool {
+	return strings.HasSuffix(nombre, extensionChecksums)
+}
+
+func rutaDeChecksums(bloque string) string {
+	return filepath.Join(dirBloques, bloque+extensionChecksums)
+}
+
+// leerChecksums devuelve los checksums guardados del bloque. Los bloques guardados
+// antes de los checksums no tienen .meta y devuelven nil sin error.
+func leerChecksums(bloque string) ([]byte, error) {
+	checksums, err := os.ReadFile(rutaDeChecksums(bloque))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	return checksums, err
+}
+
+// abrirBloque abre un bloque para leerlo y devuelve su tamaño y sus checksums guardados
+func abrirBloque(bloque string) (*os.File, int64, []byte, error) {
+	guardados, err := leerChecksums(bloque)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	file, err := os.Open(filepath.Join(dirBloques, bloque))
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, 0, nil, err
+	}
+	return file, info.Size(), guardados, nil
+}
+
+// verificarBloque vuelve a leer el bloque del disco y lo compara contra sus checksums
+func verificarBloque(bloque string) error {
+	guardados, err := leerChecksums(bloque)
+	if err != nil || guardados == nil {
+		return err
+	}
+	file, err := os.Open(filepath.Join(dirBloques, bloque))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	calculador := protocolo.NuevoCalculadorDeChecksums()
+	if _, err := io.Copy(calculador, file); err != nil {
+		return err
+	}
+	return protocolo.CompararChecksums(calculador.Checksums(), guardados)
+}
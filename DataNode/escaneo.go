@@ -0,0 +1,119 @@
+package main
+
+import (
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/UriNoHi/Distributed-file-system-DFS-/protocolo"
+)
+
+const (
+	velocidadEscaneoPorDefecto = 1024             // KB por segundo que lee el escáner
+	pausaEntreEscaneos         = 10 * time.Minute // espera entre una pasada completa y la siguiente
+	graciaDeEscritura          = 30 * time.Second // no se revisan bloques que se pueden estar escribiendo
+	carpetaCuarentena          = "cuarentena"
+)
+
+// sospechosos son bloques que fallaron al leerse y se revisan antes que el resto
+var sospechosos = make(chan string, 100)
+
+func marcarSospechoso(bloque string) {
+	select {
+	case sospechosos <- bloque:
+	default:
+		// Si la cola está llena lo encuentra la próxima pasada completa
+	}
+}
+
+// escanearBloques vuelve a leer todos los bloques de la carpeta, sin pasar de velocidad KB/s,
+// y verifica sus checksums. Una réplica dañada se mueve a la cuarentena y se reporta al
+// Namenode, que la re-replica desde una copia sana. Con velocidad 0 el escáner no corre.
+func escanearBloques(velocidad int) {
+	if velocidad <= 0 {
+		log.Println("[INFO] Escáner de bloques desactivado")
+		return
+	}
+	log.Printf("[INFO] Escáner de bloques a %d KB/s\n", velocidad)
+	for {
+		escanearUnaVez(velocidad)
+
+		espera := time.After(pausaEntreEscaneos)
+	pausa:
+		for {
+			select {
+			case bloque := <-sospechosos:
+				revisarBloque(bloque, velocidad)
+			case <-espera:
+				break pausa
+			}
+		}
+	}
+}
+
+// escanearUnaVez revisa una vez cada bloque de la carpeta, y los sospechosos entre uno y otro
+func escanearUnaVez(velocidad int) (revisados int, corruptos int) {
+	inicio := time.Now()
+	for _, bloque := range listarBloques() {
+		revisarSospechosos(velocidad)
+		if !revisarBloque(bloque, velocidad) {
+			corruptos++
+		}
+		revisados++
+	}
+	log.Printf("[INFO] Escaneo completo: %d bloques revisados, %d dañados, en %v\n", revisados, corruptos, time.Since(inicio).Round(time.Second))
+	return revisados, corruptos
+}
+
+func revisarSospechosos(velocidad int) {
+	for {
+		select {
+		case bloque := <-sospechosos:
+			revisarBloque(bloque, velocidad)
+		default:
+			return
+		}
+	}
+}
+
+// revisarBloque verifica un bloque y devuelve false si estaba dañado
+func revisarBloque(bloque string, velocidad int) bool {
+	info, err := os.Stat(filepath.Join(dirBloques, bloque))
+	if err != nil || time.Since(info.ModTime()) < graciaDeEscritura {
+		// Se borró mientras tanto o se está escribiendo
+		return true
+	}
+
+	err = verificarBloque(bloque)
+	// Se limita la velocidad para no competir con los clientes por el disco
+	time.Sleep(time.Duration(info.Size()) * time.Second / time.Duration(velocidad*1024))
+	if err == nil {
+		return true
+	}
+	if os.IsNotExist(err) {
+		return true
+	}
+	log.Printf("[ERROR] Réplica dañada %s: %v\n", bloque, err)
+	ponerEnCuarentena(bloque)
+	return false
+}
+
+// ponerEnCuarentena saca el bloque y sus checksums de la carpeta de bloques, así no se sirve
+// ni se reporta más, pero queda guardado por si hace falta revisarlo a mano
+func ponerEnCuarentena(bloque string) {
+	cuarentena := filepath.Join(dirBloques, carpetaCuarentena)
+	if err := os.MkdirAll(cuarentena, 0755); err != nil {
+		log.Println("[ERROR] No se pudo crear la carpeta de cuarentena:", err)
+		return
+	}
+	if err := os.Rename(filepath.Join(dirBloques, bloque), filepath.Join(cuarentena, bloque)); err != nil {
+		log.Println("[ERROR] No se pudo poner en cuarentena el bloque:", err)
+		return
+	}
+	if err := os.Rename(rutaDeChecksums(bloque), filepath.Join(cuarentena, bloque+extensionChecksums)); err != nil && !os.IsNotExist(err) {
+		log.Println("[ERROR] No se pudieron mover los checksums a la cuarentena:", err)
+	}
+	log.Printf("[WARNING] Bloque %s en cuarentena\n", bloque)
+	reportarBloque(protocolo.OpBlockCorrupt, bloque)
+}
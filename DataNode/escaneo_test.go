@@ -0,0 +1,115 @@
+package main
+
+import (
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/UriNoHi/Distributed-file-system-DFS-/protocolo"
+)
+
+func TestMain(m *testing.M) {
+	log.SetOutput(io.Discard)
+	os.Exit(m.Run())
+}
+
+// carpetaDeBloques usa una carpeta de bloques vacía mientras dura el test
+func carpetaDeBloques(t *testing.T) {
+	anterior := dirBloques
+	dirBloques = t.TempDir()
+	t.Cleanup(func() { dirBloques = anterior })
+}
+
+// guardarBloqueDePrueba guarda el bloque con sus checksums, escrito hace más que la gracia
+// de escritura para que el escáner lo revise
+func guardarBloqueDePrueba(t *testing.T, bloque string, datos []byte) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dirBloques, bloque), datos, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(rutaDeChecksums(bloque), checksumsDe(datos), 0644); err != nil {
+		t.Fatal(err)
+	}
+	envejecer(t, bloque)
+}
+
+func envejecer(t *testing.T, bloque string) {
+	t.Helper()
+	antes := time.Now().Add(-2 * graciaDeEscritura)
+	if err := os.Chtimes(filepath.Join(dirBloques, bloque), antes, antes); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// danarByte invierte el byte en offset del bloque en el disco, sin tocar sus checksums
+func danarByte(t *testing.T, bloque string, offset int64) {
+	t.Helper()
+	file, err := os.OpenFile(filepath.Join(dirBloques, bloque), os.O_RDWR, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer envejecer(t, bloque)
+	defer file.Close()
+	b := make([]byte, 1)
+	if _, err := file.ReadAt(b, offset); err != nil {
+		t.Fatal(err)
+	}
+	b[0] ^= 0xff
+	if _, err := file.WriteAt(b, offset); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func checksumsDe(datos []byte) []byte {
+	calculador := protocolo.NuevoCalculadorDeChecksums()
+	calculador.Write(datos)
+	return calculador.Checksums()
+}
+
+// reportesPendientes saca de la cola los reportes que todavía no mandó el heartbeat
+func reportesPendientes() []reporteIncremental {
+	reportes := []reporteIncremental{}
+	for {
+		select {
+		case reporte := <-reportesIncrementales:
+			reportes = append(reportes, reporte)
+		default:
+			return reportes
+		}
+	}
+}
+
+// Una pasada del escáner encuentra el chunk dañado, pone el bloque en cuarentena con sus
+// checksums y lo reporta; el bloque sano queda donde estaba
+func TestEscaneoDeBloqueDanado(t *testing.T) {
+	carpetaDeBloques(t)
+	reportesPendientes()
+	datos := make([]byte, 3*protocolo.BytesPorChecksum+10)
+	for i := range datos {
+		datos[i] = byte(i)
+	}
+	const sano, danado = "blk_1_1", "blk_2_1"
+	guardarBloqueDePrueba(t, sano, datos)
+	guardarBloqueDePrueba(t, danado, datos)
+	danarByte(t, danado, 2*protocolo.BytesPorChecksum+5)
+
+	revisados, corruptos := escanearUnaVez(1 << 30)
+	if revisados != 2 || corruptos != 1 {
+		t.Errorf("escaneo: %d revisados y %d dañados, se esperaban 2 y 1", revisados, corruptos)
+	}
+	if bloques := listarBloques(); len(bloques) != 1 || bloques[0] != sano {
+		t.Errorf("bloques después del escaneo: %v, se esperaba solo %s", bloques, sano)
+	}
+	for _, archivo := range []string{danado, danado + extensionChecksums} {
+		if _, err := os.Stat(filepath.Join(dirBloques, carpetaCuarentena, archivo)); err != nil {
+			t.Errorf("%s no quedó en la cuarentena: %v", archivo, err)
+		}
+	}
+	reportes := reportesPendientes()
+	if len(reportes) != 1 || reportes[0] != (reporteIncremental{tipo: protocolo.OpBlockCorrupt, bloque: danado}) {
+		t.Errorf("reportes: %+v, se esperaba %s de %s", reportes, protocolo.OpBlockCorrupt, danado)
+	}
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"context"
+	"io"
+	"log"
+	"net"
+
+	"github.com/UriNoHi/Distributed-file-system-DFS-/protocolo"
+	"github.com/UriNoHi/Distributed-file-system-DFS-/protocolo/rpc"
+)
+
+// servidorRPC atiende el servicio gRPC del DataNode con atenderPedido, igual que el protocolo
+// de frames: cada llamada se convierte en el pedido equivalente y se contesta por una llamadaRPC
+type servidorRPC struct {
+	rpc.UnimplementedDataNodeServer
+	miDireccion string
+}
+
+func servirRPC(socket net.Listener, miDireccion string) error {
+	servidor := rpc.NuevoServidor(configTLS)
+	rpc.RegisterDataNodeServer(servidor, servidorRPC{miDireccion: miDireccion})
+	return servidor.Serve(socket)
+}
+
+// atender atiende la llamada como el pedido op y devuelve lo que se contestó, convertido en R
+func atender[R any](ctx context.Context, llamada *llamadaRPC, op string, mensaje any, miDireccion string) (*R, error) {
+	pedido, err := rpc.NuevoPedido(ctx, op, mensaje)
+	if err != nil {
+		return nil, rpc.Status(err)
+	}
+	log.Printf("[INFO] Pedido gRPC recibido: %s %s\n", op, pedido.Cuerpo)
+	if err := atenderPedido(llamada, pedido, miDireccion); err != nil {
+		log.Printf("[ERROR] Pedido gRPC (%s): %v\n", op, err)
+		return nil, rpc.Status(err)
+	}
+	if llamada.err != nil {
+		return nil, rpc.Status(llamada.err)
+	}
+	var r R
+	if err := rpc.Convertir(llamada.respuesta, &r); err != nil {
+		return nil, rpc.Status(err)
+	}
+	return &r, nil
+}
+
+func (s servidorRPC) Store(stream rpc.DataNode_StoreServer) error {
+	primero, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	llamada := &llamadaRPC{pendiente: primero.Datos, recibir: func() ([]byte, error) {
+		fragmento, err := stream.Recv()
+		if err != nil {
+			return nil, err
+		}
+		return fragmento.Datos, nil
+	}}
+	ack, err := atender[rpc.RespuestaStore](stream.Context(), llamada, protocolo.OpStore, primero.Pedido, s.miDireccion)
+	if err != nil {
+		return err
+	}
+	return stream.SendAndClose(ack)
+}
+
+func (s servidorRPC) Read(p *rpc.PedidoRead, stream rpc.DataNode_ReadServer) error {
+	llamada := &llamadaRPC{enviar: stream.Send}
+	_, err := atender[rpc.RespuestaRead](stream.Context(), llamada, protocolo.OpReadBlock, p, s.miDireccion)
+	return err
+}
+
+func (s servidorRPC) Rm(ctx context.Context, p *rpc.PedidoBloque) (*rpc.Vacio, error) {
+	return atender[rpc.Vacio](ctx, &llamadaRPC{}, protocolo.OpRmBlock, p, s.miDireccion)
+}
+
+func (s servidorRPC) Replicate(ctx context.Context, p *rpc.PedidoReplicate) (*rpc.Vacio, error) {
+	return atender[rpc.Vacio](ctx, &llamadaRPC{}, protocolo.OpReplicate, p, s.miDireccion)
+}
+
+// llamadaRPC es el canal de una llamada gRPC. La respuesta y el error se guardan para
+// devolverlos al terminar, salvo en Read, donde la respuesta con el rango sale en el primer
+// fragmento, antes de los datos.
+type llamadaRPC struct {
+	respuesta any
+	err       error
+
+	pendiente []byte                 // datos del store ya recibidos que no se leyeron
+	recibir   func() ([]byte, error) // datos del fragmento siguiente del store
+	enviar    func(*rpc.FragmentoRead) error
+}
+
+func (l *llamadaRPC) Datos() io.Reader { return lectorDeLlamada{l} }
+
+func (l *llamadaRPC) EscritorDeDatos() io.Writer { return escritorDeLlamada{l} }
+
+func (l *llamadaRPC) Responder(_ *protocolo.Pedido, cuerpo any) error {
+	l.respuesta = cuerpo
+	if l.enviar == nil {
+		return nil
+	}
+	var rango rpc.RespuestaRead
+	if err := rpc.Convertir(cuerpo, &rango); err != nil {
+		return err
+	}
+	return l.enviar(&rpc.FragmentoRead{Respuesta: &rango})
+}
+
+func (l *llamadaRPC) ResponderError(_ *protocolo.Pedido, err error) error {
+	l.err = err
+	return nil
+}
+
+type lectorDeLlamada struct{ l *llamadaRPC }
+
+func (r lectorDeLlamada) Read(p []byte) (int, error) {
+	l := r.l
+	for len(l.pendiente) == 0 {
+		if l.recibir == nil {
+			return 0, io.EOF
+		}
+		datos, err := l.recibir()
+		if err != nil {
+			// Quien lee sabe cuántos bytes esperar: si el cliente cerró antes es un corte
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return 0, err
+		}
+		l.pendiente = datos
+	}
+	n := copy(p, l.pendiente)
+	l.pendiente = l.pendiente[n:]
+	return n, nil
+}
+
+type escritorDeLlamada struct{ l *llamadaRPC }
+
+func (w escritorDeLlamada) Write(p []byte) (int, error) {
+	if w.l.enviar == nil {
+		return 0, io.ErrClosedPipe
+	}
+	if err := w.l.enviar(&rpc.FragmentoRead{Datos: p}); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
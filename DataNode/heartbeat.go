@@ -0,0 +1,160 @@
+package main
+
+import (
+	"errors"
+	"log"
+	"os"
+	"time"
+
+	"github.com/UriNoHi/Distributed-file-system-DFS-/protocolo"
+)
+
+const (
+	intervaloHeartbeat  = 3 * time.Second
+	capacidadPorDefecto = 1 << 30 // 1GB disponibles para bloques
+)
+
+// reporteIncremental avisa al Namenode que un bloque se guardó o se borró en este DataNode
+type reporteIncremental struct {
+	tipo   string // protocolo.OpBlockReceived, OpBlockDeleted u OpBlockCorrupt
+	bloque string
+}
+
+// store y remove dejan acá sus reportes; los manda el loop de heartbeats por su conexión
+var reportesIncrementales = make(chan reporteIncremental, 1000)
+
+func reportarBloque(tipo string, bloque string) {
+	select {
+	case reportesIncrementales <- reporteIncremental{tipo: tipo, bloque: bloque}:
+	default:
+		// Si la cola está llena se pierde el reporte, el próximo reporte completo lo corrige
+		log.Println("[WARNING] Cola de reportes llena, se descarta el reporte de", bloque)
+	}
+}
+
+// enviarHeartbeats avisa periódicamente al Namenode que este DataNode sigue vivo,
+// junto con su capacidad, el espacio usado y la cantidad de bloques guardados.
+// Por la misma conexión manda el reporte completo de bloques al conectarse
+// y los reportes incrementales después de cada store/rm.
+func enviarHeartbeats(namenodeAddr string, miDireccion string, storageID string) {
+	var conexion protocolo.Cliente
+
+	ticker := time.NewTicker(intervaloHeartbeat)
+	defer ticker.Stop()
+
+	for {
+		if conexion == nil {
+			var err error
+			conexion, err = conectarNamenode(namenodeAddr)
+			if err != nil {
+				log.Println("[WARNING] No se pudo conectar al Namenode para el heartbeat:", err)
+				conexion = nil
+				time.Sleep(intervaloHeartbeat)
+				continue
+			}
+			log.Println("[INFO] Conectado al Namenode", namenodeAddr)
+			if err := sincronizar(conexion, miDireccion, storageID); err != nil {
+				log.Println("[WARNING] No se pudo enviar el reporte de bloques:", err)
+				conexion.Close()
+				conexion = nil
+				time.Sleep(intervaloHeartbeat)
+				continue
+			}
+		}
+
+		var err error
+		select {
+		case <-ticker.C:
+			used, blocks := usoDeBloques()
+			heartbeat := protocolo.PedidoHeartbeat{Direccion: miDireccion, Capacidad: capacidadPorDefecto, Usado: used, Bloques: blocks, Credencial: credencial(protocolo.OpHeartbeat, miDireccion)}
+			var respuesta protocolo.RespuestaClaves
+			if err = conexion.Pedir(protocolo.OpHeartbeat, heartbeat, &respuesta); err == nil {
+				actualizarClaves(respuesta.Claves)
+			}
+		case reporte := <-reportesIncrementales:
+			incremental := protocolo.PedidoReporteIncremental{Direccion: miDireccion, Bloque: reporte.bloque, Credencial: credencial(reporte.tipo, miDireccion)}
+			err = conexion.Pedir(reporte.tipo, incremental, nil)
+		}
+
+		var rechazo *protocolo.Error
+		if errors.As(err, &rechazo) {
+			log.Println("[WARNING] El Namenode rechazó el mensaje:", rechazo)
+			err = nil
+			// Si el Namenode se reinició no nos conoce: volver a registrarse y reportar todo
+			if rechazo.Codigo == protocolo.CodigoNodoDesconocido {
+				err = enviarRegistro(conexion, miDireccion, storageID)
+				if err == nil {
+					err = enviarReporteCompleto(conexion, miDireccion)
+				}
+			}
+		}
+		if err != nil {
+			log.Println("[WARNING] Se perdió la conexión con el Namenode:", err)
+			conexion.Close()
+			conexion = nil
+		}
+	}
+}
+
+// sincronizar manda el reporte completo al conectarse. Si el Namenode se reinició y no
+// conoce a este DataNode, primero vuelve a registrarse.
+func sincronizar(conexion protocolo.Cliente, miDireccion string, storageID string) error {
+	err := enviarReporteCompleto(conexion, miDireccion)
+	if err == nil || protocolo.CodigoDe(err) != protocolo.CodigoNodoDesconocido {
+		return err
+	}
+	log.Println("[INFO] El Namenode no conoce este DataNode, registrándose de nuevo")
+	if err := enviarRegistro(conexion, miDireccion, storageID); err != nil {
+		return err
+	}
+	return enviarReporteCompleto(conexion, miDireccion)
+}
+
+// enviarReporteCompleto manda todos los bloques guardados
+func enviarReporteCompleto(conexion protocolo.Cliente, miDireccion string) error {
+	bloques := listarBloques()
+	log.Printf("[INFO] Enviando reporte completo con %d bloques\n", len(bloques))
+	completo := protocolo.PedidoReporteCompleto{Direccion: miDireccion, Bloques: bloques, Credencial: credencial(protocolo.OpBlockReport, miDireccion)}
+	return conexion.Pedir(protocolo.OpBlockReport, completo, nil)
+}
+
+// listarBloques devuelve los bloques guardados, sin checksums ni archivos a medio recibir
+func listarBloques() []string {
+	entries, err := os.ReadDir(dirBloques)
+	if err != nil {
+		log.Println("[ERROR] Error leyendo carpeta de bloques:", err)
+		return nil
+	}
+	bloques := []string{}
+	for _, entry := range entries {
+		if !entry.IsDir() && nombreDeBloqueValido(entry.Name()) {
+			bloques = append(bloques, entry.Name())
+		}
+	}
+	return bloques
+}
+
+// usoDeBloques devuelve los bytes ocupados y la cantidad de bloques en la carpeta de bloques
+func usoDeBloques() (int64, int) {
+	entries, err := os.ReadDir(dirBloques)
+	if err != nil {
+		log.Println("[ERROR] Error leyendo carpeta de bloques:", err)
+		return 0, 0
+	}
+	var used int64
+	blocks := 0
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		used += info.Size()
+		if !esArchivoDeChecksums(entry.Name()) {
+			blocks++
+		}
+	}
+	return used, blocks
+}
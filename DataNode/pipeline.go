@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+	"log"
+	"net"
+	"time"
+
+	"github.com/UriNoHi/Distributed-file-system-DFS-/protocolo"
+	"github.com/UriNoHi/Distributed-file-system-DFS-/protocolo/rpc"
+)
+
+// Cuánto se espera la confirmación de la réplica siguiente después de mandarle el bloque entero
+const timeoutAck = 60 * time.Second
+
+// reenvio manda a la réplica siguiente del pipeline lo que recibe este DataNode, mientras lo
+// guarda. Si la siguiente falla se deja de reenviar, pero la escritura local sigue.
+type reenvio struct {
+	conexion protocolo.Cliente
+	envio    protocolo.Envio
+	destino  string
+	err      error
+}
+
+// abrirReenvio se conecta a la primera réplica de siguientes que responda y le manda el store
+// con el resto del pipeline y el mismo token. Devuelve nil si no queda ninguna a quién reenviar.
+func abrirReenvio(bloque string, size int64, siguientes []string, token string) *reenvio {
+	for i, dataNode := range siguientes {
+		conexion, err := conectarDataNode(dataNode)
+		if err != nil {
+			log.Printf("[WARNING] No se pudo conectar con %s para el pipeline, se saltea: %v\n", dataNode, err)
+			continue
+		}
+		envio, err := conexion.Guardar(protocolo.PedidoStore{Bloque: bloque, Size: size, Siguientes: siguientes[i+1:], Token: token})
+		if err != nil {
+			log.Printf("[WARNING] No se pudo iniciar el pipeline con %s, se saltea: %v\n", dataNode, err)
+			conexion.Close()
+			continue
+		}
+		return &reenvio{conexion: conexion, envio: envio, destino: dataNode}
+	}
+	return nil
+}
+
+func (r *reenvio) Write(p []byte) (int, error) {
+	if r.err == nil {
+		if _, r.err = r.envio.Write(p); r.err != nil {
+			log.Printf("[WARNING] Se cortó el pipeline hacia %s: %v\n", r.destino, r.err)
+		}
+	}
+	return len(p), nil
+}
+
+// esperarAck devuelve las réplicas que confirmaron el bloque más adelante en el pipeline
+func (r *reenvio) esperarAck() []string {
+	defer r.conexion.Close()
+	if r.err != nil {
+		return nil
+	}
+	r.conexion.SetDeadline(time.Now().Add(timeoutAck))
+	ack, err := r.envio.Esperar()
+	if err != nil {
+		log.Printf("[WARNING] No llegó la confirmación de %s: %v\n", r.destino, err)
+		return nil
+	}
+	return ack.Durables
+}
+
+func (r *reenvio) cerrar() {
+	r.conexion.Close()
+}
+
+// conectarDataNode abre una conexión con otro DataNode por gRPC o, con -legacy, por el
+// protocolo de frames
+func conectarDataNode(address string) (protocolo.Cliente, error) {
+	if !legacy {
+		return rpc.ConectarDataNode(address, configTLS)
+	}
+	conn, err := protocolo.Dial(context.Background(), &net.Dialer{}, address, configTLS)
+	if err != nil {
+		return nil, err
+	}
+	conexion, err := protocolo.Conectar(conn)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return conexion, nil
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/UriNoHi/Distributed-file-system-DFS-/protocolo"
+)
+
+// recibido es lo que le llegó a un DataNode de mentira por el pipeline
+type recibido struct {
+	pedido protocolo.PedidoStore
+	datos  []byte // los datos del bloque seguidos de sus checksums
+}
+
+// dataNodeFalso atiende un solo store con el protocolo de frames: lee hasta leer bytes de
+// lo que llega y, si leyó todo, contesta con durables. Si no, corta la conexión, como un
+// DataNode que se cae a mitad del bloque. Lo que recibió llega por el canal.
+func dataNodeFalso(t *testing.T, leer int64, durables []string) (string, <-chan recibido) {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+	llegada := make(chan recibido, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		conexion, err := protocolo.Aceptar(conn)
+		if err != nil {
+			return
+		}
+		pedido, err := conexion.LeerPedido()
+		if err != nil {
+			return
+		}
+		var r recibido
+		pedido.Leer(&r.pedido)
+		total := r.pedido.Size + protocolo.CantidadDeChecksums(r.pedido.Size)*protocolo.BytesDeChecksum
+		r.datos = make([]byte, min(leer, total))
+		io.ReadFull(conexion.Datos(), r.datos)
+		llegada <- r
+		if leer >= total {
+			conexion.Responder(pedido, protocolo.RespuestaStore{Durables: durables})
+		}
+	}()
+	return listener.Addr().String(), llegada
+}
+
+// pipelineDePrueba usa el protocolo de frames y una carpeta de bloques vacía, y devuelve un
+// bloque con sus checksums detrás, como lo manda el cliente
+func pipelineDePrueba(t *testing.T) ([]byte, []byte) {
+	carpetaDeBloques(t)
+	anterior := legacy
+	legacy = true
+	t.Cleanup(func() { legacy = anterior })
+	datos := make([]byte, 2*protocolo.BytesPorChecksum+100)
+	for i := range datos {
+		datos[i] = byte(i % 251)
+	}
+	return datos, append(append([]byte{}, datos...), checksumsDe(datos)...)
+}
+
+func comprobarGuardado(t *testing.T, bloque string, datos []byte) {
+	t.Helper()
+	if guardado, err := os.ReadFile(filepath.Join(dirBloques, bloque)); err != nil || !bytes.Equal(guardado, datos) {
+		t.Errorf("el bloque no quedó guardado acá: %d bytes, %v", len(guardado), err)
+	}
+}
+
+// El bloque se guarda y se reenvía entero, con sus checksums, el resto del pipeline y el
+// token, a la réplica siguiente; su confirmación vuelve con las réplicas que lo guardaron
+func TestPipelineReenvia(t *testing.T) {
+	datos, enviado := pipelineDePrueba(t)
+	const tercera = "127.0.0.1:3"
+	siguiente, llegada := dataNodeFalso(t, int64(len(enviado)), []string{"dn2", tercera})
+
+	guardado, durables, err := store("blk_1_1", int64(len(datos)), bytes.NewReader(enviado), []string{siguiente, tercera}, "token")
+	if err != nil || !guardado {
+		t.Fatalf("store: guardado %v, error %v", guardado, err)
+	}
+	if !reflect.DeepEqual(durables, []string{"dn2", tercera}) {
+		t.Errorf("durables = %v, se esperaban las que confirmó la siguiente", durables)
+	}
+	comprobarGuardado(t, "blk_1_1", datos)
+	r := <-llegada
+	esperado := protocolo.PedidoStore{Bloque: "blk_1_1", Size: int64(len(datos)), Siguientes: []string{tercera}, Token: "token"}
+	if !reflect.DeepEqual(r.pedido, esperado) {
+		t.Errorf("la siguiente recibió %+v, se esperaba %+v", r.pedido, esperado)
+	}
+	if !bytes.Equal(r.datos, enviado) {
+		t.Errorf("la siguiente recibió %d bytes distintos de los %d enviados", len(r.datos), len(enviado))
+	}
+}
+
+// Una réplica que no responde se saltea y el pipeline sigue desde la próxima
+func TestPipelineSalteaReplicaCaida(t *testing.T) {
+	datos, enviado := pipelineDePrueba(t)
+	caida, _ := net.Listen("tcp", "127.0.0.1:0")
+	caida.Close()
+	siguiente, llegada := dataNodeFalso(t, int64(len(enviado)), []string{"dn3"})
+
+	_, durables, err := store("blk_1_1", int64(len(datos)), bytes.NewReader(enviado), []string{caida.Addr().String(), siguiente}, "token")
+	if err != nil || !reflect.DeepEqual(durables, []string{"dn3"}) {
+		t.Errorf("store: durables %v, error %v", durables, err)
+	}
+	if r := <-llegada; len(r.pedido.Siguientes) != 0 {
+		t.Errorf("la última réplica recibió siguientes %v", r.pedido.Siguientes)
+	}
+}
+
+// Si la réplica siguiente se cae a mitad del bloque, este DataNode lo guarda igual y su
+// confirmación no incluye a la siguiente ni a las que venían después: así el cliente sabe
+// desde qué réplica falló el pipeline
+func TestPipelineFallaEnElMedio(t *testing.T) {
+	datos, enviado := pipelineDePrueba(t)
+	siguiente, llegada := dataNodeFalso(t, protocolo.BytesPorChecksum, nil)
+
+	guardado, durables, err := store("blk_1_1", int64(len(datos)), bytes.NewReader(enviado), []string{siguiente, "127.0.0.1:3"}, "token")
+	if err != nil || !guardado {
+		t.Fatalf("store: guardado %v, error %v", guardado, err)
+	}
+	if len(durables) != 0 {
+		t.Errorf("durables = %v con la siguiente caída", durables)
+	}
+	comprobarGuardado(t, "blk_1_1", datos)
+	if r := <-llegada; !bytes.Equal(r.datos, enviado[:protocolo.BytesPorChecksum]) {
+		t.Errorf("la siguiente recibió datos distintos antes de caerse")
+	}
+}
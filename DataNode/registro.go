@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/UriNoHi/Distributed-file-system-DFS-/protocolo"
+	"github.com/UriNoHi/Distributed-file-system-DFS-/protocolo/rpc"
+)
+
+// obtenerStorageID lee el identificador de almacenamiento de este DataNode.
+// Se genera la primera vez y se guarda en disco para que sobreviva reinicios.
+func obtenerStorageID(puerto string) string {
+	archivo := "storageID_" + puerto
+	data, err := os.ReadFile(archivo)
+	if err == nil && strings.TrimSpace(string(data)) != "" {
+		return strings.TrimSpace(string(data))
+	}
+
+	random := make([]byte, 8)
+	if _, err := rand.Read(random); err != nil {
+		log.Fatalf("No se pudo generar el storage ID: %v", err)
+	}
+	storageID := "DS-" + hex.EncodeToString(random)
+	if err := os.WriteFile(archivo, []byte(storageID+"\n"), 0644); err != nil {
+		log.Println("[ERROR] Error guardando el storage ID:", err)
+	}
+	log.Println("[INFO] Nuevo storage ID generado:", storageID)
+	return storageID
+}
+
+// registrarEnNamenode abre una conexión con el Namenode solo para anunciarse al iniciar.
+// Si el Namenode no está disponible, el loop de heartbeats vuelve a intentar el registro.
+func registrarEnNamenode(namenodeAddr string, miDireccion string, storageID string) {
+	conexion, err := conectarNamenode(namenodeAddr)
+	if err != nil {
+		log.Println("[WARNING] No se pudo conectar al Namenode para registrarse:", err)
+		return
+	}
+	defer conexion.Close()
+
+	if err := enviarRegistro(conexion, miDireccion, storageID); err != nil {
+		log.Println("[WARNING] No se pudo registrar en el Namenode:", err)
+		return
+	}
+	log.Printf("[INFO] Registrado en el Namenode %s como %s\n", namenodeAddr, miDireccion)
+}
+
+func enviarRegistro(conexion protocolo.Cliente, miDireccion string, storageID string) error {
+	registro := protocolo.PedidoRegistro{Direccion: miDireccion, StorageID: storageID, Capacidad: capacidadPorDefecto, Credencial: credencial(protocolo.OpRegister, miDireccion)}
+	var respuesta protocolo.RespuestaClaves
+	if err := conexion.Pedir(protocolo.OpRegister, registro, &respuesta); err != nil {
+		return err
+	}
+	actualizarClaves(respuesta.Claves)
+	return nil
+}
+
+// credencial firma el mensaje op de este DataNode con el secreto del cluster
+func credencial(op string, miDireccion string) string {
+	return protocolo.FirmarCredencial(op, miDireccion, secretoDelCluster, time.Now())
+}
+
+// conectarNamenode abre una conexión con el Namenode por gRPC o, con -legacy, por el
+// protocolo de frames
+func conectarNamenode(namenodeAddr string) (protocolo.Cliente, error) {
+	if !legacy {
+		return rpc.ConectarNamenode(namenodeAddr, configTLS)
+	}
+	conn, err := protocolo.Dial(context.Background(), &net.Dialer{}, namenodeAddr, configTLS)
+	if err != nil {
+		return nil, err
+	}
+	conexion, err := protocolo.Conectar(conn)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return conexion, nil
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"io"
+	"log"
+	"time"
+
+	"github.com/UriNoHi/Distributed-file-system-DFS-/protocolo"
+)
+
+// replicate copia un bloque local a otro DataNode usando el mismo pedido store que el cliente.
+// El destino avisa al Namenode con su reporte incremental cuando termina de guardarlo
+// y contesta con el mismo ack que el pipeline de escritura. El Namenode manda con la orden el
+// token de escritura que pide el destino.
+func replicate(bloque string, destino string, bloqueDestino string, tokenDestino string) {
+	log.Printf("[INFO] REPLICATE en Datanode: %s -> %s (%s)\n", bloque, destino, bloqueDestino)
+
+	file, size, guardados, err := abrirBloque(bloque)
+	if err != nil {
+		log.Println("[ERROR] Error leyendo bloque a replicar:", err)
+		return
+	}
+	defer file.Close()
+
+	conexion, err := conectarDataNode(destino)
+	if err != nil {
+		log.Println("[ERROR] Error al conectar con el Datanode destino:", err)
+		return
+	}
+	defer conexion.Close()
+
+	datos, err := conexion.Guardar(protocolo.PedidoStore{Bloque: bloqueDestino, Size: size, Token: tokenDestino})
+	if err != nil {
+		log.Println("[ERROR] Error al enviar:", err)
+		return
+	}
+	calculador := protocolo.NuevoCalculadorDeChecksums()
+	if _, err := io.CopyN(datos, io.TeeReader(file, calculador), size); err != nil {
+		log.Println("[ERROR] Error al enviar bloque:", err)
+		return
+	}
+	calculados := calculador.Checksums()
+	if guardados == nil {
+		guardados = calculados
+	}
+	// No se copia una réplica dañada: al cortar sin los checksums el destino descarta lo
+	// recibido y el Namenode elige otro origen en la próxima revisión
+	if err := protocolo.CompararChecksums(calculados, guardados); err != nil {
+		log.Printf("[ERROR] El bloque %s a replicar está dañado: %v\n", bloque, err)
+		marcarSospechoso(bloque)
+		return
+	}
+	if _, err := datos.Write(guardados); err != nil {
+		log.Println("[ERROR] Error al enviar checksums:", err)
+		return
+	}
+	conexion.SetDeadline(time.Now().Add(timeoutAck))
+	ack, err := datos.Esperar()
+	if err != nil {
+		log.Println("[ERROR] No llegó la confirmación del Datanode destino:", err)
+		return
+	}
+	if len(ack.Durables) == 0 {
+		log.Printf("[ERROR] El Datanode %s no guardó el bloque %s\n", destino, bloqueDestino)
+		return
+	}
+	log.Printf("[INFO] Bloque %s replicado en %s\n", bloque, destino)
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"github.com/UriNoHi/Distributed-file-system-DFS-/protocolo"
+)
+
+// claves son las claves con las que el Namenode firma los tokens de acceso a los bloques.
+// Llegan en la respuesta del registro y de cada heartbeat; hasta la primera no se acepta
+// ningún pedido sobre bloques.
+var claves = map[int64]protocolo.ClaveDeBloques{}
+var clavesMutex sync.RWMutex
+
+// actualizarClaves reemplaza las claves por las que mandó el Namenode
+func actualizarClaves(nuevas []protocolo.ClaveDeBloques) {
+	clavesMutex.Lock()
+	defer clavesMutex.Unlock()
+	for id := range claves {
+		if !contieneClave(nuevas, id) {
+			delete(claves, id)
+		}
+	}
+	for _, clave := range nuevas {
+		if _, existe := claves[clave.ID]; !existe {
+			log.Println("[INFO] Nueva clave para los tokens de bloques:", clave.ID)
+		}
+		claves[clave.ID] = clave
+	}
+}
+
+func contieneClave(lista []protocolo.ClaveDeBloques, id int64) bool {
+	for _, clave := range lista {
+		if clave.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// verificarToken comprueba que el token permita el acceso al bloque
+func verificarToken(texto string, bloque string, acceso string) error {
+	clavesMutex.RLock()
+	defer clavesMutex.RUnlock()
+	token, err := protocolo.VerificarToken(texto, bloque, acceso, claves, time.Now())
+	if err != nil {
+		log.Printf("[WARNING] Se rechaza %s sobre %s: %v\n", acceso, bloque, err)
+		return err
+	}
+	log.Println("[INFO] Token válido:", token)
+	return nil
+}
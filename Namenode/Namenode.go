@@ -1,8 +1,9 @@
 package main
 
 import (
-	"bufio"
-	"encoding/json"
+	"crypto/tls"
+	"errors"
+	"flag"
 	"fmt"
 	"io"
 	"log"
@@ -10,22 +11,119 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
+
+	"github.com/UriNoHi/Distributed-file-system-DFS-/protocolo"
 )
 
+// Cantidad de réplicas de cada bloque cuando el cliente no indica otra
+const replicacionPorDefecto = 2
+
+// Tamaño de bloque cuando el cliente no indica otro; se puede cambiar con el primer argumento
+var blockSizePorDefecto int64 = 4 << 20 // 4MB
+
+const blockSizeMaximo = 1 << 30 // 1GB
+
+// Con -legacy el Namenode habla el protocolo de frames en lugar de gRPC, con los clientes
+// y con los DataNodes. Todo el cluster tiene que usar el mismo.
+var legacy bool
+
+// configTLS es la configuración de TLS para escuchar y para conectarse con los DataNodes;
+// nil si el cluster no usa TLS
+var configTLS *tls.Config
+
 type DataInfo struct {
-	Block    int    `json:"block"`
-	DataNode string `json:"node"`
+	Block     int      `json:"block"`
+	ID        int64    `json:"id,omitempty"`       // identificador único del bloque en todo el DFS
+	GenStamp  int64    `json:"genstamp,omitempty"` // las réplicas con uno menor están viejas
+	Name      string   `json:"name,omitempty"`     // solo para bloques guardados antes de los IDs
+	DataNodes []string `json:"nodes"`              // un DataNode por réplica
+}
+
+type FileInfo struct {
+	Permisos
+	Replication int        `json:"replication"`
+	Size        int64      `json:"size"`
+	BlockSize   int64      `json:"blocksize,omitempty"`
+	ModTime     time.Time  `json:"mtime"`
+	Blocks      []DataInfo `json:"blocks"`
 }
 
 var nodes = []string{}
 
-var metadata = map[string][]DataInfo{}
+// Errores con los que se contesta a los pedidos, además de los del namespace
+var (
+	errSinDataNodes = protocolo.Errorf(protocolo.CodigoSinDataNodes, "no hay DataNodes vivos")
+	errMetadata     = protocolo.Errorf(protocolo.CodigoInterno, "no se pudo guardar la metadata")
+)
+
+// codigosDelNamespace dice con qué código del protocolo se contesta cada error del namespace
+var codigosDelNamespace = []struct {
+	err    error
+	codigo protocolo.Codigo
+}{
+	{errArchivoNoExiste, protocolo.CodigoNoExiste},
+	{errRutaInvalida, protocolo.CodigoInvalido},
+	{errNoEsDirectorio, protocolo.CodigoNoEsDirectorio},
+	{errEsDirectorio, protocolo.CodigoEsDirectorio},
+	{errDirectorioNoVacio, protocolo.CodigoNoVacio},
+	{errYaExiste, protocolo.CodigoExiste},
+	{errDestinoInvalido, protocolo.CodigoInvalido},
+	{errPermiso, protocolo.CodigoPermiso},
+	{errCuota, protocolo.CodigoCuota},
+}
+
+// errorDelNamespace convierte un error del namespace en la respuesta para el cliente.
+// Los que no son del namespace, como los del edit log, son errores internos.
+func errorDelNamespace(err error) error {
+	for _, conocido := range codigosDelNamespace {
+		if errors.Is(err, conocido.err) {
+			return &protocolo.Error{Codigo: conocido.codigo, Mensaje: err.Error()}
+		}
+	}
+	return &protocolo.Error{Codigo: protocolo.CodigoInterno, Mensaje: err.Error()}
+}
 
 func main() {
+	flag.BoolVar(&legacy, "legacy", false, "usar el protocolo de frames en lugar de gRPC")
+	cert := flag.String("cert", "", "certificado PEM del Namenode, para escuchar con TLS")
+	key := flag.String("key", "", "clave privada PEM del certificado")
+	ca := flag.String("ca", "", "autoridades PEM en las que se confía; exige certificado a clientes y DataNodes (mTLS)")
+	flag.StringVar(&superusuario, "superusuario", superusuarioPorDefecto(), "usuario que puede todo; con mTLS, el CN de su certificado")
+	flag.StringVar(&supergrupo, "supergrupo", supergrupo, "grupo cuyos miembros pueden todo")
+	secreto := flag.String("secreto", "secreto", "archivo con el secreto que comparte con los DataNodes; si no existe se genera")
+	flag.BoolVar(&superusuarioSinCertificado, "superusuario-sin-certificado", false, "aceptar al superusuario declarado sin mTLS (inseguro, solo para pruebas)")
+	flag.Parse()
 	setupLog()
 	// Listen any ip and port 8080
 	log.Println("Iniciando Namenode")
 
+	// Argumento opcional: tamaño de bloque por defecto del cluster, en bytes
+	if flag.NArg() > 0 {
+		blockSize, err := strconv.ParseInt(flag.Arg(0), 10, 64)
+		if err != nil || blockSize < 1 || blockSize > blockSizeMaximo {
+			log.Println("[ERROR] Tamaño de bloque inválido:", flag.Arg(0))
+			return
+		}
+		blockSizePorDefecto = blockSize
+	}
+	log.Println("Tamaño de bloque por defecto:", blockSizePorDefecto)
+
+	var err error
+	configTLS, err = protocolo.ConfigTLSDeNodo(*cert, *key, *ca)
+	if err != nil {
+		log.Println("[ERROR] Configuración de TLS inválida:", err)
+		return
+	}
+	if configTLS != nil {
+		log.Printf("[INFO] Usando TLS (mTLS: %t)\n", *ca != "")
+	}
+
+	if err := cargarSecreto(*secreto); err != nil {
+		log.Println("[ERROR] No se pudo cargar el secreto del cluster:", err)
+		return
+	}
+
 	socket, err := net.Listen("tcp", ":8080")
 	if err != nil {
 		log.Println("[ERROR] Error al iniciar el servidor TCP:", err)
@@ -37,7 +135,27 @@ func main() {
 	createMetadataFile()
 
 	getNodeList()
-
+	registrarNodosSemilla()
+	cargarGrupos()
+	log.Printf("[INFO] Superusuario: %s, supergrupo: %s\n", superusuario, supergrupo)
+	avisarSinAutenticacion(*ca != "")
+
+	rotarClaves()
+	go rotarClavesPeriodicamente()
+	go monitorDeNodos()
+	go monitorDeReplicacion()
+
+	if !legacy {
+		log.Println("Atendiendo pedidos con gRPC")
+		if err := servirRPC(socket); err != nil {
+			log.Println("[ERROR] Error en el servidor gRPC:", err)
+		}
+		return
+	}
+	log.Println("Atendiendo pedidos con el protocolo de frames (-legacy)")
+	if configTLS != nil {
+		socket = tls.NewListener(socket, configTLS)
+	}
 	for {
 		// Accept a connection
 		coneccion, err := socket.Accept()
@@ -55,128 +173,350 @@ func main() {
 func handleConnection(coneccion net.Conn) {
 	defer coneccion.Close()
 
-	reader := bufio.NewReader(coneccion)
+	conexion, err := protocolo.Aceptar(coneccion)
+	if err != nil {
+		log.Printf("[WARNING] Saludo inválido de %s: %v\n", coneccion.RemoteAddr(), err)
+		return
+	}
+	var estadoTLS *tls.ConnectionState
+	if conexionTLS, ok := coneccion.(*tls.Conn); ok {
+		estado := conexionTLS.ConnectionState()
+		estadoTLS = &estado
+	}
+	usuario := identificar(conexion.Usuario(), estadoTLS)
 	for {
-		comando, err := reader.ReadString('\n')
+		pedido, err := conexion.LeerPedido()
 		if err != nil {
+			if err != io.EOF {
+				log.Printf("[ERROR] Error leyendo el pedido de %s: %v\n", coneccion.RemoteAddr(), err)
+			}
 			log.Println("[WARNING] Cliente desconectado:", coneccion.RemoteAddr())
 			return
 		}
 
-		parts := strings.Split(strings.TrimSpace(comando), " ")
+		pedido.Usuario = usuario
+		log.Printf("[INFO] Pedido %d recibido de %s: %s %s\n", pedido.ID, coneccion.RemoteAddr(), pedido.Op, pedido.Cuerpo)
+		respuesta, err := atenderPedido(pedido)
+		if err != nil {
+			log.Printf("[WARNING] Pedido %d (%s) rechazado: %v\n", pedido.ID, pedido.Op, err)
+			err = conexion.ResponderError(pedido, err)
+		} else {
+			err = conexion.Responder(pedido, respuesta)
+		}
+		if err != nil {
+			log.Println("[ERROR] Error al enviar:", err)
+			return
+		}
+	}
+}
 
-		log.Printf("[INFO] Comando recibido de Cliente: %s", comando)
-		log.Println("[INFO] Partes del comando: ", parts)
-		switch parts[0] {
-		case "put":
-			cantBlocks, err := strconv.Atoi(parts[2])
-			if err != nil {
-				log.Println("[ERROR] Error converting block count:", err)
-				return
-			}
-			putNameNode(parts[1], cantBlocks, coneccion)
+// atenderPedido ejecuta el pedido y devuelve el cuerpo de la respuesta. Todos los pedidos
+// tienen respuesta: los que fallan, con el código del error.
+func atenderPedido(pedido *protocolo.Pedido) (any, error) {
+	switch pedido.Op {
+	case protocolo.OpPut:
+		return putNameNode(pedido)
+
+	case protocolo.OpCreate:
+		return createNameNode(pedido)
+
+	case protocolo.OpAddBlock:
+		return addBlockNameNode(pedido)
+
+	case protocolo.OpComplete:
+		return nil, completeNameNode(pedido)
+
+	case protocolo.OpAbandon:
+		return nil, abandonNameNode(pedido)
+
+	case protocolo.OpGet:
+		return getNameNode(pedido)
 
-		case "get":
-			getNameNode(parts[1], coneccion)
+	case protocolo.OpLocations:
+		return locationsNameNode(pedido)
 
-		case "info":
-			getNameNode(parts[1], coneccion)
+	case protocolo.OpStat:
+		return statNameNode(pedido)
 
-		case "ls":
-			listOfFiles(coneccion)
-		case "rm":
-			getNameNode(parts[1], coneccion)
-			rmEntry(parts[1])
+	case protocolo.OpLs:
+		return listOfFiles(pedido)
 
-		default:
-			log.Println("DEFAULT")
+	case protocolo.OpRm:
+		return rmEntry(pedido)
+
+	case protocolo.OpMkdir:
+		return nil, mkdirNameNode(pedido)
+
+	case protocolo.OpRmdir:
+		return nil, rmdirNameNode(pedido)
+
+	case protocolo.OpMv:
+		return nil, mvNameNode(pedido)
+
+	case protocolo.OpSetrep:
+		return nil, setReplication(pedido)
+
+	case protocolo.OpChmod:
+		return nil, chmodNameNode(pedido)
+
+	case protocolo.OpChown:
+		return nil, chownNameNode(pedido)
+
+	case protocolo.OpSetQuota, protocolo.OpSetSpaceQuota:
+		return nil, setQuotaNameNode(pedido)
+
+	case protocolo.OpCount:
+		return countNameNode(pedido)
+
+	case protocolo.OpRegister:
+		// Las respuestas al registro y a los heartbeats llevan las claves de los tokens
+		if err := procesarRegistro(pedido); err != nil {
+			return nil, err
 		}
+		return clavesVigentes(), nil
 
-		//coneccion.Write([]byte("Mensaje recibido: " + comando))
+	case protocolo.OpHeartbeat:
+		if err := procesarHeartbeat(pedido); err != nil {
+			return nil, err
+		}
+		return clavesVigentes(), nil
+
+	case protocolo.OpBlockReport:
+		return nil, procesarReporteCompleto(pedido)
+
+	case protocolo.OpBlockReceived, protocolo.OpBlockDeleted, protocolo.OpBlockCorrupt:
+		return nil, procesarReporteIncremental(pedido)
+
+	case protocolo.OpFsck:
+		return protocolo.RespuestaLineas{Lineas: fsck()}, nil
+
+	case protocolo.OpBlockSize:
+		// Tamaño de bloque por defecto, para que el cliente sepa cómo partir los archivos
+		return protocolo.RespuestaBlockSize{BlockSize: blockSizePorDefecto}, nil
+
+	case protocolo.OpNodes:
+		return protocolo.RespuestaLineas{Lineas: reporteDeNodos()}, nil
+
+	default:
+		return nil, protocolo.Errorf(protocolo.CodigoInvalido, "operación desconocida: %q", pedido.Op)
 	}
 }
 
-func putNameNode(fileName string, cantBlocks int, coneccion net.Conn) {
-	log.Printf("Procesando PUT en Namenode para el archivo %s con %d bloques\n", fileName, cantBlocks)
-	fmt.Printf("Procesando PUT en Namenode para el archivo %s con %d bloques\n", fileName, cantBlocks)
-
-	listaDeDatanodes := []string{}
-	if _, exists := metadata[fileName]; exists {
-		log.Printf("[WARNING] El archivo %s ya existe en el sistema. Sobrescribiendo metadata.\n", fileName)
-		fmt.Printf("[WARNING] El archivo %s ya existe en el sistema. Sobrescribiendo metadata.\n", fileName)
-		metadata[fileName] = []DataInfo{}
+// put: el cliente ya partió el archivo y pide dónde guardar cada bloque
+func putNameNode(pedido *protocolo.Pedido) (any, error) {
+	var put protocolo.PedidoPut
+	if err := pedido.Leer(&put); err != nil {
+		return nil, err
+	}
+	replicacion, err := replicacionPedida(put.Replicacion)
+	if err != nil {
+		return nil, err
+	}
+	if put.Size < 0 || put.Bloques < 0 {
+		return nil, protocolo.Errorf(protocolo.CodigoInvalido, "tamaño invalido")
+	}
+	blockSize, err := blockSizePedido(put.BlockSize)
+	if err != nil {
+		return nil, err
+	}
+	// Con el tamaño del archivo se puede comprobar que el cliente lo partió bien
+	if int64(put.Bloques) != (put.Size+blockSize-1)/blockSize {
+		log.Printf("[ERROR] %d bloques no corresponden a %d bytes con bloques de %d\n", put.Bloques, put.Size, blockSize)
+		return nil, protocolo.Errorf(protocolo.CodigoInvalido, "cantidad de bloques incorrecta")
+	}
+	fileName := put.Ruta
+	log.Printf("Procesando PUT en Namenode para el archivo %s con %d bloques y replicación %d\n", fileName, put.Bloques, replicacion)
+	fmt.Printf("Procesando PUT en Namenode para el archivo %s con %d bloques y replicación %d\n", fileName, put.Bloques, replicacion)
+
+	// Solo se asignan bloques a DataNodes que mandaron heartbeat recientemente
+	vivos := nodosVivos()
+	if len(vivos) == 0 {
+		log.Println("[ERROR] No hay DataNodes vivos para guardar el archivo", fileName)
+		return nil, errSinDataNodes
+	}
+	if replicacion > len(vivos) {
+		log.Printf("[WARNING] Se pidieron %d réplicas pero hay %d DataNodes vivos\n", replicacion, len(vivos))
 	}
 
-	for i := 0; i < cantBlocks; i++ {
-		// Seleccionar un DataNode (aquí simplemente se selecciona uno al azar)
-		indexNode := i % len(nodes)
-		nodoSeleccionado := nodes[indexNode]
+	fileInfo := &FileInfo{Replication: replicacion, Size: put.Size, BlockSize: blockSize, ModTime: time.Now()}
 
-		metadata[fileName] = append(metadata[fileName], DataInfo{Block: i, DataNode: nodoSeleccionado})
-		listaDeDatanodes = append(listaDeDatanodes, nodoSeleccionado)
+	for i := 0; i < put.Bloques; i++ {
+		dataInfo := DataInfo{Block: i, DataNodes: elegirDataNodes(vivos, i, replicacion)}
+		fileInfo.Blocks = append(fileInfo.Blocks, dataInfo)
 
-		log.Printf("[INFO] Bloque %d del archivo %s asignado al DataNode %s\n", i, fileName, nodoSeleccionado)
-		fmt.Printf("[INFO] Bloque %d del archivo %s asignado al DataNode %s\n", i, fileName, nodoSeleccionado)
+		log.Printf("[INFO] Bloque %d del archivo %s asignado a los DataNodes %v\n", i, fileName, dataInfo.DataNodes)
+		fmt.Printf("[INFO] Bloque %d del archivo %s asignado a los DataNodes %v\n", i, fileName, dataInfo.DataNodes)
+	}
+	// El archivo aparece en el namespace antes de que sus bloques lleguen a los DataNodes,
+	// así que put no reemplaza: si la subida falla, se perdería la versión anterior.
+	// Para reemplazar un archivo se usa create/addblock/complete, que cambia la metadata
+	// y borra los bloques anteriores recién cuando están todos los nuevos.
+	if err := namespace.Crear(usuarioDe(pedido), fileName, fileInfo); err != nil {
+		log.Printf("[ERROR] No se pudo crear %s: %v\n", fileName, err)
+		return nil, errorDelNamespace(err)
+	}
+	return protocolo.RespuestaBloques{Bloques: listaDeBloques(*fileInfo, protocolo.AccesoEscribir, pedido.Usuario)}, nil
+}
 
+// replicacionPedida valida la replicación de put y create; 0 usa la replicación por defecto
+func replicacionPedida(replicacion int) (int, error) {
+	if replicacion < 0 {
+		log.Println("[ERROR] Factor de replicación inválido:", replicacion)
+		return 0, protocolo.Errorf(protocolo.CodigoInvalido, "replicacion invalida")
 	}
+	if replicacion == 0 {
+		return replicacionPorDefecto, nil
+	}
+	return replicacion, nil
+}
 
-	for i := 0; i < cantBlocks; i++ {
-		//Backup node selection
-		indexNodeBackup := (i + 2) % len(nodes)
-		nodoBackup := nodes[indexNodeBackup]
+// blockSizePedido valida el tamaño de bloque de put y create; 0 usa el tamaño por defecto
+func blockSizePedido(blockSize int64) (int64, error) {
+	if blockSize == 0 {
+		return blockSizePorDefecto, nil
+	}
+	if blockSize < 1 || blockSize > blockSizeMaximo {
+		log.Println("[ERROR] Tamaño de bloque inválido:", blockSize)
+		return 0, protocolo.Errorf(protocolo.CodigoInvalido, "tamaño de bloque invalido")
+	}
+	return blockSize, nil
+}
 
-		metadata[fileName+"_backup"] = append(metadata[fileName], DataInfo{Block: i, DataNode: nodoBackup})
-		listaDeDatanodes = append(listaDeDatanodes, nodoBackup)
+// elegirDataNodes elige dónde guardar el bloque i: cada réplica va a un DataNode distinto,
+// rotando sobre los vivos
+func elegirDataNodes(vivos []string, i int, replicacion int) []string {
+	elegidos := []string{}
+	for r := 0; r < replicacion && r < len(vivos); r++ {
+		elegidos = append(elegidos, vivos[(i+r)%len(vivos)])
+	}
+	return elegidos
+}
 
-		log.Printf("[INFO] Bloque de Recuperacion %d del archivo %s asignado al DataNode %s\n", i, fileName, nodoBackup)
-		fmt.Printf("[INFO] Bloque de Recuperacion %d del archivo %s asignado al DataNode %s\n", i, fileName, nodoBackup)
+func getNameNode(pedido *protocolo.Pedido) (any, error) {
+	var get protocolo.PedidoRuta
+	if err := pedido.Leer(&get); err != nil {
+		return nil, err
 	}
+	fileName := get.Ruta
+	log.Printf("[INFO] Procesando GET en Namenode para el archivo %s\n", fileName)
+	fmt.Printf("[INFO] Procesando GET en Namenode para el archivo %s\n", fileName)
 
-	data, err := json.MarshalIndent(metadata, "", "  ")
+	fileInfo, err := buscarArchivo(pedido, fileName)
 	if err != nil {
-		log.Println("[ERROR] Error marshaling metadata:", err)
-	} else {
-		if err := os.WriteFile("metadata.json", data, 0644); err != nil {
-			log.Println("[ERROR] Error writing metadata file:", err)
-		}
+		return nil, err
+	}
+	for _, dataInfo := range fileInfo.Blocks {
+		fmt.Printf("[INFO] Bloque %d del archivo %s se encuentra en los DataNodes %v\n", dataInfo.Block, fileName, dataInfo.DataNodes)
 	}
-	_, err = coneccion.Write([]byte(strings.Join(listaDeDatanodes, ",") + "\n"))
+	bloques := listaDeBloques(fileInfo, protocolo.AccesoLeer, pedido.Usuario)
+	log.Printf("[INFO] Lista de DataNodes para el archivo %s: %v\n", fileName, bloques)
+	return protocolo.RespuestaBloques{Bloques: bloques}, nil
+}
+
+// buscarArchivo devuelve la metadata del archivo para leerlo o, si no se puede, el error
+// que corresponde: no existe, es un directorio, la ruta es inválida o falta permiso
+func buscarArchivo(pedido *protocolo.Pedido, ruta string) (FileInfo, error) {
+	fileInfo, err := namespace.Open(usuarioDe(pedido), ruta)
 	if err != nil {
-		log.Println("[ERROR] Error al enviar:", err)
-		return
+		return FileInfo{}, errorDelNamespace(err)
 	}
+	return fileInfo, nil
 }
 
-func getNameNode(fileName string, coneccion net.Conn) {
-	log.Printf("[INFO] Procesando GET en Namenode para el archivo %s\n", fileName)
-	fmt.Printf("[INFO] Procesando GET en Namenode para el archivo %s\n", fileName)
+// locations: los bloques que tienen algún byte del rango, cada uno con su offset en el
+// archivo y su largo. Con largo negativo el rango llega hasta el final del archivo.
+func locationsNameNode(pedido *protocolo.Pedido) (any, error) {
+	var locations protocolo.PedidoLocations
+	if err := pedido.Leer(&locations); err != nil {
+		return nil, err
+	}
+	fileName, offset, largo := locations.Ruta, locations.Offset, locations.Largo
+	if offset < 0 {
+		return nil, protocolo.Errorf(protocolo.CodigoInvalido, "rango invalido")
+	}
+	log.Printf("[INFO] Procesando LOCATIONS en Namenode para %s desde %d (largo %d)\n", fileName, offset, largo)
 
-	listaDeDatanodes := []string{}
-	if info, exists := metadata[fileName]; exists {
-		for _, dataInfo := range info {
-			block := dataInfo.DataNode
-			listaDeDatanodes = append(listaDeDatanodes, block)
-			fmt.Printf("[INFO] Bloque %d del archivo %s se encuentra en el DataNode %s\n", dataInfo.Block, fileName, block)
+	fileInfo, err := buscarArchivo(pedido, fileName)
+	if err != nil {
+		return nil, err
+	}
+	fin := fileInfo.Size
+	if largo >= 0 && largo < fileInfo.Size-offset {
+		fin = offset + largo
+	}
+	blockSize := fileInfo.blockSizeEfectivo()
+	bloques := []protocolo.Bloque{}
+	for i, dataInfo := range fileInfo.Blocks {
+		inicioBloque := int64(i) * blockSize
+		largoBloque := min(blockSize, fileInfo.Size-inicioBloque)
+		if inicioBloque+largoBloque <= offset || inicioBloque >= fin {
+			continue
 		}
-		log.Printf("[INFO] Lista de DataNodes para el archivo %s: %v\n", fileName, listaDeDatanodes)
-		_, err := coneccion.Write([]byte(strings.Join(listaDeDatanodes, ",") + "\n"))
-		if err != nil {
-			log.Println("[ERROR] Error al enviar:", err)
+		bloques = append(bloques, protocolo.Bloque{
+			Nombre:   dataInfo.nombre(),
+			Replicas: dataInfo.DataNodes,
+			Offset:   inicioBloque,
+			Largo:    largoBloque,
+			Token:    tokenDeBloque(dataInfo.nombre(), protocolo.AccesoLeer, pedido.Usuario),
+		})
+	}
+	return protocolo.RespuestaBloques{Bloques: bloques}, nil
+}
 
-		}
+// listaDeBloques arma la respuesta para el cliente: cada bloque con sus réplicas y el token
+// para hacer acceso sobre él
+func listaDeBloques(fileInfo FileInfo, acceso string, usuario string) []protocolo.Bloque {
+	bloques := []protocolo.Bloque{}
+	for _, dataInfo := range fileInfo.Blocks {
+		token := tokenDeBloque(dataInfo.nombre(), acceso, usuario)
+		bloques = append(bloques, protocolo.Bloque{Nombre: dataInfo.nombre(), Replicas: dataInfo.DataNodes, Token: token})
 	}
+	return bloques
 }
 
-func listOfFiles(coneccion net.Conn) {
-	log.Println("[INFO] Procesando LS en Namenode")
-	fmt.Println("[INFO] Procesando LS en Namenode")
-	listOfFiles := []string{}
-	for fileName := range metadata {
-		listOfFiles = append(listOfFiles, fileName)
+func listOfFiles(pedido *protocolo.Pedido) (any, error) {
+	// ls sin ruta es la raíz
+	ls := protocolo.PedidoRuta{Ruta: "/"}
+	if err := pedido.Leer(&ls); err != nil {
+		return nil, err
 	}
-	_, err := coneccion.Write([]byte(strings.Join(listOfFiles, ",") + "\n"))
+	log.Println("[INFO] Procesando LS en Namenode para", ls.Ruta)
+	fmt.Println("[INFO] Procesando LS en Namenode para", ls.Ruta)
+	entradas, err := namespace.List(usuarioDe(pedido), ls.Ruta)
 	if err != nil {
-		log.Println("[ERROR] Error al enviar:", err)
+		return nil, errorDelNamespace(err)
+	}
+	listOfFiles := []protocolo.Entrada{}
+	for _, entrada := range entradas {
+		listOfFiles = append(listOfFiles, entradaDelProtocolo(entrada))
+	}
+	return protocolo.RespuestaLs{Entradas: listOfFiles}, nil
+}
+
+func statNameNode(pedido *protocolo.Pedido) (any, error) {
+	var stat protocolo.PedidoRuta
+	if err := pedido.Leer(&stat); err != nil {
+		return nil, err
+	}
+	log.Println("[INFO] Procesando STAT en Namenode para", stat.Ruta)
+	entrada, err := namespace.Stat(usuarioDe(pedido), stat.Ruta)
+	if err != nil {
+		return nil, errorDelNamespace(err)
+	}
+	return entradaDelProtocolo(entrada), nil
+}
+
+func entradaDelProtocolo(entrada Entrada) protocolo.Entrada {
+	return protocolo.Entrada{
+		Nombre:      entrada.Nombre,
+		Dir:         entrada.EsDir,
+		Size:        entrada.Size,
+		Replicacion: entrada.Replication,
+		ModTime:     entrada.ModTime.Unix(),
+		BlockSize:   entrada.BlockSize,
+		Usuario:     entrada.Owner,
+		Grupo:       entrada.Group,
+		Modo:        entrada.Mode,
 	}
 }
 
@@ -191,39 +531,15 @@ func setupLog() {
 	log.SetFlags(log.LstdFlags | log.Lshortfile) // fecha, hora y línea de código
 }
 
-func createMetadataFile() {
-	data, err := json.MarshalIndent(metadata, "", "  ")
-
-	if err != nil {
-		log.Println("[ERROR] Error marshaling metadata:", err)
-	}
-
-	_, err = os.Stat("metadata.json")
-
-	if err == nil {
-		fileData, err := os.ReadFile("metadata.json")
-		if err != nil {
-			log.Println("[ERROR] Error reading metadata file:", err)
-			return
-		}
-
-		err = json.Unmarshal(fileData, &metadata)
-		if err != nil {
-			log.Println("[ERROR] Error unmarshaling metadata file:", err)
-		}
-	} else if os.IsNotExist(err) {
-		if err := os.WriteFile("metadata.json", data, 0644); err != nil {
-			log.Println("[ERROR] Error writing metadata file:", err)
-
-		}
-		log.Println("Archivo 'metadata.json' creado correctamente ✅")
-	}
-}
-
 func getNodeList() {
 	log.Println("[INFO] Lista de DataNodes disponibles:")
 	//leo el archivo nodeList para obtener los datanodes
+	//es opcional: los DataNodes también se agregan solos con el comando register
 	fileData, err := os.ReadFile("nodeList")
+	if os.IsNotExist(err) {
+		log.Println("[INFO] No hay archivo nodeList, se esperan registros de DataNodes")
+		return
+	}
 	if err != nil {
 		log.Println("[ERROR] Error reading nodeList file:", err)
 		return
@@ -238,17 +554,25 @@ func getNodeList() {
 	}
 }
 
-func rmEntry(fileName string) {
-	log.Printf("[INFO] Procesando RM en Namenode para el archivo %s\n", fileName)
-	fmt.Printf("[INFO] Procesando RM en Namenode para el archivo %s\n", fileName)
-	delete(metadata, fileName)
+func rmEntry(pedido *protocolo.Pedido) (any, error) {
+	var rm protocolo.PedidoRm
+	if err := pedido.Leer(&rm); err != nil {
+		return nil, err
+	}
+	fileName := rm.Ruta
+	log.Printf("[INFO] Procesando RM en Namenode para %s (recursivo: %v)\n", fileName, rm.Recursivo)
+	fmt.Printf("[INFO] Procesando RM en Namenode para %s (recursivo: %v)\n", fileName, rm.Recursivo)
 
-	data, err := json.MarshalIndent(metadata, "", "  ")
+	// Se borra y se devuelve la lista de bloques en un solo paso, así otro put
+	// del mismo archivo no se mezcla entre la consulta y el borrado
+	borrados, err := namespace.Remove(usuarioDe(pedido), fileName, rm.Recursivo)
 	if err != nil {
-		log.Println("[ERROR] Error marshaling metadata:", err)
-	} else {
-		if err := os.WriteFile("metadata.json", data, 0644); err != nil {
-			log.Println("[ERROR] Error writing metadata file:", err)
-		}
+		log.Printf("[ERROR] No se pudo borrar %s: %v\n", fileName, err)
+		return nil, errorDelNamespace(err)
+	}
+	bloques := []protocolo.Bloque{}
+	for _, fileInfo := range borrados {
+		bloques = append(bloques, listaDeBloques(fileInfo, protocolo.AccesoBorrar, pedido.Usuario)...)
 	}
+	return protocolo.RespuestaBloques{Bloques: bloques}, nil
 }
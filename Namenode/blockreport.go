@@ -0,0 +1,188 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"net/url"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/UriNoHi/Distributed-file-system-DFS-/protocolo"
+)
+
+// bloquesReportados guarda, por DataNode, los bloques que realmente tiene en disco
+// según su último reporte completo más los reportes incrementales posteriores.
+var bloquesReportados = map[string]map[string]bool{}
+var bloquesReportadosMutex sync.Mutex
+
+// blockreport: todos los bloques que el DataNode tiene en disco
+func procesarReporteCompleto(pedido *protocolo.Pedido) error {
+	var reporte protocolo.PedidoReporteCompleto
+	if err := pedido.Leer(&reporte); err != nil {
+		return err
+	}
+	address := reporte.Direccion
+	if err := autenticarDataNode(pedido, address, reporte.Credencial); err != nil {
+		return err
+	}
+	if !nodoConocido(address) {
+		log.Printf("[WARNING] Reporte de bloques de un DataNode desconocido %s, se ignora\n", address)
+		return errNodoDesconocido
+	}
+
+	bloques := map[string]bool{}
+	lista := []string{}
+	for _, bloque := range reporte.Bloques {
+		if bloque != "" {
+			bloques[bloque] = true
+			lista = append(lista, bloque)
+		}
+	}
+
+	bloquesReportadosMutex.Lock()
+	bloquesReportados[address] = bloques
+	bloquesReportadosMutex.Unlock()
+
+	log.Printf("[INFO] Reporte completo de %s: %d bloques\n", address, len(bloques))
+	for _, problema := range reconciliarNodo(address) {
+		log.Println("[WARNING]", problema)
+	}
+	descartarReplicasViejas(address, lista)
+	return nil
+}
+
+// blockreceived / blockdeleted / blockcorrupt: un bloque que el DataNode guardó o borró.
+// Una réplica corrupta ya la sacó el DataNode a su cuarentena: se trata como borrada
+// y el monitor de replicación la reemplaza con una copia de otra réplica sana.
+func procesarReporteIncremental(pedido *protocolo.Pedido) error {
+	var reporte protocolo.PedidoReporteIncremental
+	if err := pedido.Leer(&reporte); err != nil {
+		return err
+	}
+	address := reporte.Direccion
+	bloque := reporte.Bloque
+	if err := autenticarDataNode(pedido, address, reporte.Credencial); err != nil {
+		return err
+	}
+	if bloque == "" {
+		log.Println("[ERROR] Reporte incremental inválido:", string(pedido.Cuerpo))
+		return protocolo.Errorf(protocolo.CodigoInvalido, "reporte invalido")
+	}
+	if !nodoConocido(address) {
+		log.Printf("[WARNING] Reporte incremental de un DataNode desconocido %s, se ignora\n", address)
+		return errNodoDesconocido
+	}
+
+	bloquesReportadosMutex.Lock()
+	if bloquesReportados[address] == nil {
+		bloquesReportados[address] = map[string]bool{}
+	}
+	if pedido.Op == protocolo.OpBlockReceived {
+		bloquesReportados[address][bloque] = true
+	} else {
+		delete(bloquesReportados[address], bloque)
+	}
+	bloquesReportadosMutex.Unlock()
+
+	if pedido.Op == protocolo.OpBlockCorrupt {
+		log.Printf("[WARNING] Réplica corrupta de %s en %s, se va a re-replicar\n", bloque, address)
+	} else {
+		log.Printf("[INFO] %s: %s en %s\n", pedido.Op, bloque, address)
+	}
+	if pedido.Op == protocolo.OpBlockReceived {
+		confirmarReplicacion(address, bloque)
+		descartarReplicasViejas(address, []string{bloque})
+	}
+	return nil
+}
+
+func nodoConocido(address string) bool {
+	nodeStatusMutex.Lock()
+	defer nodeStatusMutex.Unlock()
+	_, exists := nodeStatus[address]
+	return exists
+}
+
+// bloquesEsperados arma, a partir de la metadata, qué bloques debería tener cada DataNode
+func bloquesEsperados() map[string]map[string]bool {
+	esperados := map[string]map[string]bool{}
+	for _, fileInfo := range namespace.Snapshot() {
+		for _, dataInfo := range fileInfo.Blocks {
+			for _, dataNode := range dataInfo.DataNodes {
+				if esperados[dataNode] == nil {
+					esperados[dataNode] = map[string]bool{}
+				}
+				esperados[dataNode][dataInfo.nombre()] = true
+			}
+		}
+	}
+	return esperados
+}
+
+// nombreDeBloqueAnterior es el nombre que se usaba cuando el bloque se derivaba de la ruta;
+// solo sirve para completar la metadata guardada antes de que los nombres se guarden en ella
+func nombreDeBloqueAnterior(ruta string, block int) string {
+	return url.PathEscape(ruta) + "_block_" + strconv.Itoa(block)
+}
+
+// reconciliarNodo compara lo que reportó un DataNode contra la metadata.
+// Devuelve las réplicas faltantes (esperadas pero no reportadas), las réplicas viejas
+// (con un generation stamp anterior al de la metadata) y los bloques huérfanos
+// (reportados pero que ningún archivo usa en ese nodo ni es de una escritura en curso).
+func reconciliarNodo(address string) []string {
+	bloquesReportadosMutex.Lock()
+	reportados, tieneReporte := bloquesReportados[address]
+	copia := map[string]bool{}
+	for bloque := range reportados {
+		copia[bloque] = true
+	}
+	bloquesReportadosMutex.Unlock()
+
+	if !tieneReporte {
+		return []string{fmt.Sprintf("sin reporte de bloques de %s", address)}
+	}
+
+	esperados := bloquesEsperados()[address]
+	enEscritura := bloquesEnEscritura()
+	actuales := genStampsActuales()
+	problemas := []string{}
+	for bloque := range esperados {
+		if !copia[bloque] {
+			problemas = append(problemas, fmt.Sprintf("replica faltante %s en %s", bloque, address))
+		}
+	}
+	for bloque := range copia {
+		if esReplicaVieja(bloque, actuales) {
+			problemas = append(problemas, fmt.Sprintf("replica vieja %s en %s", bloque, address))
+		} else if !esperados[bloque] && !enEscritura[bloque] {
+			problemas = append(problemas, fmt.Sprintf("bloque huerfano %s en %s", bloque, address))
+		}
+	}
+	sort.Strings(problemas)
+	return problemas
+}
+
+// fsck reconcilia todos los DataNodes conocidos y devuelve los problemas encontrados
+func fsck() []string {
+	log.Println("[INFO] Procesando FSCK en Namenode")
+
+	nodeStatusMutex.Lock()
+	direcciones := append([]string{}, nodes...)
+	nodeStatusMutex.Unlock()
+	estados := snapshotDeNodos()
+
+	problemas := []string{}
+	for _, address := range direcciones {
+		// El reporte de un nodo muerto ya no sirve, sus réplicas las maneja el monitor de replicación
+		if estados[address].Estado == estadoMuerto {
+			problemas = append(problemas, fmt.Sprintf("DataNode %s muerto", address))
+			continue
+		}
+		problemas = append(problemas, reconciliarNodo(address)...)
+	}
+	if len(problemas) == 0 {
+		problemas = append(problemas, "sin problemas")
+	}
+	return problemas
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"log"
+
+	"github.com/UriNoHi/Distributed-file-system-DFS-/protocolo"
+)
+
+// nombre devuelve el nombre con el que el bloque se guarda en los DataNodes:
+// "blk_<id>_<genstamp>". Todas las réplicas de un bloque usan el mismo nombre
+// y no depende de la ruta del archivo, así mv no tiene que tocar los DataNodes.
+func (dataInfo DataInfo) nombre() string {
+	if dataInfo.ID == 0 {
+		return dataInfo.Name
+	}
+	return protocolo.NombreDeBloque(dataInfo.ID, dataInfo.GenStamp)
+}
+
+// genStampsActuales devuelve, por ID de bloque, el generation stamp que tiene en la metadata
+func genStampsActuales() map[int64]int64 {
+	actuales := map[int64]int64{}
+	for _, fileInfo := range namespace.Snapshot() {
+		for _, dataInfo := range fileInfo.Blocks {
+			if dataInfo.ID != 0 {
+				actuales[dataInfo.ID] = dataInfo.GenStamp
+			}
+		}
+	}
+	return actuales
+}
+
+// esReplicaVieja indica si el bloque es de un ID que sigue en uso pero con un generation stamp anterior
+func esReplicaVieja(nombre string, actuales map[int64]int64) bool {
+	id, genStamp, ok := protocolo.ParsearBloque(nombre)
+	if !ok {
+		return false
+	}
+	actual, exists := actuales[id]
+	return exists && genStamp < actual
+}
+
+// descartarReplicasViejas le pide al DataNode que borre las réplicas viejas que reportó.
+// Un nodo que estuvo caído puede volver con bloques que mientras tanto se reescribieron.
+func descartarReplicasViejas(address string, bloques []string) {
+	actuales := genStampsActuales()
+	for _, bloque := range bloques {
+		if esReplicaVieja(bloque, actuales) {
+			log.Printf("[WARNING] Réplica vieja %s en %s, se descarta\n", bloque, address)
+			go ordenarBorrado(address, bloque)
+		}
+	}
+}
@@ -0,0 +1,300 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"path"
+	"slices"
+
+	"github.com/UriNoHi/Distributed-file-system-DFS-/protocolo"
+)
+
+// Un directorio puede tener dos cuotas, como en HDFS. La de nombres limita cuántos archivos
+// y directorios hay debajo de él, contándose a sí mismo; la de espacio, cuántos bytes ocupan
+// sus archivos contando todas las réplicas. Se comprueban al asignar bloques (put, addblock
+// y complete) y al agregar entradas o espacio debajo del directorio (create, mkdir, mv y
+// setrep). Solo el superusuario las cambia. Una cuota menor a lo que ya se usa vale: solo
+// impide crecer. Los directorios con cuota guardan su uso en memoria, así comprobarla no
+// recorre el directorio: aplicar lo actualiza con cada edición y Cargar lo cuenta de nuevo.
+// No va al checkpoint.
+
+var errCuota = errors.New("se excede la cuota")
+
+// Uso es lo que hay debajo de un directorio, incluido él mismo
+type Uso struct {
+	Directorios int64
+	Archivos    int64
+	Bytes       int64
+	Espacio     int64 // bytes contando todas las réplicas
+}
+
+func (uso Uso) nombres() int64 {
+	return uso.Directorios + uso.Archivos
+}
+
+func (uso Uso) sumar(otro Uso) Uso {
+	return Uso{
+		Directorios: uso.Directorios + otro.Directorios,
+		Archivos:    uso.Archivos + otro.Archivos,
+		Bytes:       uso.Bytes + otro.Bytes,
+		Espacio:     uso.Espacio + otro.Espacio,
+	}
+}
+
+func (uso Uso) negativo() Uso {
+	return Uso{Directorios: -uso.Directorios, Archivos: -uso.Archivos, Bytes: -uso.Bytes, Espacio: -uso.Espacio}
+}
+
+// Resumen es lo que devuelve count: el uso y las cuotas, 0 si no tiene
+type Resumen struct {
+	Uso
+	Cuota        int64
+	CuotaEspacio int64
+}
+
+// espacioDe son los bytes que ocupa el archivo en los DataNodes
+func espacioDe(fileInfo *FileInfo) int64 {
+	return fileInfo.Size * int64(fileInfo.Replication)
+}
+
+func usoDeArchivo(fileInfo *FileInfo) Uso {
+	return Uso{Archivos: 1, Bytes: fileInfo.Size, Espacio: espacioDe(fileInfo)}
+}
+
+// usoDe cuenta todo lo que hay debajo del directorio. De los subdirectorios con cuota
+// usa el uso guardado.
+func usoDe(directorio *Directorio) Uso {
+	uso := Uso{Directorios: 1}
+	for _, fileInfo := range directorio.Files {
+		uso = uso.sumar(usoDeArchivo(fileInfo))
+	}
+	for _, hijo := range directorio.Dirs {
+		uso = uso.sumar(hijo.usoActual())
+	}
+	return uso
+}
+
+// usoDeEntrada es el uso del archivo o directorio nombre del directorio padre, o nada si no existe
+func usoDeEntrada(padre *Directorio, nombre string) Uso {
+	if fileInfo, exists := padre.Files[nombre]; exists {
+		return usoDeArchivo(fileInfo)
+	}
+	if directorio, exists := padre.Dirs[nombre]; exists {
+		return directorio.usoActual()
+	}
+	return Uso{}
+}
+
+func (directorio *Directorio) tieneCuota() bool {
+	return directorio.Cuota != 0 || directorio.CuotaEspacio != 0
+}
+
+// usoActual es el uso guardado del directorio, o el contado si no tiene cuota
+func (directorio *Directorio) usoActual() Uso {
+	if directorio.uso != nil {
+		return *directorio.uso
+	}
+	return usoDe(directorio)
+}
+
+// guardarUso empieza a guardar el uso del directorio si le pusieron una cuota, o deja de
+// hacerlo si se la sacaron
+func guardarUso(directorio *Directorio) {
+	if !directorio.tieneCuota() {
+		directorio.uso = nil
+	} else if directorio.uso == nil {
+		uso := usoDe(directorio)
+		directorio.uso = &uso
+	}
+}
+
+// recalcularUsos cuenta de nuevo, en una pasada, el uso de los directorios con cuota
+// debajo del directorio, incluido él. Devuelve el del directorio.
+func recalcularUsos(directorio *Directorio) Uso {
+	uso := Uso{Directorios: 1}
+	for _, fileInfo := range directorio.Files {
+		uso = uso.sumar(usoDeArchivo(fileInfo))
+	}
+	for _, hijo := range directorio.Dirs {
+		uso = uso.sumar(recalcularUsos(hijo))
+	}
+	directorio.uso = nil
+	if directorio.tieneCuota() {
+		guardado := uso
+		directorio.uso = &guardado
+	}
+	return uso
+}
+
+// cambiarUso suma delta al uso guardado de los directorios con cuota de la ruta, desde la
+// raíz hasta ella. Se llama con ns.mu tomado.
+func (ns *Namespace) cambiarUso(ruta string, delta Uso) {
+	for _, a := range ns.ancestros(ruta) {
+		if a.directorio.uso != nil {
+			*a.directorio.uso = a.directorio.uso.sumar(delta)
+		}
+	}
+}
+
+// ancestro es un directorio existente de una ruta
+type ancestro struct {
+	ruta       string
+	directorio *Directorio
+}
+
+// ancestros devuelve los directorios que existen en la ruta, desde la raíz hasta el más
+// profundo, incluida la ruta si es un directorio. Se llama con ns.mu tomado.
+func (ns *Namespace) ancestros(ruta string) []ancestro {
+	actual := ancestro{ruta: "/", directorio: ns.raiz}
+	ancestros := []ancestro{actual}
+	for _, parte := range partesDeRuta(ruta) {
+		siguiente, exists := actual.directorio.Dirs[parte]
+		if !exists {
+			break
+		}
+		actual = ancestro{ruta: path.Join(actual.ruta, parte), directorio: siguiente}
+		ancestros = append(ancestros, actual)
+	}
+	return ancestros
+}
+
+// faltantes cuenta los directorios de la ruta que no existen y se crearían
+func faltantes(ruta string, ancestros []ancestro) int64 {
+	return int64(len(partesDeRuta(ruta)) + 1 - len(ancestros))
+}
+
+// comprobarCuotas verifica que agregar nombres entradas y espacio bytes debajo de cada uno
+// de los directorios no pase sus cuotas. Lo que se saca (valores negativos) siempre se puede.
+func comprobarCuotas(ancestros []ancestro, nombres int64, espacio int64) error {
+	for _, a := range ancestros {
+		directorio := a.directorio
+		if !directorio.tieneCuota() {
+			continue
+		}
+		uso := directorio.usoActual()
+		if directorio.Cuota > 0 && nombres > 0 && uso.nombres()+nombres > directorio.Cuota {
+			return fmt.Errorf("%w de nombres de %s: %d de %d", errCuota, a.ruta, uso.nombres()+nombres, directorio.Cuota)
+		}
+		if directorio.CuotaEspacio > 0 && espacio > 0 && uso.Espacio+espacio > directorio.CuotaEspacio {
+			return fmt.Errorf("%w de espacio de %s: %d bytes de %d", errCuota, a.ruta, uso.Espacio+espacio, directorio.CuotaEspacio)
+		}
+	}
+	return nil
+}
+
+// CheckQuota comprueba que un archivo que se escribe de a un bloque pueda ocupar espacio
+// bytes en la ruta, reemplazando al que ya esté ahí
+func (ns *Namespace) CheckQuota(ruta string, espacio int64) error {
+	ruta, err := normalizarRuta(ruta)
+	if err != nil {
+		return err
+	}
+	ns.mu.RLock()
+	defer ns.mu.RUnlock()
+	if anterior, err := ns.buscarArchivo(ruta); err == nil {
+		espacio -= espacioDe(anterior)
+	}
+	return comprobarCuotas(ns.ancestros(path.Dir(ruta)), 0, espacio)
+}
+
+// SetQuota cambia la cuota de nombres de un directorio; 0 la saca
+func (ns *Namespace) SetQuota(quien Usuario, ruta string, cuota int64) error {
+	return ns.cambiarCuota(quien, ruta, Edicion{Op: "setquota", Quota: cuota})
+}
+
+// SetSpaceQuota cambia la cuota de espacio de un directorio; 0 la saca
+func (ns *Namespace) SetSpaceQuota(quien Usuario, ruta string, cuota int64) error {
+	return ns.cambiarCuota(quien, ruta, Edicion{Op: "setspacequota", Quota: cuota})
+}
+
+func (ns *Namespace) cambiarCuota(quien Usuario, ruta string, edicion Edicion) error {
+	ruta, err := normalizarRuta(ruta)
+	if err != nil {
+		return err
+	}
+	ns.mu.Lock()
+	defer ns.mu.Unlock()
+	if !quien.esSuperusuario() {
+		return errPermiso
+	}
+	if _, err := ns.buscarDirectorio(ruta); err != nil {
+		return err
+	}
+	edicion.File = ruta
+	return ns.registrar(edicion)
+}
+
+// Count devuelve lo que hay debajo de la ruta y sus cuotas. Solo pide poder llegar hasta ella.
+func (ns *Namespace) Count(quien Usuario, ruta string) (Resumen, error) {
+	ruta, err := normalizarRuta(ruta)
+	if err != nil {
+		return Resumen{}, err
+	}
+	ns.mu.RLock()
+	defer ns.mu.RUnlock()
+	if _, err := ns.recorrer(quien, ruta); err != nil {
+		return Resumen{}, err
+	}
+	if fileInfo, err := ns.buscarArchivo(ruta); err == nil {
+		return Resumen{Uso: usoDeArchivo(fileInfo)}, nil
+	}
+	directorio, err := ns.buscarDirectorio(ruta)
+	if err != nil {
+		return Resumen{}, err
+	}
+	return Resumen{Uso: directorio.usoActual(), Cuota: directorio.Cuota, CuotaEspacio: directorio.CuotaEspacio}, nil
+}
+
+// fueraDe devuelve los ancestros de destino que no lo son también de origen: en un mv, los
+// únicos cuyo uso cambia
+func fueraDe(destino []ancestro, origen []ancestro) []ancestro {
+	distintos := []ancestro{}
+	for _, a := range destino {
+		if !slices.ContainsFunc(origen, func(o ancestro) bool { return o.directorio == a.directorio }) {
+			distintos = append(distintos, a)
+		}
+	}
+	return distintos
+}
+
+// setquota y setspacequota: cambian las cuotas de un directorio
+func setQuotaNameNode(pedido *protocolo.Pedido) error {
+	var cuota protocolo.PedidoCuota
+	if err := pedido.Leer(&cuota); err != nil {
+		return err
+	}
+	if cuota.Cuota < 0 {
+		return protocolo.Errorf(protocolo.CodigoInvalido, "cuota invalida: %d", cuota.Cuota)
+	}
+	log.Printf("[INFO] Procesando %s en Namenode para %s: %d (usuario %s)\n", pedido.Op, cuota.Ruta, cuota.Cuota, pedido.Usuario)
+	cambiar := namespace.SetQuota
+	if pedido.Op == protocolo.OpSetSpaceQuota {
+		cambiar = namespace.SetSpaceQuota
+	}
+	if err := cambiar(usuarioDe(pedido), cuota.Ruta, cuota.Cuota); err != nil {
+		log.Printf("[ERROR] No se pudo cambiar la cuota de %s: %v\n", cuota.Ruta, err)
+		return errorDelNamespace(err)
+	}
+	return nil
+}
+
+// count: cuántos directorios, archivos y bytes hay debajo de una ruta, y sus cuotas
+func countNameNode(pedido *protocolo.Pedido) (any, error) {
+	var count protocolo.PedidoRuta
+	if err := pedido.Leer(&count); err != nil {
+		return nil, err
+	}
+	resumen, err := namespace.Count(usuarioDe(pedido), count.Ruta)
+	if err != nil {
+		return nil, errorDelNamespace(err)
+	}
+	return protocolo.RespuestaCount{
+		Directorios:  resumen.Directorios,
+		Archivos:     resumen.Archivos,
+		Bytes:        resumen.Bytes,
+		Espacio:      resumen.Espacio,
+		Cuota:        resumen.Cuota,
+		CuotaEspacio: resumen.CuotaEspacio,
+	}, nil
+}
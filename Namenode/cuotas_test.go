@@ -0,0 +1,175 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+func comprobarCuota(t *testing.T, que string, err error, excede bool) {
+	t.Helper()
+	if excede && !errors.Is(err, errCuota) {
+		t.Errorf("%s = %v, se esperaba %v", que, err, errCuota)
+	}
+	if !excede && err != nil {
+		t.Errorf("%s: %v", que, err)
+	}
+}
+
+// contar cuenta lo que hay debajo del directorio sin usar el uso guardado
+func contar(directorio *Directorio) Uso {
+	uso := Uso{Directorios: 1}
+	for _, fileInfo := range directorio.Files {
+		uso = uso.sumar(usoDeArchivo(fileInfo))
+	}
+	for _, hijo := range directorio.Dirs {
+		uso = uso.sumar(contar(hijo))
+	}
+	return uso
+}
+
+// comprobarUsos compara el uso guardado de cada directorio con cuota con el que tiene
+func comprobarUsos(t *testing.T, ruta string, directorio *Directorio) {
+	t.Helper()
+	if directorio.tieneCuota() {
+		if directorio.uso == nil {
+			t.Errorf("%s tiene cuota y no guarda su uso", ruta)
+		} else if *directorio.uso != contar(directorio) {
+			t.Errorf("uso guardado de %s: %+v, tiene %+v", ruta, *directorio.uso, contar(directorio))
+		}
+	} else if directorio.uso != nil {
+		t.Errorf("%s no tiene cuota y guarda su uso", ruta)
+	}
+	for nombre, hijo := range directorio.Dirs {
+		comprobarUsos(t, ruta+nombre+"/", hijo)
+	}
+}
+
+func TestCuotaDeNombres(t *testing.T) {
+	ns := cargarNamespace(t, t.TempDir())
+	if err := ns.Mkdir(root, "/q", false); err != nil {
+		t.Fatal(err)
+	}
+	// /q cuenta como uno de sus nombres
+	if err := ns.SetQuota(root, "/q", 3); err != nil {
+		t.Fatal(err)
+	}
+	_, err := ns.Put(root, "/q/a", archivoDePrueba(1, 1))
+	comprobarCuota(t, "put /q/a", err, false)
+	comprobarCuota(t, "mkdir /q/d", ns.Mkdir(root, "/q/d", false), false)
+	_, err = ns.Put(root, "/q/b", archivoDePrueba(1, 1))
+	comprobarCuota(t, "put /q/b con la cuota llena", err, true)
+	comprobarCuota(t, "mkdir -p /q/d/e con la cuota llena", ns.Mkdir(root, "/q/d/e", true), true)
+	_, err = ns.Put(root, "/q/a", archivoDePrueba(5, 1))
+	comprobarCuota(t, "reemplazar /q/a con la cuota llena", err, false)
+
+	if _, err := ns.Put(root, "/afuera", archivoDePrueba(1, 1)); err != nil {
+		t.Fatal(err)
+	}
+	comprobarCuota(t, "mv /afuera /q con la cuota llena", ns.Rename(root, "/afuera", "/q/afuera"), true)
+	comprobarCuota(t, "mv dentro de /q", ns.Rename(root, "/q/a", "/q/d/a"), false)
+	comprobarCuota(t, "mv /q/d/a afuera", ns.Rename(root, "/q/d/a", "/a"), false)
+	comprobarCuota(t, "mv /afuera /q", ns.Rename(root, "/afuera", "/q/afuera"), false)
+
+	if _, err := ns.Remove(root, "/q/afuera", false); err != nil {
+		t.Fatal(err)
+	}
+	_, err = ns.Put(root, "/q/b", archivoDePrueba(1, 1))
+	comprobarCuota(t, "put /q/b después de borrar", err, false)
+	comprobarUsos(t, "/", ns.raiz)
+
+	// Con la cuota en 0 ya no hay límite
+	if err := ns.SetQuota(root, "/q", 0); err != nil {
+		t.Fatal(err)
+	}
+	_, err = ns.Put(root, "/q/c", archivoDePrueba(1, 1))
+	comprobarCuota(t, "put /q/c sin cuota", err, false)
+	comprobarUsos(t, "/", ns.raiz)
+}
+
+func TestCuotaDeEspacio(t *testing.T) {
+	ns := cargarNamespace(t, t.TempDir())
+	if err := ns.Mkdir(root, "/q/d", true); err != nil {
+		t.Fatal(err)
+	}
+	if err := ns.SetSpaceQuota(root, "/q", 100); err != nil {
+		t.Fatal(err)
+	}
+	// El espacio cuenta todas las réplicas
+	_, err := ns.Put(root, "/q/d/a", archivoDePrueba(30, 3))
+	comprobarCuota(t, "put de 90 bytes", err, false)
+	_, err = ns.Put(root, "/q/b", archivoDePrueba(11, 1))
+	comprobarCuota(t, "put de 11 bytes con 10 libres", err, true)
+	_, err = ns.Put(root, "/q/b", archivoDePrueba(10, 1))
+	comprobarCuota(t, "put de 10 bytes con 10 libres", err, false)
+	_, err = ns.SetReplication(root, "/q/b", 2)
+	comprobarCuota(t, "setrep con la cuota llena", err, true)
+	_, err = ns.SetReplication(root, "/q/d/a", 2)
+	comprobarCuota(t, "bajar la replicación", err, false)
+	_, err = ns.SetReplication(root, "/q/b", 3)
+	comprobarCuota(t, "setrep con 30 libres", err, false)
+	_, err = ns.Put(root, "/q/d/a", archivoDePrueba(40, 1))
+	comprobarCuota(t, "reemplazar 60 bytes por 40", err, false)
+	comprobarCuota(t, "CheckQuota de 31 bytes con 30 libres", ns.CheckQuota("/q/c", 31), true)
+	comprobarCuota(t, "CheckQuota para reemplazar /q/d/a", ns.CheckQuota("/q/d/a", 70), false)
+
+	resumen, err := ns.Count(root, "/q")
+	if err != nil {
+		t.Fatal(err)
+	}
+	esperado := Uso{Directorios: 2, Archivos: 2, Bytes: 50, Espacio: 70}
+	if resumen.Uso != esperado || resumen.CuotaEspacio != 100 {
+		t.Errorf("count /q = %+v, se esperaba %+v con cuota de espacio 100", resumen, esperado)
+	}
+	comprobarUsos(t, "/", ns.raiz)
+}
+
+// El uso guardado sigue a cada edición y se cuenta igual al recargar el namespace
+func TestUsoGuardado(t *testing.T) {
+	dir := t.TempDir()
+	ns := cargarNamespace(t, dir)
+	for _, ruta := range []string{"/a/b", "/c"} {
+		if err := ns.Mkdir(root, ruta, true); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, ruta := range []string{"/", "/a", "/a/b", "/c"} {
+		if err := ns.SetQuota(root, ruta, 1000); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := ns.SetSpaceQuota(root, "/a/b", 1000); err != nil {
+		t.Fatal(err)
+	}
+	pasos := []struct {
+		nombre string
+		hacer  func() error
+	}{
+		{"put", func() error { _, err := ns.Put(root, "/a/b/x", archivoDePrueba(10, 2)); return err }},
+		{"put con padres", func() error { _, err := ns.Put(root, "/a/b/n/m/y", archivoDePrueba(7, 1)); return err }},
+		{"reemplazar", func() error { _, err := ns.Put(root, "/a/b/x", archivoDePrueba(4, 3)); return err }},
+		{"mkdir -p", func() error { return ns.Mkdir(root, "/c/d/e", true) }},
+		{"setrep", func() error { _, err := ns.SetReplication(root, "/a/b/x", 1); return err }},
+		{"mv de archivo", func() error { return ns.Rename(root, "/a/b/x", "/c/d") }},
+		{"mv de directorio", func() error { return ns.Rename(root, "/a/b/n", "/c/n") }},
+		{"mv de directorio con cuota", func() error { return ns.Rename(root, "/a/b", "/c/b") }},
+		{"rm -r", func() error { _, err := ns.Remove(root, "/c/n", true); return err }},
+		{"sacar la cuota", func() error { return ns.SetQuota(root, "/c", 0) }},
+		{"rm", func() error { _, err := ns.Remove(root, "/c/d/x", false); return err }},
+	}
+	for _, paso := range pasos {
+		if err := paso.hacer(); err != nil {
+			t.Fatalf("%s: %v", paso.nombre, err)
+		}
+		comprobarUsos(t, "/", ns.raiz)
+	}
+
+	recargado := cargarNamespace(t, dir)
+	comprobarUsos(t, "/", recargado.raiz)
+	for _, ruta := range []string{"/", "/a", "/c", "/c/b"} {
+		antes, _ := ns.Count(root, ruta)
+		despues, _ := recargado.Count(root, ruta)
+		if antes != despues {
+			t.Errorf("count %s: %+v antes de recargar y %+v después", ruta, antes, despues)
+		}
+	}
+}
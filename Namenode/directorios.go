@@ -0,0 +1,52 @@
+package main
+
+import (
+	"log"
+
+	"github.com/UriNoHi/Distributed-file-system-DFS-/protocolo"
+)
+
+// mkdir: con padres crea también los directorios que falten, como mkdir -p
+func mkdirNameNode(pedido *protocolo.Pedido) error {
+	var mkdir protocolo.PedidoMkdir
+	if err := pedido.Leer(&mkdir); err != nil {
+		return err
+	}
+	ruta := mkdir.Ruta
+	log.Printf("[INFO] Procesando MKDIR en Namenode para %s (padres: %v)\n", ruta, mkdir.Padres)
+	if err := namespace.Mkdir(usuarioDe(pedido), ruta, mkdir.Padres); err != nil {
+		log.Printf("[ERROR] No se pudo crear el directorio %s: %v\n", ruta, err)
+		return errorDelNamespace(err)
+	}
+	return nil
+}
+
+// rmdir, solo para directorios vacíos
+func rmdirNameNode(pedido *protocolo.Pedido) error {
+	var rmdir protocolo.PedidoRuta
+	if err := pedido.Leer(&rmdir); err != nil {
+		return err
+	}
+	ruta := rmdir.Ruta
+	log.Printf("[INFO] Procesando RMDIR en Namenode para %s\n", ruta)
+	if err := namespace.Rmdir(usuarioDe(pedido), ruta); err != nil {
+		log.Printf("[ERROR] No se pudo borrar el directorio %s: %v\n", ruta, err)
+		return errorDelNamespace(err)
+	}
+	return nil
+}
+
+// mv: solo cambia la metadata, los bloques se guardan con nombres que no dependen de la ruta
+func mvNameNode(pedido *protocolo.Pedido) error {
+	var mv protocolo.PedidoMv
+	if err := pedido.Leer(&mv); err != nil {
+		return err
+	}
+	origen, destino := mv.Origen, mv.Destino
+	log.Printf("[INFO] Procesando MV en Namenode de %s a %s\n", origen, destino)
+	if err := namespace.Rename(usuarioDe(pedido), origen, destino); err != nil {
+		log.Printf("[ERROR] No se pudo mover %s a %s: %v\n", origen, destino, err)
+		return errorDelNamespace(err)
+	}
+	return nil
+}
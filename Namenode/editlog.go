@@ -0,0 +1,398 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path"
+	"strconv"
+	"time"
+)
+
+const (
+	archivoImagen       = "metadata.json"
+	archivoAnterior     = "metadata.json.anterior" // copia de la metadata migrada de un formato anterior
+	archivoEdiciones    = "edits.log"
+	intervaloCheckpoint = 60 * time.Second
+	edicionesCheckpoint = 1000 // con tantas ediciones acumuladas se hace checkpoint sin esperar
+)
+
+// Edicion es una operación sobre el namespace. Se escribe en el edit log antes de
+// aplicarse a la metadata, así después de un crash se puede reconstruir el estado.
+type Edicion struct {
+	TxID        int64     `json:"txid"`
+	Time        time.Time `json:"time"`
+	Op          string    `json:"op"`             // put, rm, mkdir, mv, setrep, setnodes, alloc, chmod, chown, setquota, setspacequota
+	File        string    `json:"file"`           // ruta absoluta del archivo o directorio
+	Destino     string    `json:"dest,omitempty"` // ruta nueva en un mv
+	Info        *FileInfo `json:"info,omitempty"`
+	Replication int       `json:"replication,omitempty"`
+	Block       int       `json:"block,omitempty"`
+	Nodes       []string  `json:"nodes,omitempty"`
+	Owner       string    `json:"owner,omitempty"` // dueño de los directorios nuevos o el nuevo dueño en un chown
+	Group       string    `json:"group,omitempty"` // grupo nuevo en un chown
+	Mode        uint32    `json:"mode,omitempty"`  // modo nuevo en un chmod
+	Quota       int64     `json:"quota,omitempty"` // cuota nueva en un setquota o setspacequota
+}
+
+// imagenMetadata es el checkpoint: el árbol completo hasta la transacción TxID. Los
+// formatos anteriores se leen con leerImagen (ver migracion.go).
+type imagenMetadata struct {
+	Version        int         `json:"version"`
+	TxID           int64       `json:"txid"`
+	UltimoBloqueID int64       `json:"lastBlockId"`
+	UltimoGenStamp int64       `json:"lastGenStamp"`
+	Raiz           *Directorio `json:"root"`
+
+	// Files es el formato anterior, sin directorios; se migra al cargar
+	Files map[string]*FileInfo `json:"files,omitempty"`
+}
+
+// registrar escribe la edición en el edit log, espera a que esté en disco
+// y recién ahí la aplica a la metadata. Se llama con ns.mu tomado.
+func (ns *Namespace) registrar(edicion Edicion) error {
+	if ns.editLogFallido != nil {
+		return ns.editLogFallido
+	}
+	edicion.TxID = ns.ultimoTxID + 1
+	edicion.Time = time.Now()
+	data, err := json.Marshal(edicion)
+	if err != nil {
+		return err
+	}
+	offset, err := ns.editLog.Seek(0, io.SeekCurrent)
+	if err != nil {
+		log.Println("[ERROR] Error leyendo la posición del edit log:", err)
+		return err
+	}
+	if _, err := ns.editLog.Write(append(data, '\n')); err != nil {
+		log.Println("[ERROR] Error escribiendo el edit log:", err)
+		ns.descartarEdicion(offset)
+		return err
+	}
+	if err := ns.editLog.Sync(); err != nil {
+		log.Println("[ERROR] Error sincronizando el edit log:", err)
+		ns.descartarEdicion(offset)
+		return err
+	}
+	ns.ultimoTxID = edicion.TxID
+	ns.aplicar(edicion)
+
+	ns.edicionesDesdeCheckpoint++
+	if ns.edicionesDesdeCheckpoint >= edicionesCheckpoint {
+		ns.checkpoint()
+	}
+	return nil
+}
+
+// descartarEdicion corta el edit log en offset para sacar una edición que no se terminó de
+// escribir. Si quedara, las siguientes se escribirían detrás y al reproducir el log se
+// perderían todas, porque se corta en la primera línea incompleta.
+func (ns *Namespace) descartarEdicion(offset int64) {
+	err := ns.editLog.Truncate(offset)
+	if err == nil {
+		_, err = ns.editLog.Seek(offset, io.SeekStart)
+	}
+	if err != nil {
+		// No se puede garantizar el log: se rechazan las ediciones hasta reiniciar
+		log.Println("[ERROR] No se pudo sacar la edición incompleta del edit log, no se aceptan más ediciones:", err)
+		ns.editLogFallido = err
+	}
+}
+
+// aplicar modifica el árbol. Las validaciones ya se hicieron antes de registrar la edición;
+// las horas salen de la edición para que reproducir el log deje el mismo árbol.
+func (ns *Namespace) aplicar(edicion Edicion) {
+	ruta := path.Clean("/" + edicion.File)
+	switch edicion.Op {
+	case "put":
+		uso := usoDeArchivo(edicion.Info)
+		uso.Directorios = faltantes(path.Dir(ruta), ns.ancestros(path.Dir(ruta)))
+		padre := ns.crearDirectorios(path.Dir(ruta), edicion.Info.Owner, edicion.Time)
+		if anterior, exists := padre.Files[path.Base(ruta)]; exists {
+			uso = uso.sumar(usoDeArchivo(anterior).negativo())
+		}
+		padre.Files[path.Base(ruta)] = edicion.Info
+		padre.ModTime = edicion.Time
+		ns.cambiarUso(path.Dir(ruta), uso)
+		ns.actualizarContadores(edicion.Info)
+	case "alloc":
+		// Solo reserva un ID o un generation stamp para un archivo que se está escribiendo
+		ns.actualizarContadores(edicion.Info)
+	case "mkdir":
+		nuevos := faltantes(ruta, ns.ancestros(ruta))
+		ns.crearDirectorios(ruta, edicion.Owner, edicion.Time)
+		ns.cambiarUso(ruta, Uso{Directorios: nuevos})
+	case "rm":
+		if padre, err := ns.buscarDirectorio(path.Dir(ruta)); err == nil {
+			ns.cambiarUso(path.Dir(ruta), usoDeEntrada(padre, path.Base(ruta)).negativo())
+			delete(padre.Files, path.Base(ruta))
+			delete(padre.Dirs, path.Base(ruta))
+			padre.ModTime = edicion.Time
+		}
+	case "mv":
+		padreOrigen, err := ns.buscarDirectorio(path.Dir(ruta))
+		if err != nil {
+			return
+		}
+		destino := path.Clean("/" + edicion.Destino)
+		nombre := path.Base(ruta)
+		movido := usoDeEntrada(padreOrigen, nombre)
+		ns.cambiarUso(path.Dir(ruta), movido.negativo())
+		movido.Directorios += faltantes(path.Dir(destino), ns.ancestros(path.Dir(destino)))
+		padreDestino := ns.crearDirectorios(path.Dir(destino), edicion.Owner, edicion.Time)
+		if fileInfo, exists := padreOrigen.Files[nombre]; exists {
+			delete(padreOrigen.Files, nombre)
+			padreDestino.Files[path.Base(destino)] = fileInfo
+		} else if directorio, exists := padreOrigen.Dirs[nombre]; exists {
+			delete(padreOrigen.Dirs, nombre)
+			padreDestino.Dirs[path.Base(destino)] = directorio
+		}
+		padreOrigen.ModTime = edicion.Time
+		padreDestino.ModTime = edicion.Time
+		ns.cambiarUso(path.Dir(destino), movido)
+	case "setrep":
+		if fileInfo, err := ns.buscarArchivo(ruta); err == nil {
+			ns.cambiarUso(path.Dir(ruta), Uso{Espacio: fileInfo.Size * int64(edicion.Replication-fileInfo.Replication)})
+			fileInfo.Replication = edicion.Replication
+		}
+	case "setnodes":
+		if fileInfo, err := ns.buscarArchivo(ruta); err == nil && edicion.Block < len(fileInfo.Blocks) {
+			fileInfo.Blocks[edicion.Block].DataNodes = edicion.Nodes
+		}
+	case "chmod":
+		if permisos, err := ns.permisosDe(ruta); err == nil {
+			permisos.Mode = edicion.Mode
+		}
+	case "chown":
+		if permisos, err := ns.permisosDe(ruta); err == nil {
+			if edicion.Owner != "" {
+				permisos.Owner = edicion.Owner
+			}
+			if edicion.Group != "" {
+				permisos.Group = edicion.Group
+			}
+		}
+	case "setquota":
+		if directorio, err := ns.buscarDirectorio(ruta); err == nil {
+			directorio.Cuota = edicion.Quota
+			guardarUso(directorio)
+		}
+	case "setspacequota":
+		if directorio, err := ns.buscarDirectorio(ruta); err == nil {
+			directorio.CuotaEspacio = edicion.Quota
+			guardarUso(directorio)
+		}
+	default:
+		log.Println("[WARNING] Edición desconocida en el edit log:", edicion.Op)
+	}
+}
+
+// actualizarContadores deja los contadores de bloques por encima de los que usa fileInfo,
+// así al reproducir el log quedan donde estaban
+func (ns *Namespace) actualizarContadores(fileInfo *FileInfo) {
+	for _, dataInfo := range fileInfo.Blocks {
+		ns.ultimoBloqueID = max(ns.ultimoBloqueID, dataInfo.ID)
+		ns.ultimoGenStamp = max(ns.ultimoGenStamp, dataInfo.GenStamp)
+	}
+}
+
+// crearDirectorios crea los directorios de la ruta que falten (como mkdir -p) y devuelve el último.
+// Los nuevos son de owner, con el grupo del directorio donde se crean, como en HDFS.
+func (ns *Namespace) crearDirectorios(ruta string, owner string, modTime time.Time) *Directorio {
+	actual := ns.raiz
+	for _, parte := range partesDeRuta(ruta) {
+		siguiente, exists := actual.Dirs[parte]
+		if !exists {
+			siguiente = nuevoDirectorio(modTime)
+			if owner != "" {
+				siguiente.Permisos = Permisos{Owner: owner, Group: actual.Group, Mode: modoDeDirectorio}
+			}
+			actual.Dirs[parte] = siguiente
+			actual.ModTime = modTime
+		}
+		actual = siguiente
+	}
+	return actual
+}
+
+// Cargar lee el último checkpoint, le aplica las ediciones posteriores
+// del edit log y deja un checkpoint nuevo con el log vacío. Si metadata.json no se puede
+// leer no se toca: el Namenode no arranca antes que pisarlo con un namespace vacío.
+func (ns *Namespace) Cargar() error {
+	ns.mu.Lock()
+	defer ns.mu.Unlock()
+
+	imagen := imagenMetadata{}
+	fileData, err := os.ReadFile(ns.ruta(archivoImagen))
+	if err == nil {
+		if imagen, err = leerImagen(fileData); err != nil {
+			return fmt.Errorf("%s: %w", ns.ruta(archivoImagen), err)
+		}
+		if imagen.Version > versionDeImagen {
+			return fmt.Errorf("%s es de la versión %d y este Namenode lee hasta la %d", ns.ruta(archivoImagen), imagen.Version, versionDeImagen)
+		}
+		// El checkpoint de abajo la reemplaza con el formato nuevo
+		if imagen.Version < versionDeImagen {
+			log.Printf("[WARNING] %s tiene un formato anterior, se migra y se guarda una copia en %s\n", archivoImagen, archivoAnterior)
+			if err := os.WriteFile(ns.ruta(archivoAnterior), fileData, 0644); err != nil {
+				return err
+			}
+		}
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+	ns.ultimoTxID = imagen.TxID
+	ns.ultimoBloqueID = imagen.UltimoBloqueID
+	ns.ultimoGenStamp = imagen.UltimoGenStamp
+	ns.raiz = nuevoDirectorio(time.Now())
+	if imagen.Raiz != nil {
+		ns.raiz = imagen.Raiz
+		completarDirectorios(ns.raiz)
+	}
+	completarPermisos(ns.raiz)
+	for fileName, fileInfo := range imagen.Files {
+		log.Printf("[INFO] Migrando %s a /%s\n", fileName, fileName)
+		// Estos bloques se guardaron con el nombre del archivo sin escapar
+		for i := range fileInfo.Blocks {
+			fileInfo.Blocks[i].Name = fileName + "_block_" + strconv.Itoa(fileInfo.Blocks[i].Block)
+		}
+		ns.aplicar(Edicion{Op: "put", File: "/" + fileName, Info: fileInfo, Time: fileInfo.ModTime})
+	}
+	log.Printf("[INFO] Checkpoint cargado hasta la transacción %d\n", ns.ultimoTxID)
+
+	ns.editLog, err = os.OpenFile(ns.ruta(archivoEdiciones), os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return err
+	}
+	aplicadas := ns.reproducirEdiciones()
+	log.Printf("[INFO] Edit log reproducido: %d ediciones, última transacción %d\n", aplicadas, ns.ultimoTxID)
+	completarNombresDeBloques("/", ns.raiz)
+	completarPermisos(ns.raiz)
+	recalcularUsos(ns.raiz)
+
+	return ns.checkpoint()
+}
+
+// reproducirEdiciones aplica las ediciones posteriores al checkpoint. Si el Namenode se cayó
+// a mitad de una escritura la última línea queda incompleta: se descarta y se corta el log ahí.
+func (ns *Namespace) reproducirEdiciones() int {
+	reader := bufio.NewReader(ns.editLog)
+	var offset int64
+	aplicadas := 0
+	for {
+		linea, err := reader.ReadBytes('\n')
+		if err == io.EOF && len(linea) == 0 {
+			break
+		}
+		var edicion Edicion
+		if err != nil || json.Unmarshal(linea, &edicion) != nil {
+			log.Printf("[WARNING] Edición incompleta al final del edit log (offset %d), se descarta\n", offset)
+			if err := ns.editLog.Truncate(offset); err != nil {
+				log.Println("[ERROR] Error cortando el edit log:", err)
+			}
+			break
+		}
+		offset += int64(len(linea))
+
+		if edicion.TxID <= ns.ultimoTxID {
+			continue // ya está incluida en el checkpoint
+		}
+		ns.aplicar(edicion)
+		ns.ultimoTxID = edicion.TxID
+		aplicadas++
+	}
+	return aplicadas
+}
+
+// checkpoint guarda la metadata completa en un archivo temporal y lo renombra,
+// así nunca queda un metadata.json a medio escribir. Después vacía el edit log.
+// Se llama con ns.mu tomado.
+func (ns *Namespace) checkpoint() error {
+	data, err := json.MarshalIndent(imagenMetadata{Version: versionDeImagen, TxID: ns.ultimoTxID, UltimoBloqueID: ns.ultimoBloqueID, UltimoGenStamp: ns.ultimoGenStamp, Raiz: ns.raiz}, "", "  ")
+	if err != nil {
+		log.Println("[ERROR] Error marshaling metadata:", err)
+		return err
+	}
+
+	temporal := ns.ruta(archivoImagen) + ".tmp"
+	file, err := os.Create(temporal)
+	if err != nil {
+		log.Println("[ERROR] Error writing metadata file:", err)
+		return err
+	}
+	_, err = file.Write(data)
+	if err == nil {
+		err = file.Sync()
+	}
+	file.Close()
+	if err == nil {
+		err = os.Rename(temporal, ns.ruta(archivoImagen))
+	}
+	if err != nil {
+		log.Println("[ERROR] Error writing metadata file:", err)
+		return err
+	}
+
+	// Las ediciones ya están en la imagen; si se corta acá, al reproducir se saltean por txid
+	if err := ns.editLog.Truncate(0); err != nil {
+		log.Println("[ERROR] Error vaciando el edit log:", err)
+		return err
+	}
+	if _, err := ns.editLog.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	ns.edicionesDesdeCheckpoint = 0
+	log.Printf("[INFO] Checkpoint guardado hasta la transacción %d\n", ns.ultimoTxID)
+	return nil
+}
+
+func (ns *Namespace) checkpointPeriodico() {
+	for {
+		time.Sleep(intervaloCheckpoint)
+		ns.mu.Lock()
+		if ns.edicionesDesdeCheckpoint > 0 {
+			ns.checkpoint()
+		}
+		ns.mu.Unlock()
+	}
+}
+
+// completarDirectorios inicializa los mapas que el JSON deja en nil por omitempty
+func completarDirectorios(directorio *Directorio) {
+	if directorio.Dirs == nil {
+		directorio.Dirs = map[string]*Directorio{}
+	}
+	if directorio.Files == nil {
+		directorio.Files = map[string]*FileInfo{}
+	}
+	for _, hijo := range directorio.Dirs {
+		completarDirectorios(hijo)
+	}
+}
+
+// completarNombresDeBloques les pone nombre a los bloques guardados antes de que el nombre
+// estuviera en la metadata, cuando se derivaba de la ruta del archivo
+func completarNombresDeBloques(ruta string, directorio *Directorio) {
+	for nombre, fileInfo := range directorio.Files {
+		for i, dataInfo := range fileInfo.Blocks {
+			if dataInfo.ID == 0 && dataInfo.Name == "" {
+				fileInfo.Blocks[i].Name = nombreDeBloqueAnterior(path.Join(ruta, nombre), dataInfo.Block)
+			}
+		}
+	}
+	for nombre, hijo := range directorio.Dirs {
+		completarNombresDeBloques(path.Join(ruta, nombre), hijo)
+	}
+}
+
+// createMetadataFile carga el namespace del disco al iniciar el Namenode
+func createMetadataFile() {
+	if err := namespace.Cargar(); err != nil {
+		log.Fatalf("[ERROR] No se pudo cargar la metadata: %v", err)
+	}
+	go namespace.checkpointPeriodico()
+}
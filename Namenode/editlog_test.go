@@ -0,0 +1,254 @@
+package main
+
+import (
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+var root = Usuario{Nombre: "root"}
+
+func TestMain(m *testing.M) {
+	log.SetOutput(io.Discard)
+	superusuario = root.Nombre
+	os.Exit(m.Run())
+}
+
+// cargarNamespace carga el namespace guardado en dir, como al iniciar el Namenode
+func cargarNamespace(t *testing.T, dir string) *Namespace {
+	t.Helper()
+	ns := NewNamespace(dir)
+	if err := ns.Cargar(); err != nil {
+		t.Fatalf("Cargar: %v", err)
+	}
+	t.Cleanup(func() { ns.editLog.Close() })
+	return ns
+}
+
+// archivoDePrueba es la metadata de un archivo de size bytes en un bloque
+func archivoDePrueba(size int64, replicacion int) *FileInfo {
+	return &FileInfo{Replication: replicacion, Size: size, BlockSize: 1 << 20, Blocks: []DataInfo{{DataNodes: []string{"dn1"}}}}
+}
+
+func comprobarExiste(t *testing.T, ns *Namespace, ruta string) {
+	t.Helper()
+	if _, err := ns.Stat(root, ruta); err != nil {
+		t.Errorf("Stat(%s): %v", ruta, err)
+	}
+}
+
+func comprobarNoExiste(t *testing.T, ns *Namespace, ruta string) {
+	t.Helper()
+	if _, err := ns.Stat(root, ruta); err != errArchivoNoExiste {
+		t.Errorf("Stat(%s) = %v, se esperaba %v", ruta, err, errArchivoNoExiste)
+	}
+}
+
+// Las ediciones que no llegaron a un checkpoint se recuperan del edit log
+func TestReproducirEditLog(t *testing.T) {
+	dir := t.TempDir()
+	ns := cargarNamespace(t, dir)
+	if err := ns.Mkdir(root, "/a/b", true); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ns.Put(root, "/a/b/x", archivoDePrueba(10, 2)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ns.Put(root, "/a/y", archivoDePrueba(20, 1)); err != nil {
+		t.Fatal(err)
+	}
+	if err := ns.Rename(root, "/a/y", "/a/b/y"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ns.SetReplication(root, "/a/b/x", 3); err != nil {
+		t.Fatal(err)
+	}
+	if err := ns.SetQuota(root, "/a", 10); err != nil {
+		t.Fatal(err)
+	}
+	if ns.edicionesDesdeCheckpoint == 0 {
+		t.Fatal("las ediciones tendrían que estar solo en el edit log")
+	}
+
+	recargado := cargarNamespace(t, dir)
+	comprobarExiste(t, recargado, "/a/b/x")
+	comprobarExiste(t, recargado, "/a/b/y")
+	comprobarNoExiste(t, recargado, "/a/y")
+	fileInfo, err := recargado.Open(root, "/a/b/x")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fileInfo.Replication != 3 || fileInfo.Size != 10 {
+		t.Errorf("/a/b/x quedó con replicación %d y tamaño %d", fileInfo.Replication, fileInfo.Size)
+	}
+	if resumen, _ := recargado.Count(root, "/a"); resumen.Cuota != 10 {
+		t.Errorf("la cuota de /a quedó en %d", resumen.Cuota)
+	}
+	if recargado.ultimoTxID != ns.ultimoTxID || recargado.ultimoBloqueID != ns.ultimoBloqueID {
+		t.Errorf("contadores: txid %d y bloque %d, se esperaban %d y %d",
+			recargado.ultimoTxID, recargado.ultimoBloqueID, ns.ultimoTxID, ns.ultimoBloqueID)
+	}
+}
+
+// Después de un checkpoint el log se vacía; al cargar se usan la imagen y lo que vino después
+func TestCheckpoint(t *testing.T) {
+	dir := t.TempDir()
+	ns := cargarNamespace(t, dir)
+	if _, err := ns.Put(root, "/antes", archivoDePrueba(1, 1)); err != nil {
+		t.Fatal(err)
+	}
+	if err := ns.checkpoint(); err != nil {
+		t.Fatal(err)
+	}
+	if info, err := os.Stat(ns.ruta(archivoEdiciones)); err != nil || info.Size() != 0 {
+		t.Fatalf("el edit log tendría que estar vacío después del checkpoint: %v", err)
+	}
+	if _, err := ns.Put(root, "/despues", archivoDePrueba(1, 1)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ns.Remove(root, "/antes", false); err != nil {
+		t.Fatal(err)
+	}
+
+	recargado := cargarNamespace(t, dir)
+	comprobarNoExiste(t, recargado, "/antes")
+	comprobarExiste(t, recargado, "/despues")
+}
+
+// Una línea incompleta al final del log, de un crash a mitad de escritura, se descarta
+// sin perder las ediciones anteriores
+func TestEdicionIncompletaAlFinal(t *testing.T) {
+	dir := t.TempDir()
+	ns := cargarNamespace(t, dir)
+	if _, err := ns.Put(root, "/completo", archivoDePrueba(1, 1)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ns.editLog.WriteString(`{"txid":99,"op":"put","fi`); err != nil {
+		t.Fatal(err)
+	}
+
+	recargado := cargarNamespace(t, dir)
+	comprobarExiste(t, recargado, "/completo")
+	if _, err := recargado.Put(root, "/nuevo", archivoDePrueba(1, 1)); err != nil {
+		t.Fatal(err)
+	}
+	comprobarExiste(t, cargarNamespace(t, dir), "/nuevo")
+}
+
+// Si una escritura en el log falla a medias, descartarEdicion la saca para que las
+// ediciones siguientes no queden detrás de una línea rota
+func TestDescartarEdicion(t *testing.T) {
+	dir := t.TempDir()
+	ns := cargarNamespace(t, dir)
+	if _, err := ns.Put(root, "/a", archivoDePrueba(1, 1)); err != nil {
+		t.Fatal(err)
+	}
+	offset, err := ns.editLog.Seek(0, io.SeekCurrent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ns.editLog.WriteString(`{"txid":3,"op":"put","fi`); err != nil {
+		t.Fatal(err)
+	}
+	ns.descartarEdicion(offset)
+	if ns.editLogFallido != nil {
+		t.Fatal(ns.editLogFallido)
+	}
+	if _, err := ns.Put(root, "/b", archivoDePrueba(1, 1)); err != nil {
+		t.Fatal(err)
+	}
+
+	recargado := cargarNamespace(t, dir)
+	comprobarExiste(t, recargado, "/a")
+	comprobarExiste(t, recargado, "/b")
+}
+
+// Si no se puede sacar la edición incompleta, el namespace no acepta más ediciones
+func TestEditLogFallido(t *testing.T) {
+	ns := cargarNamespace(t, t.TempDir())
+	ns.editLog.Close()
+	ns.descartarEdicion(0)
+	if ns.editLogFallido == nil {
+		t.Fatal("descartarEdicion con el log cerrado tendría que marcarlo como fallido")
+	}
+	if err := ns.Mkdir(root, "/x", false); err == nil {
+		t.Fatal("se registró una edición con el edit log fallido")
+	}
+}
+
+// El metadata.json del Namenode original se migra: cada "<archivo>_backup" suma sus
+// DataNodes como réplicas y los bloques conservan el nombre que tienen en los DataNodes
+func TestMigrarMetadataOriginal(t *testing.T) {
+	original, err := os.ReadFile(filepath.Join("testdata", "metadata-original.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, archivoImagen), original, 0644); err != nil {
+		t.Fatal(err)
+	}
+	esperados := map[string][]DataInfo{
+		"/ejemplo.txt": {
+			{Block: 0, Name: "ejemplo.txt_block_0", DataNodes: []string{"localhost:9000"}},
+			{Block: 1, Name: "ejemplo.txt_block_1", DataNodes: []string{"localhost:9001"}},
+			{Block: 2, Name: "ejemplo.txt_block_2", DataNodes: []string{"localhost:9002", "localhost:9001"}},
+		},
+		"/notas_backup": {
+			{Block: 0, Name: "notas_backup_block_0", DataNodes: []string{"localhost:9001", "localhost:9000"}},
+		},
+	}
+	comprobar := func(ns *Namespace) {
+		t.Helper()
+		archivos := ns.Snapshot()
+		if len(archivos) != len(esperados) {
+			t.Errorf("quedaron %d archivos, se esperaban %d: %v", len(archivos), len(esperados), archivos)
+		}
+		for ruta, bloques := range esperados {
+			fileInfo, existe := archivos[ruta]
+			if !existe {
+				t.Errorf("no se migró %s", ruta)
+				continue
+			}
+			if !reflect.DeepEqual(fileInfo.Blocks, bloques) {
+				t.Errorf("bloques de %s: %+v, se esperaban %+v", ruta, fileInfo.Blocks, bloques)
+			}
+			if fileInfo.BlockSize != blockSizeAnterior || fileInfo.Size != int64(len(bloques))*blockSizeAnterior || fileInfo.Replication != replicacionPorDefecto {
+				t.Errorf("%s quedó con tamaño %d, bloques de %d y replicación %d", ruta, fileInfo.Size, fileInfo.BlockSize, fileInfo.Replication)
+			}
+		}
+	}
+	comprobar(cargarNamespace(t, dir))
+
+	// El original queda guardado y el checkpoint ya tiene el formato nuevo
+	if copia, err := os.ReadFile(filepath.Join(dir, archivoAnterior)); err != nil || string(copia) != string(original) {
+		t.Errorf("la copia de la metadata original no coincide: %v", err)
+	}
+	comprobar(cargarNamespace(t, dir))
+}
+
+// Una metadata que no se entiende no se pisa con un namespace vacío
+func TestMetadataIlegible(t *testing.T) {
+	for nombre, contenido := range map[string]string{
+		"no es JSON":            "ejemplo.txt 0 localhost:9000",
+		"no es un mapa":         `[{"block":0,"node":"localhost:9000"}]`,
+		"bloques de otro tipo":  `{"ejemplo.txt":"localhost:9000"}`,
+		"de una versión futura": `{"version":99,"txid":3,"root":{}}`,
+	} {
+		dir := t.TempDir()
+		ruta := filepath.Join(dir, archivoImagen)
+		if err := os.WriteFile(ruta, []byte(contenido), 0644); err != nil {
+			t.Fatal(err)
+		}
+		ns := NewNamespace(dir)
+		if err := ns.Cargar(); err == nil {
+			ns.editLog.Close()
+			t.Errorf("%s: se cargó la metadata", nombre)
+		}
+		if despues, _ := os.ReadFile(ruta); string(despues) != contenido {
+			t.Errorf("%s: la metadata quedó %q", nombre, despues)
+		}
+	}
+}
@@ -0,0 +1,247 @@
+package main
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"github.com/UriNoHi/Distributed-file-system-DFS-/protocolo"
+)
+
+// Un archivo también se puede escribir de a un bloque, sin saber su tamaño de antemano:
+//
+//	create <ruta> [replicacion] [tamañoDeBloque]  -> <escritura> <tamañoDeBloque>
+//	addblock <escritura>                          -> el bloque y sus réplicas
+//	complete <escritura> <tamaño>                 -> ok
+//	abandon <escritura>                           -> ok
+//
+// El archivo recién aparece en el namespace con el complete. Hasta entonces sus bloques
+// solo están acá, en memoria: si el Namenode se reinicia, la escritura se pierde y los
+// bloques que ya estaban en los DataNodes quedan huérfanos. Si el archivo ya existía,
+// hasta el complete se sigue leyendo la versión anterior, y sus bloques se borran recién
+// después; así es como el cliente reemplaza un archivo, también con put.
+//
+// Un addblock con renovar no asigna un bloque: devuelve uno ya asignado de la escritura
+// con un token nuevo, mientras ningún DataNode lo haya reportado. Lo usa un put largo
+// al que le vencieron los tokens de los bloques que todavía no mandó.
+
+// Una escritura sin actividad por este tiempo se da por abandonada
+const vencimientoDeEscritura = time.Hour
+
+type escrituraEnCurso struct {
+	usuario         Usuario // solo quien la empezó la puede continuar
+	ruta            string
+	replicacion     int
+	blockSize       int64
+	genStamp        int64
+	bloques         []DataInfo
+	ultimaActividad time.Time
+}
+
+var escrituras = map[int64]*escrituraEnCurso{}
+var ultimaEscritura int64
+var escriturasMutex sync.Mutex
+
+// create: empieza la escritura de un archivo; replicacion 0 usa la replicación por defecto
+func createNameNode(pedido *protocolo.Pedido) (any, error) {
+	var create protocolo.PedidoCreate
+	if err := pedido.Leer(&create); err != nil {
+		return nil, err
+	}
+	ruta := create.Ruta
+	replicacion, err := replicacionPedida(create.Replicacion)
+	if err != nil {
+		return nil, err
+	}
+	blockSize, err := blockSizePedido(create.BlockSize)
+	if err != nil {
+		return nil, err
+	}
+	if err := namespace.CheckCreate(usuarioDe(pedido), ruta); err != nil {
+		return nil, errorDelNamespace(err)
+	}
+	genStamp, err := namespace.NewGenStamp()
+	if err != nil {
+		return nil, errMetadata
+	}
+
+	escriturasMutex.Lock()
+	descartarEscriturasVencidas()
+	ultimaEscritura++
+	id := ultimaEscritura
+	escrituras[id] = &escrituraEnCurso{
+		usuario:         usuarioDe(pedido),
+		ruta:            ruta,
+		replicacion:     replicacion,
+		blockSize:       blockSize,
+		genStamp:        genStamp,
+		ultimaActividad: time.Now(),
+	}
+	escriturasMutex.Unlock()
+
+	log.Printf("[INFO] Escritura %d de %s iniciada (replicación %d, bloques de %d bytes)\n", id, ruta, replicacion, blockSize)
+	return protocolo.RespuestaCreate{Escritura: id, BlockSize: blockSize}, nil
+}
+
+// addblock: asigna el bloque siguiente del archivo
+func addBlockNameNode(pedido *protocolo.Pedido) (any, error) {
+	escriturasMutex.Lock()
+	defer escriturasMutex.Unlock()
+
+	cuerpo, escritura, err := buscarEscritura(pedido)
+	if err != nil {
+		return nil, err
+	}
+	id := cuerpo.Escritura
+	if cuerpo.Renovar {
+		return renovarBloque(pedido, id, escritura, cuerpo.Bloque)
+	}
+	// Cada bloque nuevo se cuenta lleno, porque todavía no se sabe cuánto se va a escribir
+	i := len(escritura.bloques)
+	espacio := int64(i+1) * escritura.blockSize * int64(escritura.replicacion)
+	if err := namespace.CheckQuota(escritura.ruta, espacio); err != nil {
+		log.Printf("[ERROR] No se asigna el bloque %d de la escritura %d (%s): %v\n", i, id, escritura.ruta, err)
+		return nil, errorDelNamespace(err)
+	}
+	vivos := nodosVivos()
+	if len(vivos) == 0 {
+		log.Println("[ERROR] No hay DataNodes vivos para guardar el archivo", escritura.ruta)
+		return nil, errSinDataNodes
+	}
+	bloqueID, err := namespace.AllocateBlock()
+	if err != nil {
+		return nil, errMetadata
+	}
+	dataInfo := DataInfo{Block: i, ID: bloqueID, GenStamp: escritura.genStamp, DataNodes: elegirDataNodes(vivos, i, escritura.replicacion)}
+	escritura.bloques = append(escritura.bloques, dataInfo)
+	escritura.ultimaActividad = time.Now()
+
+	log.Printf("[INFO] Bloque %d de la escritura %d (%s) asignado a los DataNodes %v\n", i, id, escritura.ruta, dataInfo.DataNodes)
+	return protocolo.RespuestaBloques{Bloques: listaDeBloques(FileInfo{Blocks: []DataInfo{dataInfo}}, protocolo.AccesoEscribir, pedido.Usuario)}, nil
+}
+
+// renovarBloque devuelve el bloque i de la escritura con un token de escritura nuevo.
+// Se llama con escriturasMutex tomado.
+func renovarBloque(pedido *protocolo.Pedido, id int64, escritura *escrituraEnCurso, i int) (any, error) {
+	if i < 0 || i >= len(escritura.bloques) {
+		return nil, protocolo.Errorf(protocolo.CodigoInvalido, "la escritura no tiene el bloque %d", i)
+	}
+	dataInfo := escritura.bloques[i]
+	// Un bloque ya guardado no se vuelve a escribir
+	if bloqueGuardado(dataInfo.nombre()) {
+		log.Printf("[WARNING] Se pidió un token de escritura para %s, que ya está guardado\n", dataInfo.nombre())
+		return nil, protocolo.Errorf(protocolo.CodigoPermiso, "el bloque %s ya está guardado", dataInfo.nombre())
+	}
+	escritura.ultimaActividad = time.Now()
+	log.Printf("[INFO] Token renovado para el bloque %d de la escritura %d (%s)\n", i, id, escritura.ruta)
+	return protocolo.RespuestaBloques{Bloques: listaDeBloques(FileInfo{Blocks: []DataInfo{dataInfo}}, protocolo.AccesoEscribir, pedido.Usuario)}, nil
+}
+
+// complete: el archivo queda en el namespace con los bloques asignados
+func completeNameNode(pedido *protocolo.Pedido) error {
+	escriturasMutex.Lock()
+	cuerpo, escritura, err := buscarEscritura(pedido)
+	if err != nil {
+		escriturasMutex.Unlock()
+		return err
+	}
+	id, size := cuerpo.Escritura, cuerpo.Size
+	if size < 0 {
+		escriturasMutex.Unlock()
+		return protocolo.Errorf(protocolo.CodigoInvalido, "tamaño invalido")
+	}
+	if int64(len(escritura.bloques)) != (size+escritura.blockSize-1)/escritura.blockSize {
+		escriturasMutex.Unlock()
+		log.Printf("[ERROR] La escritura %d tiene %d bloques y no corresponden a %d bytes\n", id, len(escritura.bloques), size)
+		return protocolo.Errorf(protocolo.CodigoInvalido, "cantidad de bloques incorrecta")
+	}
+	delete(escrituras, id)
+	escriturasMutex.Unlock()
+
+	fileInfo := &FileInfo{
+		Replication: escritura.replicacion,
+		Size:        size,
+		BlockSize:   escritura.blockSize,
+		ModTime:     time.Now(),
+		Blocks:      escritura.bloques,
+	}
+	anterior, err := namespace.Complete(escritura.usuario, escritura.ruta, fileInfo)
+	if err != nil {
+		log.Printf("[ERROR] No se pudo crear %s: %v\n", escritura.ruta, err)
+		ordenarBorradoDeBloques(escritura.bloques)
+		return errorDelNamespace(err)
+	}
+	if anterior != nil {
+		log.Printf("[WARNING] El archivo %s ya existe en el sistema. Sobrescribiendo metadata.\n", escritura.ruta)
+		ordenarBorradoDeBloques(anterior.Blocks)
+	}
+	log.Printf("[INFO] Escritura %d completa: %s con %d bloques\n", id, escritura.ruta, len(escritura.bloques))
+	return nil
+}
+
+// abandon: se descarta la escritura y se borran los bloques que ya se mandaron
+func abandonNameNode(pedido *protocolo.Pedido) error {
+	escriturasMutex.Lock()
+	cuerpo, escritura, err := buscarEscritura(pedido)
+	if err == nil {
+		delete(escrituras, cuerpo.Escritura)
+	}
+	escriturasMutex.Unlock()
+
+	if err != nil {
+		return err
+	}
+	log.Printf("[INFO] Escritura %d de %s abandonada\n", cuerpo.Escritura, escritura.ruta)
+	ordenarBorradoDeBloques(escritura.bloques)
+	return nil
+}
+
+// buscarEscritura lee el PedidoEscritura del pedido y devuelve la escritura a la que se
+// refiere. Se llama con escriturasMutex tomado.
+func buscarEscritura(pedido *protocolo.Pedido) (protocolo.PedidoEscritura, *escrituraEnCurso, error) {
+	var cuerpo protocolo.PedidoEscritura
+	if err := pedido.Leer(&cuerpo); err != nil {
+		return cuerpo, nil, err
+	}
+	escritura := escrituras[cuerpo.Escritura]
+	if escritura == nil {
+		return cuerpo, nil, protocolo.Errorf(protocolo.CodigoNoExiste, "no existe la escritura")
+	}
+	if quien := usuarioDe(pedido); quien.Nombre != escritura.usuario.Nombre && !quien.esSuperusuario() {
+		return cuerpo, nil, protocolo.Errorf(protocolo.CodigoPermiso, "la escritura es de otro usuario")
+	}
+	return cuerpo, escritura, nil
+}
+
+// descartarEscriturasVencidas se llama con escriturasMutex tomado
+func descartarEscriturasVencidas() {
+	for id, escritura := range escrituras {
+		if time.Since(escritura.ultimaActividad) > vencimientoDeEscritura {
+			log.Printf("[WARNING] La escritura %d de %s venció, se descartan sus bloques\n", id, escritura.ruta)
+			delete(escrituras, id)
+			ordenarBorradoDeBloques(escritura.bloques)
+		}
+	}
+}
+
+// bloquesEnEscritura devuelve los nombres de los bloques de archivos que todavía se están escribiendo
+func bloquesEnEscritura() map[string]bool {
+	escriturasMutex.Lock()
+	defer escriturasMutex.Unlock()
+	bloques := map[string]bool{}
+	for _, escritura := range escrituras {
+		for _, dataInfo := range escritura.bloques {
+			bloques[dataInfo.nombre()] = true
+		}
+	}
+	return bloques
+}
+
+// ordenarBorradoDeBloques pide a cada DataNode que borre su réplica de los bloques
+func ordenarBorradoDeBloques(bloques []DataInfo) {
+	for _, dataInfo := range bloques {
+		for _, dataNode := range dataInfo.DataNodes {
+			go ordenarBorrado(dataNode, dataInfo.nombre())
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/UriNoHi/Distributed-file-system-DFS-/protocolo"
+)
+
+// pedirComo atiende el pedido op con cuerpo como si lo mandara quien
+func pedirComo(t *testing.T, quien Usuario, op string, cuerpo any) (any, error) {
+	t.Helper()
+	data, err := json.Marshal(cuerpo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return atenderPedido(&protocolo.Pedido{Op: op, Cuerpo: data, Usuario: quien.Nombre})
+}
+
+// dataNodeVivo agrega un DataNode que acaba de mandar heartbeat, para que se le asignen
+// bloques. No escucha en ningún lado: los borrados que se le ordenan fallan.
+func dataNodeVivo(t *testing.T) {
+	const direccion = "127.0.0.1:1"
+	nodeStatusMutex.Lock()
+	nodes = append(nodes, direccion)
+	nodeStatus[direccion] = &NodeStatus{Address: direccion, LastHeartbeat: time.Now()}
+	nodeStatusMutex.Unlock()
+	t.Cleanup(func() {
+		nodeStatusMutex.Lock()
+		delete(nodeStatus, direccion)
+		quitarNodo(direccion)
+		nodeStatusMutex.Unlock()
+	})
+}
+
+// usarNamespace reemplaza el namespace del Namenode por ns mientras dura el test
+func usarNamespace(t *testing.T, ns *Namespace) {
+	anterior := namespace
+	namespace = ns
+	t.Cleanup(func() { namespace = anterior })
+}
+
+// empezarEscritura hace create de ruta y addblock de cantidad bloques
+func empezarEscritura(t *testing.T, quien Usuario, ruta string, cantidad int) (int64, []protocolo.Bloque) {
+	t.Helper()
+	respuesta, err := pedirComo(t, quien, protocolo.OpCreate, protocolo.PedidoCreate{Ruta: ruta, Replicacion: 1})
+	if err != nil {
+		t.Fatalf("create %s: %v", ruta, err)
+	}
+	escritura := respuesta.(protocolo.RespuestaCreate).Escritura
+	bloques := []protocolo.Bloque{}
+	for i := 0; i < cantidad; i++ {
+		respuesta, err := pedirComo(t, quien, protocolo.OpAddBlock, protocolo.PedidoEscritura{Escritura: escritura})
+		if err != nil {
+			t.Fatalf("addblock %d de %s: %v", i, ruta, err)
+		}
+		bloques = append(bloques, respuesta.(protocolo.RespuestaBloques).Bloques...)
+	}
+	return escritura, bloques
+}
+
+func nombresDeBloques(t *testing.T, ruta string) []string {
+	t.Helper()
+	fileInfo, err := namespace.Open(root, ruta)
+	if err != nil {
+		t.Fatalf("open %s: %v", ruta, err)
+	}
+	nombres := []string{}
+	for _, dataInfo := range fileInfo.Blocks {
+		nombres = append(nombres, dataInfo.nombre())
+	}
+	return nombres
+}
+
+// Un archivo que se está reemplazando se sigue leyendo como estaba hasta el complete;
+// si la escritura no se completa, no cambia. Put no reemplaza un archivo.
+func TestReemplazarSinCompletar(t *testing.T) {
+	usarNamespace(t, cargarNamespace(t, t.TempDir()))
+	dataNodeVivo(t)
+	rotarClaves()
+
+	put := protocolo.PedidoPut{Ruta: "/f", Bloques: 1, Size: 10, Replicacion: 1}
+	if _, err := pedirComo(t, root, protocolo.OpPut, put); err != nil {
+		t.Fatal(err)
+	}
+	original := nombresDeBloques(t, "/f")
+
+	escritura, _ := empezarEscritura(t, root, "/f", 2)
+	if actual := nombresDeBloques(t, "/f"); !slices.Equal(actual, original) {
+		t.Errorf("bloques de /f durante la escritura: %v, se esperaba %v", actual, original)
+	}
+	if _, err := pedirComo(t, root, protocolo.OpAbandon, protocolo.PedidoEscritura{Escritura: escritura}); err != nil {
+		t.Fatal(err)
+	}
+	if actual := nombresDeBloques(t, "/f"); !slices.Equal(actual, original) {
+		t.Errorf("bloques de /f después de abandonar: %v, se esperaba %v", actual, original)
+	}
+
+	put.Size, put.Bloques = 0, 0
+	if _, err := pedirComo(t, root, protocolo.OpPut, put); err == nil || protocolo.CodigoDe(err) != protocolo.CodigoExiste {
+		t.Errorf("put sobre /f: error %v, se esperaba que ya existe", err)
+	}
+	if actual := nombresDeBloques(t, "/f"); !slices.Equal(actual, original) {
+		t.Errorf("bloques de /f después del put: %v, se esperaba %v", actual, original)
+	}
+
+	escritura, bloques := empezarEscritura(t, root, "/f", 2)
+	complete := protocolo.PedidoEscritura{Escritura: escritura, Size: blockSizePorDefecto + 1}
+	if _, err := pedirComo(t, root, protocolo.OpComplete, complete); err != nil {
+		t.Fatal(err)
+	}
+	nuevos := []string{bloques[0].Nombre, bloques[1].Nombre}
+	if actual := nombresDeBloques(t, "/f"); !slices.Equal(actual, nuevos) {
+		t.Errorf("bloques de /f después del complete: %v, se esperaba %v", actual, nuevos)
+	}
+}
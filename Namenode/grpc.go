@@ -0,0 +1,154 @@
+package main
+
+import (
+	"context"
+	"log"
+	"net"
+
+	"github.com/UriNoHi/Distributed-file-system-DFS-/protocolo"
+	"github.com/UriNoHi/Distributed-file-system-DFS-/protocolo/rpc"
+)
+
+// servidorRPC atiende el servicio gRPC del Namenode con los mismos handlers que el protocolo
+// de frames: cada llamada se convierte en el pedido equivalente
+type servidorRPC struct {
+	rpc.UnimplementedNamenodeServer
+}
+
+func servirRPC(socket net.Listener) error {
+	servidor := rpc.NuevoServidor(configTLS)
+	rpc.RegisterNamenodeServer(servidor, servidorRPC{})
+	return servidor.Serve(socket)
+}
+
+// atenderRPC atiende una llamada gRPC como el pedido op y convierte la respuesta en R
+func atenderRPC[R any](ctx context.Context, op string, mensaje any) (*R, error) {
+	pedido, err := rpc.NuevoPedido(ctx, op, mensaje)
+	if err != nil {
+		return nil, rpc.Status(err)
+	}
+	pedido.Usuario = identificar(pedido.Usuario, rpc.EstadoTLS(ctx))
+	log.Printf("[INFO] Pedido gRPC recibido: %s %s\n", op, pedido.Cuerpo)
+	respuesta, err := atenderPedido(pedido)
+	if err != nil {
+		log.Printf("[WARNING] Pedido gRPC (%s) rechazado: %v\n", op, err)
+		return nil, rpc.Status(err)
+	}
+	var r R
+	if err := rpc.Convertir(respuesta, &r); err != nil {
+		return nil, rpc.Status(err)
+	}
+	return &r, nil
+}
+
+func (servidorRPC) Put(ctx context.Context, p *rpc.PedidoPut) (*rpc.RespuestaBloques, error) {
+	return atenderRPC[rpc.RespuestaBloques](ctx, protocolo.OpPut, p)
+}
+
+func (servidorRPC) Create(ctx context.Context, p *rpc.PedidoCreate) (*rpc.RespuestaCreate, error) {
+	return atenderRPC[rpc.RespuestaCreate](ctx, protocolo.OpCreate, p)
+}
+
+func (servidorRPC) AddBlock(ctx context.Context, p *rpc.PedidoEscritura) (*rpc.RespuestaBloques, error) {
+	return atenderRPC[rpc.RespuestaBloques](ctx, protocolo.OpAddBlock, p)
+}
+
+func (servidorRPC) Complete(ctx context.Context, p *rpc.PedidoEscritura) (*rpc.Vacio, error) {
+	return atenderRPC[rpc.Vacio](ctx, protocolo.OpComplete, p)
+}
+
+func (servidorRPC) Abandon(ctx context.Context, p *rpc.PedidoEscritura) (*rpc.Vacio, error) {
+	return atenderRPC[rpc.Vacio](ctx, protocolo.OpAbandon, p)
+}
+
+func (servidorRPC) Get(ctx context.Context, p *rpc.PedidoRuta) (*rpc.RespuestaBloques, error) {
+	return atenderRPC[rpc.RespuestaBloques](ctx, protocolo.OpGet, p)
+}
+
+func (servidorRPC) Locations(ctx context.Context, p *rpc.PedidoLocations) (*rpc.RespuestaBloques, error) {
+	return atenderRPC[rpc.RespuestaBloques](ctx, protocolo.OpLocations, p)
+}
+
+func (servidorRPC) Stat(ctx context.Context, p *rpc.PedidoRuta) (*rpc.Entrada, error) {
+	return atenderRPC[rpc.Entrada](ctx, protocolo.OpStat, p)
+}
+
+func (servidorRPC) Ls(ctx context.Context, p *rpc.PedidoRuta) (*rpc.RespuestaLs, error) {
+	return atenderRPC[rpc.RespuestaLs](ctx, protocolo.OpLs, p)
+}
+
+func (servidorRPC) Rm(ctx context.Context, p *rpc.PedidoRm) (*rpc.RespuestaBloques, error) {
+	return atenderRPC[rpc.RespuestaBloques](ctx, protocolo.OpRm, p)
+}
+
+func (servidorRPC) Mkdir(ctx context.Context, p *rpc.PedidoMkdir) (*rpc.Vacio, error) {
+	return atenderRPC[rpc.Vacio](ctx, protocolo.OpMkdir, p)
+}
+
+func (servidorRPC) Rmdir(ctx context.Context, p *rpc.PedidoRuta) (*rpc.Vacio, error) {
+	return atenderRPC[rpc.Vacio](ctx, protocolo.OpRmdir, p)
+}
+
+func (servidorRPC) Mv(ctx context.Context, p *rpc.PedidoMv) (*rpc.Vacio, error) {
+	return atenderRPC[rpc.Vacio](ctx, protocolo.OpMv, p)
+}
+
+func (servidorRPC) Setrep(ctx context.Context, p *rpc.PedidoSetrep) (*rpc.Vacio, error) {
+	return atenderRPC[rpc.Vacio](ctx, protocolo.OpSetrep, p)
+}
+
+func (servidorRPC) Chmod(ctx context.Context, p *rpc.PedidoChmod) (*rpc.Vacio, error) {
+	return atenderRPC[rpc.Vacio](ctx, protocolo.OpChmod, p)
+}
+
+func (servidorRPC) Chown(ctx context.Context, p *rpc.PedidoChown) (*rpc.Vacio, error) {
+	return atenderRPC[rpc.Vacio](ctx, protocolo.OpChown, p)
+}
+
+func (servidorRPC) SetQuota(ctx context.Context, p *rpc.PedidoCuota) (*rpc.Vacio, error) {
+	return atenderRPC[rpc.Vacio](ctx, protocolo.OpSetQuota, p)
+}
+
+func (servidorRPC) SetSpaceQuota(ctx context.Context, p *rpc.PedidoCuota) (*rpc.Vacio, error) {
+	return atenderRPC[rpc.Vacio](ctx, protocolo.OpSetSpaceQuota, p)
+}
+
+func (servidorRPC) Count(ctx context.Context, p *rpc.PedidoRuta) (*rpc.RespuestaCount, error) {
+	return atenderRPC[rpc.RespuestaCount](ctx, protocolo.OpCount, p)
+}
+
+func (servidorRPC) BlockSize(ctx context.Context, p *rpc.Vacio) (*rpc.RespuestaBlockSize, error) {
+	return atenderRPC[rpc.RespuestaBlockSize](ctx, protocolo.OpBlockSize, p)
+}
+
+func (servidorRPC) Nodes(ctx context.Context, p *rpc.Vacio) (*rpc.RespuestaLineas, error) {
+	return atenderRPC[rpc.RespuestaLineas](ctx, protocolo.OpNodes, p)
+}
+
+func (servidorRPC) Fsck(ctx context.Context, p *rpc.Vacio) (*rpc.RespuestaLineas, error) {
+	return atenderRPC[rpc.RespuestaLineas](ctx, protocolo.OpFsck, p)
+}
+
+func (servidorRPC) Register(ctx context.Context, p *rpc.PedidoRegistro) (*rpc.RespuestaClaves, error) {
+	return atenderRPC[rpc.RespuestaClaves](ctx, protocolo.OpRegister, p)
+}
+
+func (servidorRPC) Heartbeat(ctx context.Context, p *rpc.PedidoHeartbeat) (*rpc.RespuestaClaves, error) {
+	return atenderRPC[rpc.RespuestaClaves](ctx, protocolo.OpHeartbeat, p)
+}
+
+func (servidorRPC) BlockReport(ctx context.Context, p *rpc.PedidoReporteCompleto) (*rpc.Vacio, error) {
+	return atenderRPC[rpc.Vacio](ctx, protocolo.OpBlockReport, p)
+}
+
+func (servidorRPC) BlockReceived(ctx context.Context, p *rpc.PedidoReporteIncremental) (*rpc.Vacio, error) {
+	return atenderRPC[rpc.Vacio](ctx, protocolo.OpBlockReceived, p)
+}
+
+func (servidorRPC) BlockDeleted(ctx context.Context, p *rpc.PedidoReporteIncremental) (*rpc.Vacio, error) {
+	return atenderRPC[rpc.Vacio](ctx, protocolo.OpBlockDeleted, p)
+}
+
+func (servidorRPC) BlockCorrupt(ctx context.Context, p *rpc.PedidoReporteIncremental) (*rpc.Vacio, error) {
+	return atenderRPC[rpc.Vacio](ctx, protocolo.OpBlockCorrupt, p)
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/UriNoHi/Distributed-file-system-DFS-/protocolo"
+)
+
+// Estados posibles de un DataNode según su último heartbeat
+const (
+	estadoVivo    = "vivo"
+	estadoStale   = "stale"
+	estadoMuerto  = "muerto"
+	limiteStale   = 10 * time.Second // sin heartbeat por más de esto => stale
+	limiteMuerto  = 30 * time.Second // sin heartbeat por más de esto => muerto
+	intervaloScan = 5 * time.Second
+)
+
+type NodeStatus struct {
+	Address       string
+	StorageID     string
+	Capacity      int64
+	Used          int64
+	Blocks        int
+	LastHeartbeat time.Time
+	Estado        string
+}
+
+var nodeStatus = map[string]*NodeStatus{}
+
+// Se contesta a los mensajes de un DataNode que no se registró, por ejemplo porque el
+// Namenode se reinició; el DataNode se vuelve a registrar
+var errNodoDesconocido = protocolo.Errorf(protocolo.CodigoNodoDesconocido, "nodo desconocido")
+var nodeStatusMutex sync.Mutex
+
+// registrarNodosSemilla agrega los nodos del archivo nodeList como conocidos.
+// Quedan muertos hasta que se registren o manden su primer heartbeat.
+func registrarNodosSemilla() {
+	nodeStatusMutex.Lock()
+	defer nodeStatusMutex.Unlock()
+	for _, address := range nodes {
+		if _, exists := nodeStatus[address]; !exists {
+			nodeStatus[address] = &NodeStatus{Address: address, Estado: estadoMuerto}
+		}
+	}
+}
+
+// heartbeat: el DataNode sigue vivo, con su capacidad, el espacio usado y la cantidad de bloques
+func procesarHeartbeat(pedido *protocolo.Pedido) error {
+	var heartbeat protocolo.PedidoHeartbeat
+	if err := pedido.Leer(&heartbeat); err != nil {
+		return err
+	}
+	address, capacity, used, blocks := heartbeat.Direccion, heartbeat.Capacidad, heartbeat.Usado, heartbeat.Bloques
+	if err := autenticarDataNode(pedido, address, heartbeat.Credencial); err != nil {
+		return err
+	}
+
+	nodeStatusMutex.Lock()
+	status, exists := nodeStatus[address]
+	if !exists {
+		nodeStatusMutex.Unlock()
+		log.Printf("[WARNING] Heartbeat de un DataNode desconocido %s, se ignora\n", address)
+		return errNodoDesconocido
+	}
+	if status.Estado != estadoVivo {
+		log.Printf("[INFO] DataNode %s pasa de %s a %s\n", address, status.Estado, estadoVivo)
+	}
+	status.Capacity = capacity
+	status.Used = used
+	status.Blocks = blocks
+	status.LastHeartbeat = time.Now()
+	status.Estado = estadoVivo
+	nodeStatusMutex.Unlock()
+	return nil
+}
+
+// monitorDeNodos revisa periódicamente los heartbeats y actualiza el estado de cada nodo
+func monitorDeNodos() {
+	for {
+		time.Sleep(intervaloScan)
+
+		nodeStatusMutex.Lock()
+		for _, status := range nodeStatus {
+			nuevoEstado := calcularEstado(status.LastHeartbeat)
+			if nuevoEstado != status.Estado {
+				log.Printf("[WARNING] DataNode %s pasa de %s a %s\n", status.Address, status.Estado, nuevoEstado)
+				status.Estado = nuevoEstado
+			}
+		}
+		nodeStatusMutex.Unlock()
+	}
+}
+
+func calcularEstado(lastHeartbeat time.Time) string {
+	if lastHeartbeat.IsZero() {
+		return estadoMuerto
+	}
+	sinHeartbeat := time.Since(lastHeartbeat)
+	switch {
+	case sinHeartbeat > limiteMuerto:
+		return estadoMuerto
+	case sinHeartbeat > limiteStale:
+		return estadoStale
+	default:
+		return estadoVivo
+	}
+}
+
+// nodosVivos devuelve los DataNodes que pueden recibir bloques, en el orden de la lista de nodos
+func nodosVivos() []string {
+	nodeStatusMutex.Lock()
+	defer nodeStatusMutex.Unlock()
+
+	vivos := []string{}
+	for _, address := range nodes {
+		status, exists := nodeStatus[address]
+		if exists && calcularEstado(status.LastHeartbeat) == estadoVivo {
+			vivos = append(vivos, address)
+		}
+	}
+	return vivos
+}
+
+// reporteDeNodos arma una línea con el estado de cada DataNode
+func reporteDeNodos() []string {
+	nodeStatusMutex.Lock()
+	defer nodeStatusMutex.Unlock()
+
+	reporte := []string{}
+	for _, address := range nodes {
+		status, exists := nodeStatus[address]
+		if !exists {
+			continue
+		}
+		reporte = append(reporte, fmt.Sprintf("%s %s capacidad=%d usado=%d bloques=%d",
+			address, calcularEstado(status.LastHeartbeat), status.Capacity, status.Used, status.Blocks))
+	}
+	return reporte
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Antes del edit log, metadata.json era un mapa de nombre de archivo a sus bloques, sin
+// directorios ni versión. En el formato original cada entrada de un bloque tenía un solo
+// DataNode y la copia de respaldo se anotaba aparte, en "<archivo>_backup"; con la
+// replicación por archivo cada archivo pasó a ser un FileInfo. Los dos se convierten al
+// Files de imagenMetadata, que Cargar pasa al árbol con los bloques "<archivo>_block_<i>"
+// con los que los guardaron los DataNodes.
+//
+// Ninguno guardaba el tamaño del archivo: se toma el máximo que entra en sus bloques de
+// blockSizeAnterior. El get de un archivo entero lo corta a lo que mandan los DataNodes.
+
+// versionDeImagen es la versión del formato del checkpoint. Las imágenes sin versión con
+// txid son las de antes de que se agregara; sin txid, el mapa de archivos de antes del edit log.
+const versionDeImagen = 1
+
+// sufijoDeRespaldo marca, en el formato original, la entrada con la copia de un archivo
+const sufijoDeRespaldo = "_backup"
+
+// bloqueOriginal es una réplica de un bloque en el formato original
+type bloqueOriginal struct {
+	Block    int    `json:"block"`
+	DataNode string `json:"node"`
+}
+
+// leerImagen interpreta el contenido de metadata.json en cualquiera de sus formatos
+func leerImagen(data []byte) (imagenMetadata, error) {
+	var campos map[string]json.RawMessage
+	if err := json.Unmarshal(data, &campos); err != nil {
+		return imagenMetadata{}, err
+	}
+	imagen := imagenMetadata{}
+	if esNumero(campos["version"]) || esNumero(campos["txid"]) {
+		err := json.Unmarshal(data, &imagen)
+		return imagen, err
+	}
+	files, err := migrarMetadataAnterior(campos)
+	if err != nil {
+		return imagenMetadata{}, fmt.Errorf("no es un checkpoint ni la metadata de antes del edit log: %w", err)
+	}
+	imagen.Files = files
+	return imagen, nil
+}
+
+func esNumero(valor json.RawMessage) bool {
+	valor = bytes.TrimSpace(valor)
+	return len(valor) > 0 && (valor[0] == '-' || valor[0] >= '0' && valor[0] <= '9')
+}
+
+// migrarMetadataAnterior convierte el mapa de archivos de antes del edit log
+func migrarMetadataAnterior(campos map[string]json.RawMessage) (map[string]*FileInfo, error) {
+	files := map[string]*FileInfo{}
+	respaldos := map[string][]bloqueOriginal{}
+	for nombre, valor := range campos {
+		if valor = bytes.TrimSpace(valor); len(valor) > 0 && valor[0] == '{' {
+			var fileInfo FileInfo
+			if err := json.Unmarshal(valor, &fileInfo); err != nil {
+				return nil, fmt.Errorf("%s: %w", nombre, err)
+			}
+			files[nombre] = &fileInfo
+			continue
+		}
+		var bloques []bloqueOriginal
+		if err := json.Unmarshal(valor, &bloques); err != nil {
+			return nil, fmt.Errorf("%s: %w", nombre, err)
+		}
+		// Un archivo que se llama así de verdad no tiene otro con el nombre sin el sufijo
+		if original, esRespaldo := strings.CutSuffix(nombre, sufijoDeRespaldo); esRespaldo && campos[original] != nil {
+			respaldos[original] = bloques
+			continue
+		}
+		files[nombre] = &FileInfo{Replication: replicacionPorDefecto}
+		agregarReplicas(files[nombre], bloques)
+	}
+	// La copia de respaldo de cada bloque es una réplica más del mismo bloque
+	for nombre, bloques := range respaldos {
+		agregarReplicas(files[nombre], bloques)
+	}
+	for _, fileInfo := range files {
+		if fileInfo.BlockSize == 0 {
+			fileInfo.BlockSize = blockSizeAnterior
+		}
+		if fileInfo.Size == 0 {
+			fileInfo.Size = int64(len(fileInfo.Blocks)) * fileInfo.BlockSize
+		}
+		if fileInfo.Replication < 1 {
+			fileInfo.Replication = replicacionPorDefecto
+		}
+		if fileInfo.ModTime.IsZero() {
+			fileInfo.ModTime = time.Now()
+		}
+	}
+	return files, nil
+}
+
+// agregarReplicas suma a los bloques del archivo los DataNodes de las entradas originales
+func agregarReplicas(fileInfo *FileInfo, bloques []bloqueOriginal) {
+	for _, bloque := range bloques {
+		if bloque.Block < 0 {
+			continue
+		}
+		for len(fileInfo.Blocks) <= bloque.Block {
+			fileInfo.Blocks = append(fileInfo.Blocks, DataInfo{Block: len(fileInfo.Blocks)})
+		}
+		dataInfo := &fileInfo.Blocks[bloque.Block]
+		if bloque.DataNode != "" && !slices.Contains(dataInfo.DataNodes, bloque.DataNode) {
+			dataInfo.DataNodes = append(dataInfo.DataNodes, bloque.DataNode)
+		}
+	}
+}
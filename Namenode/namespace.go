@@ -0,0 +1,637 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	errArchivoNoExiste   = errors.New("no existe el archivo")
+	errRutaInvalida      = errors.New("la ruta tiene que ser absoluta")
+	errNoEsDirectorio    = errors.New("no es un directorio")
+	errEsDirectorio      = errors.New("es un directorio")
+	errDirectorioNoVacio = errors.New("el directorio no está vacío")
+	errYaExiste          = errors.New("ya existe")
+	errDestinoInvalido   = errors.New("no se puede mover un directorio adentro de sí mismo")
+	errPermiso           = errors.New("permiso denegado")
+)
+
+// Directorio es un nodo del árbol del namespace. Las cuotas en 0 son sin cuota (ver cuotas.go).
+type Directorio struct {
+	Permisos
+	ModTime      time.Time              `json:"mtime"`
+	Cuota        int64                  `json:"quota,omitempty"`
+	CuotaEspacio int64                  `json:"spaceQuota,omitempty"`
+	Dirs         map[string]*Directorio `json:"dirs,omitempty"`
+	Files        map[string]*FileInfo   `json:"files,omitempty"`
+	// uso es lo que hay debajo, solo en los directorios con cuota
+	uso *Uso
+}
+
+// Entrada es una línea del ls
+type Entrada struct {
+	Nombre      string
+	EsDir       bool
+	Size        int64
+	Replication int
+	BlockSize   int64
+	ModTime     time.Time
+	Permisos
+}
+
+// Los archivos guardados antes de poder elegir el tamaño de bloque usaban bloques de 1KB
+const blockSizeAnterior = 1024
+
+// Namespace guarda el árbol de directorios y archivos. Todas las goroutines (clientes,
+// reportes de DataNodes y monitores) pasan por sus métodos: las lecturas comparten
+// el lock y las escrituras lo toman exclusivo mientras se escriben en el edit log
+// y se aplican, así el orden del log es el mismo orden en que se aplican.
+// Las lecturas devuelven copias para que nadie toque la metadata sin el lock.
+// Las rutas son absolutas ("/dir/archivo") y se normalizan con normalizarRuta.
+type Namespace struct {
+	mu   sync.RWMutex
+	raiz *Directorio
+
+	dir                      string // carpeta del checkpoint y del edit log
+	editLog                  *os.File
+	ultimoTxID               int64
+	edicionesDesdeCheckpoint int
+	editLogFallido           error // si no se pudo sacar una edición incompleta, no se registran más
+
+	ultimoBloqueID int64 // los IDs y generation stamps nunca se reutilizan
+	ultimoGenStamp int64
+}
+
+// namespace es el único Namespace del Namenode
+var namespace = NewNamespace(".")
+
+func NewNamespace(dir string) *Namespace {
+	return &Namespace{raiz: nuevoDirectorio(time.Now()), dir: dir}
+}
+
+func nuevoDirectorio(modTime time.Time) *Directorio {
+	return &Directorio{ModTime: modTime, Dirs: map[string]*Directorio{}, Files: map[string]*FileInfo{}}
+}
+
+func (ns *Namespace) ruta(nombre string) string {
+	return filepath.Join(ns.dir, nombre)
+}
+
+// normalizarRuta exige una ruta absoluta y la limpia: "/a//b/../c/" -> "/a/c"
+func normalizarRuta(ruta string) (string, error) {
+	if !strings.HasPrefix(ruta, "/") {
+		return "", errRutaInvalida
+	}
+	return path.Clean(ruta), nil
+}
+
+// partesDeRuta separa una ruta normalizada en sus componentes; la raíz no tiene ninguno
+func partesDeRuta(ruta string) []string {
+	if ruta == "/" {
+		return nil
+	}
+	return strings.Split(strings.TrimPrefix(ruta, "/"), "/")
+}
+
+// buscarDirectorio recorre el árbol hasta el directorio de la ruta
+func (ns *Namespace) buscarDirectorio(ruta string) (*Directorio, error) {
+	actual := ns.raiz
+	for _, parte := range partesDeRuta(ruta) {
+		siguiente, exists := actual.Dirs[parte]
+		if !exists {
+			if _, esArchivo := actual.Files[parte]; esArchivo {
+				return nil, errNoEsDirectorio
+			}
+			return nil, errArchivoNoExiste
+		}
+		actual = siguiente
+	}
+	return actual, nil
+}
+
+func (ns *Namespace) buscarArchivo(ruta string) (*FileInfo, error) {
+	padre, err := ns.buscarDirectorio(path.Dir(ruta))
+	if err != nil {
+		return nil, err
+	}
+	nombre := path.Base(ruta)
+	if fileInfo, exists := padre.Files[nombre]; exists {
+		return fileInfo, nil
+	}
+	if _, exists := padre.Dirs[nombre]; exists {
+		return nil, errEsDirectorio
+	}
+	return nil, errArchivoNoExiste
+}
+
+// comprobarPadres verifica que ningún componente de la ruta, salvo el último, sea un archivo
+func (ns *Namespace) comprobarPadres(ruta string) error {
+	actual := ns.raiz
+	for _, parte := range partesDeRuta(path.Dir(ruta)) {
+		if _, esArchivo := actual.Files[parte]; esArchivo {
+			return errNoEsDirectorio
+		}
+		siguiente, exists := actual.Dirs[parte]
+		if !exists {
+			return nil
+		}
+		actual = siguiente
+	}
+	return nil
+}
+
+// Put crea el archivo o reemplaza su metadata si ya existía. Los directorios
+// padres que falten se crean. Les asigna ID y generation stamp a los bloques de
+// fileInfo y devuelve la metadata anterior del archivo, o nil si no existía.
+// Los bloques de la versión anterior no se reutilizan. Un archivo nuevo es de quien
+// lo crea; uno reemplazado conserva sus permisos. Falla si pasa alguna cuota.
+func (ns *Namespace) Put(quien Usuario, ruta string, fileInfo *FileInfo) (*FileInfo, error) {
+	return ns.guardarArchivo(quien, ruta, fileInfo, true, true)
+}
+
+// Crear es como Put pero no reemplaza: falla con errYaExiste si el archivo ya existe
+func (ns *Namespace) Crear(quien Usuario, ruta string, fileInfo *FileInfo) error {
+	_, err := ns.guardarArchivo(quien, ruta, fileInfo, true, false)
+	return err
+}
+
+// Complete es como Put para un archivo escrito de a un bloque: los bloques ya tienen
+// el ID y el generation stamp que se les reservó con NewGenStamp y AllocateBlock
+func (ns *Namespace) Complete(quien Usuario, ruta string, fileInfo *FileInfo) (*FileInfo, error) {
+	return ns.guardarArchivo(quien, ruta, fileInfo, false, true)
+}
+
+func (ns *Namespace) guardarArchivo(quien Usuario, ruta string, fileInfo *FileInfo, asignarBloques bool, reemplazar bool) (*FileInfo, error) {
+	ruta, err := normalizarRuta(ruta)
+	if err != nil {
+		return nil, err
+	}
+	ns.mu.Lock()
+	defer ns.mu.Unlock()
+
+	anterior, padre, err := ns.comprobarArchivoNuevo(quien, ruta)
+	if err != nil {
+		return nil, err
+	}
+	if anterior != nil && !reemplazar {
+		return nil, errYaExiste
+	}
+	ancestros := ns.ancestros(path.Dir(ruta))
+	nombres := faltantes(path.Dir(ruta), ancestros)
+	espacio := espacioDe(fileInfo)
+	if anterior != nil {
+		espacio -= espacioDe(anterior)
+	} else {
+		nombres++
+	}
+	if err := comprobarCuotas(ancestros, nombres, espacio); err != nil {
+		return nil, err
+	}
+	if anterior != nil {
+		fileInfo.Permisos = anterior.Permisos
+	} else {
+		fileInfo.Permisos = Permisos{Owner: quien.Nombre, Group: padre.Group, Mode: modoDeArchivo}
+	}
+	if asignarBloques {
+		ns.ultimoGenStamp++
+		for i := range fileInfo.Blocks {
+			ns.ultimoBloqueID++
+			fileInfo.Blocks[i].ID = ns.ultimoBloqueID
+			fileInfo.Blocks[i].GenStamp = ns.ultimoGenStamp
+			fileInfo.Blocks[i].Name = ""
+		}
+	}
+	copia := fileInfo.copia()
+	return anterior, ns.registrar(Edicion{Op: "put", File: ruta, Info: &copia})
+}
+
+// CheckCreate comprueba que se pueda crear un archivo en la ruta, antes de empezar a escribirlo
+func (ns *Namespace) CheckCreate(quien Usuario, ruta string) error {
+	ruta, err := normalizarRuta(ruta)
+	if err != nil {
+		return err
+	}
+	ns.mu.RLock()
+	defer ns.mu.RUnlock()
+	anterior, _, err := ns.comprobarArchivoNuevo(quien, ruta)
+	if err != nil || anterior != nil {
+		return err
+	}
+	ancestros := ns.ancestros(path.Dir(ruta))
+	return comprobarCuotas(ancestros, faltantes(path.Dir(ruta), ancestros)+1, 0)
+}
+
+// comprobarArchivoNuevo devuelve la metadata del archivo que se va a reemplazar, o nil
+// si no existe, y el directorio existente más profundo de la ruta, donde se crea el
+// archivo o sus padres. Crear pide escritura en ese directorio, y reemplazar también en
+// el archivo. Se llama con ns.mu tomado.
+func (ns *Namespace) comprobarArchivoNuevo(quien Usuario, ruta string) (*FileInfo, *Directorio, error) {
+	if ruta == "/" {
+		return nil, nil, errEsDirectorio
+	}
+	if err := ns.comprobarPadres(ruta); err != nil {
+		return nil, nil, err
+	}
+	padre, err := ns.comprobarEscrituraEn(quien, ruta)
+	if err != nil {
+		return nil, nil, err
+	}
+	existente, err := ns.buscarArchivo(ruta)
+	switch err {
+	case nil:
+		if !quien.puede(existente.Permisos, accesoEscritura) {
+			return nil, nil, errPermiso
+		}
+		copia := existente.copia()
+		return &copia, padre, nil
+	case errEsDirectorio:
+		return nil, nil, err
+	}
+	return nil, padre, nil
+}
+
+// NewGenStamp reserva el generation stamp de un archivo que se escribe de a un bloque
+func (ns *Namespace) NewGenStamp() (int64, error) {
+	ns.mu.Lock()
+	defer ns.mu.Unlock()
+	genStamp := ns.ultimoGenStamp + 1
+	return genStamp, ns.registrar(Edicion{Op: "alloc", Info: &FileInfo{Blocks: []DataInfo{{GenStamp: genStamp}}}})
+}
+
+// AllocateBlock reserva el ID de un bloque nuevo. La reserva va al edit log para que
+// después de un reinicio no se repita un nombre que ya puede estar en los DataNodes.
+func (ns *Namespace) AllocateBlock() (int64, error) {
+	ns.mu.Lock()
+	defer ns.mu.Unlock()
+	id := ns.ultimoBloqueID + 1
+	return id, ns.registrar(Edicion{Op: "alloc", Info: &FileInfo{Blocks: []DataInfo{{ID: id}}}})
+}
+
+// Rename mueve un archivo o un directorio. Si el destino es un directorio existente
+// se mueve adentro de él, como mv. No se pisa un archivo que ya exista. Pide escritura
+// en el directorio de origen y en el de destino.
+func (ns *Namespace) Rename(quien Usuario, origen string, destino string) error {
+	origen, err := normalizarRuta(origen)
+	if err != nil {
+		return err
+	}
+	destino, err = normalizarRuta(destino)
+	if err != nil {
+		return err
+	}
+	ns.mu.Lock()
+	defer ns.mu.Unlock()
+
+	if origen == "/" {
+		return errRutaInvalida
+	}
+	if _, err := ns.comprobarEscrituraEn(quien, origen); err != nil {
+		return err
+	}
+	_, err = ns.buscarArchivo(origen)
+	esDir := err == errEsDirectorio
+	if err != nil && !esDir {
+		return err
+	}
+	if _, err := ns.buscarDirectorio(destino); err == nil {
+		destino = path.Join(destino, path.Base(origen))
+	}
+	if destino == origen {
+		return nil
+	}
+	if esDir && strings.HasPrefix(destino, origen+"/") {
+		return errDestinoInvalido
+	}
+	if err := ns.comprobarPadres(destino); err != nil {
+		return err
+	}
+	if _, err := ns.buscarDirectorio(path.Dir(destino)); err != nil {
+		return err
+	}
+	if _, err := ns.buscarArchivo(destino); err != errArchivoNoExiste {
+		return errYaExiste
+	}
+	if _, err := ns.comprobarEscrituraEn(quien, destino); err != nil {
+		return err
+	}
+	// Lo movido solo cuenta de nuevo para las cuotas de los directorios donde no estaba
+	var movido Uso
+	if esDir {
+		directorio, _ := ns.buscarDirectorio(origen)
+		movido = directorio.usoActual()
+	} else {
+		fileInfo, _ := ns.buscarArchivo(origen)
+		movido = usoDeArchivo(fileInfo)
+	}
+	nuevos := fueraDe(ns.ancestros(path.Dir(destino)), ns.ancestros(path.Dir(origen)))
+	if err := comprobarCuotas(nuevos, movido.nombres(), movido.Espacio); err != nil {
+		return err
+	}
+	return ns.registrar(Edicion{Op: "mv", File: origen, Destino: destino})
+}
+
+// Mkdir crea un directorio. Con padres=true crea los que falten y no falla si ya existe.
+// Los directorios nuevos son de quien los crea.
+func (ns *Namespace) Mkdir(quien Usuario, ruta string, padres bool) error {
+	ruta, err := normalizarRuta(ruta)
+	if err != nil {
+		return err
+	}
+	ns.mu.Lock()
+	defer ns.mu.Unlock()
+
+	if err := ns.comprobarPadres(ruta); err != nil {
+		return err
+	}
+	if _, err := ns.buscarDirectorio(ruta); err == nil {
+		if padres {
+			return nil
+		}
+		return errYaExiste
+	}
+	if _, err := ns.buscarArchivo(ruta); err == nil {
+		return errYaExiste
+	}
+	if !padres {
+		if _, err := ns.buscarDirectorio(path.Dir(ruta)); err != nil {
+			return err
+		}
+	}
+	if _, err := ns.comprobarEscrituraEn(quien, ruta); err != nil {
+		return err
+	}
+	ancestros := ns.ancestros(ruta)
+	if err := comprobarCuotas(ancestros, faltantes(ruta, ancestros), 0); err != nil {
+		return err
+	}
+	return ns.registrar(Edicion{Op: "mkdir", File: ruta, Owner: quien.Nombre})
+}
+
+// Remove borra un archivo, o un directorio con todo su contenido si recursivo es true.
+// Devuelve la metadata de los archivos borrados para poder borrar sus bloques.
+// Pide escritura en el directorio que lo contiene y, para vaciar un directorio, todos
+// los permisos en él y en cada subdirectorio.
+func (ns *Namespace) Remove(quien Usuario, ruta string, recursivo bool) (map[string]FileInfo, error) {
+	ruta, err := normalizarRuta(ruta)
+	if err != nil {
+		return nil, err
+	}
+	ns.mu.Lock()
+	defer ns.mu.Unlock()
+
+	if ruta == "/" {
+		return nil, errRutaInvalida
+	}
+	if _, err := ns.comprobarEscrituraEn(quien, ruta); err != nil {
+		return nil, err
+	}
+	borrados := map[string]FileInfo{}
+	fileInfo, err := ns.buscarArchivo(ruta)
+	switch err {
+	case nil:
+		borrados[ruta] = fileInfo.copia()
+	case errEsDirectorio:
+		if !recursivo {
+			return nil, errEsDirectorio
+		}
+		directorio, _ := ns.buscarDirectorio(ruta)
+		if err := comprobarSubarbol(quien, directorio); err != nil {
+			return nil, err
+		}
+		aplanar(ruta, directorio, borrados)
+	default:
+		return nil, err
+	}
+	return borrados, ns.registrar(Edicion{Op: "rm", File: ruta})
+}
+
+// Rmdir borra un directorio vacío
+func (ns *Namespace) Rmdir(quien Usuario, ruta string) error {
+	ruta, err := normalizarRuta(ruta)
+	if err != nil {
+		return err
+	}
+	ns.mu.Lock()
+	defer ns.mu.Unlock()
+
+	if ruta == "/" {
+		return errRutaInvalida
+	}
+	if _, err := ns.comprobarEscrituraEn(quien, ruta); err != nil {
+		return err
+	}
+	directorio, err := ns.buscarDirectorio(ruta)
+	if err != nil {
+		return err
+	}
+	if len(directorio.Dirs) > 0 || len(directorio.Files) > 0 {
+		return errDirectorioNoVacio
+	}
+	return ns.registrar(Edicion{Op: "rm", File: ruta})
+}
+
+// SetReplication cambia la replicación pedida y devuelve la anterior. Pide escritura en el
+// archivo; más réplicas tienen que entrar en la cuota de espacio.
+func (ns *Namespace) SetReplication(quien Usuario, ruta string, replicacion int) (int, error) {
+	ruta, err := normalizarRuta(ruta)
+	if err != nil {
+		return 0, err
+	}
+	ns.mu.Lock()
+	defer ns.mu.Unlock()
+	if _, err := ns.recorrer(quien, ruta); err != nil {
+		return 0, err
+	}
+	fileInfo, err := ns.buscarArchivo(ruta)
+	if err != nil {
+		return 0, err
+	}
+	if !quien.puede(fileInfo.Permisos, accesoEscritura) {
+		return 0, errPermiso
+	}
+	espacio := fileInfo.Size * int64(replicacion-fileInfo.Replication)
+	if err := comprobarCuotas(ns.ancestros(path.Dir(ruta)), 0, espacio); err != nil {
+		return 0, err
+	}
+	anterior := fileInfo.Replication
+	return anterior, ns.registrar(Edicion{Op: "setrep", File: ruta, Replication: replicacion})
+}
+
+// AddReplica agrega un DataNode a las réplicas de un bloque
+func (ns *Namespace) AddReplica(ruta string, block int, dataNode string) error {
+	ns.mu.Lock()
+	defer ns.mu.Unlock()
+	fileInfo, err := ns.buscarArchivo(ruta)
+	if err != nil {
+		return err
+	}
+	if block >= len(fileInfo.Blocks) {
+		return errArchivoNoExiste
+	}
+	nodos := []string{}
+	for _, actual := range fileInfo.Blocks[block].DataNodes {
+		if actual == dataNode {
+			return nil
+		}
+		nodos = append(nodos, actual)
+	}
+	nodos = append(nodos, dataNode)
+	return ns.registrar(Edicion{Op: "setnodes", File: ruta, Block: block, Nodes: nodos})
+}
+
+// DropReplicas saca DataNodes de las réplicas de un bloque
+func (ns *Namespace) DropReplicas(ruta string, block int, dataNodes []string) error {
+	ns.mu.Lock()
+	defer ns.mu.Unlock()
+	fileInfo, err := ns.buscarArchivo(ruta)
+	if err != nil {
+		return err
+	}
+	if block >= len(fileInfo.Blocks) {
+		return errArchivoNoExiste
+	}
+	sacar := map[string]bool{}
+	for _, dataNode := range dataNodes {
+		sacar[dataNode] = true
+	}
+	nodos := []string{}
+	for _, actual := range fileInfo.Blocks[block].DataNodes {
+		if !sacar[actual] {
+			nodos = append(nodos, actual)
+		}
+	}
+	return ns.registrar(Edicion{Op: "setnodes", File: ruta, Block: block, Nodes: nodos})
+}
+
+// Open devuelve una copia de la metadata del archivo para leerlo. Pide lectura en el archivo.
+func (ns *Namespace) Open(quien Usuario, ruta string) (FileInfo, error) {
+	ruta, err := normalizarRuta(ruta)
+	if err != nil {
+		return FileInfo{}, err
+	}
+	ns.mu.RLock()
+	defer ns.mu.RUnlock()
+	if _, err := ns.recorrer(quien, ruta); err != nil {
+		return FileInfo{}, err
+	}
+	fileInfo, err := ns.buscarArchivo(ruta)
+	if err != nil {
+		return FileInfo{}, err
+	}
+	if !quien.puede(fileInfo.Permisos, accesoLectura) {
+		return FileInfo{}, errPermiso
+	}
+	return fileInfo.copia(), nil
+}
+
+// Stat devuelve la entrada de un archivo o directorio. Solo pide poder llegar hasta él.
+func (ns *Namespace) Stat(quien Usuario, ruta string) (Entrada, error) {
+	ruta, err := normalizarRuta(ruta)
+	if err != nil {
+		return Entrada{}, err
+	}
+	ns.mu.RLock()
+	defer ns.mu.RUnlock()
+	if _, err := ns.recorrer(quien, ruta); err != nil {
+		return Entrada{}, err
+	}
+
+	fileInfo, err := ns.buscarArchivo(ruta)
+	if err == nil {
+		return entradaDeArchivo(path.Base(ruta), fileInfo), nil
+	}
+	directorio, err := ns.buscarDirectorio(ruta)
+	if err != nil {
+		return Entrada{}, err
+	}
+	return entradaDeDirectorio(path.Base(ruta), directorio), nil
+}
+
+// List devuelve el contenido de un directorio, o la entrada del archivo si la ruta es un
+// archivo. Listar un directorio pide lectura en él.
+func (ns *Namespace) List(quien Usuario, ruta string) ([]Entrada, error) {
+	ruta, err := normalizarRuta(ruta)
+	if err != nil {
+		return nil, err
+	}
+	ns.mu.RLock()
+	defer ns.mu.RUnlock()
+	if _, err := ns.recorrer(quien, ruta); err != nil {
+		return nil, err
+	}
+
+	if ruta != "/" {
+		if fileInfo, err := ns.buscarArchivo(ruta); err == nil {
+			return []Entrada{entradaDeArchivo(path.Base(ruta), fileInfo)}, nil
+		}
+	}
+	directorio, err := ns.buscarDirectorio(ruta)
+	if err != nil {
+		return nil, err
+	}
+	if !quien.puede(directorio.Permisos, accesoLectura) {
+		return nil, errPermiso
+	}
+	entradas := []Entrada{}
+	for nombre, hijo := range directorio.Dirs {
+		entradas = append(entradas, entradaDeDirectorio(nombre, hijo))
+	}
+	for nombre, fileInfo := range directorio.Files {
+		entradas = append(entradas, entradaDeArchivo(nombre, fileInfo))
+	}
+	sort.Slice(entradas, func(i, j int) bool { return entradas[i].Nombre < entradas[j].Nombre })
+	return entradas, nil
+}
+
+func entradaDeArchivo(nombre string, fileInfo *FileInfo) Entrada {
+	return Entrada{Nombre: nombre, Size: fileInfo.Size, Replication: fileInfo.Replication, BlockSize: fileInfo.blockSizeEfectivo(), ModTime: fileInfo.ModTime, Permisos: fileInfo.Permisos}
+}
+
+func entradaDeDirectorio(nombre string, directorio *Directorio) Entrada {
+	return Entrada{Nombre: nombre, EsDir: true, ModTime: directorio.ModTime, Permisos: directorio.Permisos}
+}
+
+// blockSizeEfectivo es el tamaño de los bloques del archivo, también para los guardados
+// antes de que se pudiera elegir
+func (fileInfo *FileInfo) blockSizeEfectivo() int64 {
+	if fileInfo.BlockSize == 0 {
+		return blockSizeAnterior
+	}
+	return fileInfo.BlockSize
+}
+
+// Snapshot devuelve una copia de todos los archivos indexados por ruta,
+// para los monitores que recorren la metadata entera
+func (ns *Namespace) Snapshot() map[string]FileInfo {
+	ns.mu.RLock()
+	defer ns.mu.RUnlock()
+	copia := map[string]FileInfo{}
+	aplanar("/", ns.raiz, copia)
+	return copia
+}
+
+// aplanar agrega a archivos todos los archivos debajo del directorio
+func aplanar(ruta string, directorio *Directorio, archivos map[string]FileInfo) {
+	for nombre, fileInfo := range directorio.Files {
+		archivos[path.Join(ruta, nombre)] = fileInfo.copia()
+	}
+	for nombre, hijo := range directorio.Dirs {
+		aplanar(path.Join(ruta, nombre), hijo, archivos)
+	}
+}
+
+func (fileInfo *FileInfo) copia() FileInfo {
+	copia := *fileInfo
+	copia.Blocks = make([]DataInfo, len(fileInfo.Blocks))
+	for i, dataInfo := range fileInfo.Blocks {
+		copia.Blocks[i] = dataInfo
+		copia.Blocks[i].DataNodes = append([]string{}, dataInfo.DataNodes...)
+	}
+	return copia
+}
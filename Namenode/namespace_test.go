@@ -0,0 +1,83 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+// Muchos clientes a la vez haciendo put, get, ls, mv y rm sobre el mismo namespace.
+// Se corre con go test -race: lo que importa es que no haya carreras ni pánicos, y que
+// al final el namespace quede como lo dejaron las operaciones que no fallaron.
+func TestNamespaceConcurrente(t *testing.T) {
+	ns := cargarNamespace(t, t.TempDir())
+	const clientes = 16
+	const operaciones = 50
+
+	var wg sync.WaitGroup
+	for c := 0; c < clientes; c++ {
+		wg.Add(1)
+		go func(c int) {
+			defer wg.Done()
+			dir := fmt.Sprintf("/c%d", c%4) // varios clientes comparten cada directorio
+			for i := 0; i < operaciones; i++ {
+				ruta := fmt.Sprintf("%s/f%d-%d", dir, c, i)
+				if _, err := ns.Put(root, ruta, archivoDePrueba(int64(i), 1)); err != nil {
+					t.Errorf("Put(%s): %v", ruta, err)
+					return
+				}
+				if _, err := ns.Open(root, ruta); err != nil {
+					t.Errorf("Open(%s): %v", ruta, err)
+				}
+				if _, err := ns.List(root, dir); err != nil {
+					t.Errorf("List(%s): %v", dir, err)
+				}
+				ns.Snapshot()
+				switch i % 3 {
+				case 0:
+					if _, err := ns.Remove(root, ruta, false); err != nil {
+						t.Errorf("Remove(%s): %v", ruta, err)
+					}
+				case 1:
+					if err := ns.Rename(root, ruta, ruta+".mv"); err != nil {
+						t.Errorf("Rename(%s): %v", ruta, err)
+					}
+				}
+			}
+		}(c)
+	}
+	// Mientras tanto, otros leen y reemplazan el mismo archivo
+	for c := 0; c < 4; c++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < operaciones; i++ {
+				if _, err := ns.Put(root, "/compartido", archivoDePrueba(int64(i), 1)); err != nil {
+					t.Errorf("Put(/compartido): %v", err)
+				}
+				if _, err := ns.Stat(root, "/compartido"); err != nil {
+					t.Errorf("Stat(/compartido): %v", err)
+				}
+				ns.List(root, "/")
+			}
+		}()
+	}
+	wg.Wait()
+
+	// De cada cliente quedan los archivos que no se borraron: 2 de cada 3
+	archivos := 0
+	for d := 0; d < 4; d++ {
+		entradas, err := ns.List(root, fmt.Sprintf("/c%d", d))
+		if err != nil {
+			t.Fatal(err)
+		}
+		archivos += len(entradas)
+	}
+	esperados := clientes * (operaciones - (operaciones+2)/3)
+	if archivos != esperados {
+		t.Errorf("quedaron %d archivos, se esperaban %d", archivos, esperados)
+	}
+	if len(ns.Snapshot()) != esperados+1 {
+		t.Errorf("el snapshot tiene %d archivos, se esperaban %d", len(ns.Snapshot()), esperados+1)
+	}
+}
@@ -0,0 +1,325 @@
+package main
+
+import (
+	"crypto/tls"
+	"log"
+	"os"
+	"os/user"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/UriNoHi/Distributed-file-system-DFS-/protocolo"
+)
+
+// Los permisos son como los de POSIX: cada archivo y directorio tiene dueño, grupo y los
+// bits rwx del dueño, del grupo y del resto. Leer un archivo pide r; crear, borrar o mover
+// algo pide w en el directorio que lo contiene; pasar por un directorio pide x. El
+// superusuario y los miembros del supergrupo pueden todo.
+//
+// Quién hace cada pedido sale del certificado del cliente si el Namenode usa mTLS, y si no,
+// del usuario que el cliente declara al conectarse, sin verificar (como la autenticación
+// simple de HDFS). Como cualquiera puede declararse el superusuario, sin certificado no se
+// acepta al superusuario ni a los miembros del supergrupo, salvo con -superusuario-sin-certificado.
+// Los grupos de cada usuario están en el archivo grupos, con una línea por grupo:
+//
+//	grupo: usuario1 usuario2
+
+// Permisos son el dueño, el grupo y los bits de modo de un archivo o directorio
+type Permisos struct {
+	Owner string `json:"owner,omitempty"`
+	Group string `json:"group,omitempty"`
+	Mode  uint32 `json:"mode,omitempty"`
+}
+
+const (
+	modoDeArchivo    = 0644
+	modoDeDirectorio = 0755
+	modoMaximo       = 0777
+
+	accesoLectura   = 4
+	accesoEscritura = 2
+	accesoEjecucion = 1
+)
+
+// Quien no declara usuario ni presenta certificado tiene los permisos del resto
+const usuarioAnonimo = "anonimo"
+
+// superusuario es el usuario que puede todo; por defecto, el que corre el Namenode, como en HDFS
+var superusuario string
+var supergrupo = "supergroup"
+
+// superusuarioSinCertificado acepta al superusuario declarado sin certificado; solo para
+// clusters de prueba, porque cualquiera puede declararlo
+var superusuarioSinCertificado bool
+
+// gruposDeUsuarios tiene los grupos de cada usuario, cargados del archivo grupos al iniciar
+var gruposDeUsuarios = map[string][]string{}
+
+// Usuario es quien hace un pedido
+type Usuario struct {
+	Nombre string
+	Grupos []string
+}
+
+func superusuarioPorDefecto() string {
+	if actual, err := user.Current(); err == nil {
+		return actual.Username
+	}
+	return "root"
+}
+
+// usuarioDe devuelve el usuario del pedido con sus grupos
+func usuarioDe(pedido *protocolo.Pedido) Usuario {
+	return Usuario{Nombre: pedido.Usuario, Grupos: gruposDeUsuarios[pedido.Usuario]}
+}
+
+// identificar decide quién hace los pedidos de una conexión: con mTLS, el CN del
+// certificado verificado; si no, el usuario declarado, salvo que sea el superusuario o
+// del supergrupo, que sin certificado queda como anónimo
+func identificar(declarado string, estado *tls.ConnectionState) string {
+	if estado != nil && len(estado.VerifiedChains) > 0 {
+		declarado = estado.PeerCertificates[0].Subject.CommonName
+	} else if (Usuario{Nombre: declarado, Grupos: gruposDeUsuarios[declarado]}).esSuperusuario() && !superusuarioSinCertificado {
+		log.Printf("[WARNING] %s se declaró sin certificado y es superusuario, se lo trata como %s\n", declarado, usuarioAnonimo)
+		return usuarioAnonimo
+	}
+	if declarado == "" {
+		return usuarioAnonimo
+	}
+	return declarado
+}
+
+// avisarSinAutenticacion advierte al iniciar si los usuarios no se verifican
+func avisarSinAutenticacion(mTLS bool) {
+	if mTLS {
+		return
+	}
+	log.Println("[WARNING] ==========================================================")
+	log.Println("[WARNING] Sin mTLS los usuarios NO se verifican: cualquier cliente puede")
+	log.Println("[WARNING] declararse otro usuario y pasar los permisos de ese usuario.")
+	if superusuarioSinCertificado {
+		log.Printf("[WARNING] Con -superusuario-sin-certificado, quien se declare %s o del grupo %s puede TODO.\n", superusuario, supergrupo)
+	} else {
+		log.Println("[WARNING] El superusuario y el supergrupo solo se aceptan con certificado.")
+	}
+	log.Println("[WARNING] ==========================================================")
+}
+
+func (u Usuario) esSuperusuario() bool {
+	return u.Nombre == superusuario || u.enGrupo(supergrupo)
+}
+
+func (u Usuario) enGrupo(grupo string) bool {
+	return slices.Contains(u.Grupos, grupo)
+}
+
+// puede dice si el usuario tiene todos los accesos pedidos (accesoLectura, accesoEscritura,
+// accesoEjecucion) según los permisos
+func (u Usuario) puede(permisos Permisos, acceso uint32) bool {
+	if u.esSuperusuario() {
+		return true
+	}
+	bits := permisos.Mode
+	switch {
+	case u.Nombre == permisos.Owner:
+		bits >>= 6
+	case u.enGrupo(permisos.Group):
+		bits >>= 3
+	}
+	return bits&acceso == acceso
+}
+
+// cargarGrupos lee el archivo grupos. Es opcional: sin él nadie tiene grupos.
+func cargarGrupos() {
+	fileData, err := os.ReadFile("grupos")
+	if os.IsNotExist(err) {
+		log.Println("[INFO] No hay archivo grupos, los usuarios no tienen grupos")
+		return
+	}
+	if err != nil {
+		log.Println("[ERROR] Error leyendo el archivo grupos:", err)
+		return
+	}
+	for _, line := range strings.Split(string(fileData), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		grupo, usuarios, ok := strings.Cut(line, ":")
+		grupo = strings.TrimSpace(grupo)
+		if !ok || grupo == "" {
+			log.Println("[WARNING] Línea inválida en el archivo grupos:", line)
+			continue
+		}
+		for _, usuario := range strings.Fields(usuarios) {
+			gruposDeUsuarios[usuario] = append(gruposDeUsuarios[usuario], grupo)
+		}
+		log.Printf("[INFO] Grupo %s: %s\n", grupo, strings.TrimSpace(usuarios))
+	}
+}
+
+// recorrer baja por los directorios de la ruta que existen, hasta el que contendría su
+// último componente, exigiendo permiso de ejecución en cada uno. Devuelve el último
+// directorio al que llegó. Se llama con ns.mu tomado.
+func (ns *Namespace) recorrer(quien Usuario, ruta string) (*Directorio, error) {
+	actual := ns.raiz
+	if ruta == "/" {
+		return actual, nil
+	}
+	for _, parte := range partesDeRuta(path.Dir(ruta)) {
+		if !quien.puede(actual.Permisos, accesoEjecucion) {
+			return nil, errPermiso
+		}
+		siguiente, exists := actual.Dirs[parte]
+		if !exists {
+			return actual, nil
+		}
+		actual = siguiente
+	}
+	if !quien.puede(actual.Permisos, accesoEjecucion) {
+		return nil, errPermiso
+	}
+	return actual, nil
+}
+
+// comprobarEscrituraEn exige permiso de escritura en el directorio donde se crea, borra o
+// mueve la ruta; si faltan directorios, en el último que existe, donde se van a crear.
+// Se llama con ns.mu tomado.
+func (ns *Namespace) comprobarEscrituraEn(quien Usuario, ruta string) (*Directorio, error) {
+	padre, err := ns.recorrer(quien, ruta)
+	if err != nil {
+		return nil, err
+	}
+	if !quien.puede(padre.Permisos, accesoEscritura) {
+		return nil, errPermiso
+	}
+	return padre, nil
+}
+
+// comprobarSubarbol exige todos los permisos en cada directorio debajo de directorio
+// (incluido), para poder vaciarlos en un rm recursivo
+func comprobarSubarbol(quien Usuario, directorio *Directorio) error {
+	if !quien.puede(directorio.Permisos, accesoLectura|accesoEscritura|accesoEjecucion) {
+		return errPermiso
+	}
+	for _, hijo := range directorio.Dirs {
+		if err := comprobarSubarbol(quien, hijo); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// permisosDe devuelve los permisos del archivo o directorio para modificarlos.
+// Se llama con ns.mu tomado.
+func (ns *Namespace) permisosDe(ruta string) (*Permisos, error) {
+	if fileInfo, err := ns.buscarArchivo(ruta); err == nil {
+		return &fileInfo.Permisos, nil
+	}
+	directorio, err := ns.buscarDirectorio(ruta)
+	if err != nil {
+		return nil, err
+	}
+	return &directorio.Permisos, nil
+}
+
+// Chmod cambia los bits de modo. Solo lo puede hacer el dueño o el superusuario.
+func (ns *Namespace) Chmod(quien Usuario, ruta string, modo uint32) error {
+	ruta, err := normalizarRuta(ruta)
+	if err != nil {
+		return err
+	}
+	ns.mu.Lock()
+	defer ns.mu.Unlock()
+
+	if _, err := ns.recorrer(quien, ruta); err != nil {
+		return err
+	}
+	permisos, err := ns.permisosDe(ruta)
+	if err != nil {
+		return err
+	}
+	if quien.Nombre != permisos.Owner && !quien.esSuperusuario() {
+		return errPermiso
+	}
+	return ns.registrar(Edicion{Op: "chmod", File: ruta, Mode: modo})
+}
+
+// Chown cambia el dueño, el grupo o los dos; el que viene vacío no cambia. Como en HDFS,
+// solo el superusuario cambia el dueño, y el dueño puede pasar el archivo a otro de sus grupos.
+func (ns *Namespace) Chown(quien Usuario, ruta string, owner string, group string) error {
+	ruta, err := normalizarRuta(ruta)
+	if err != nil {
+		return err
+	}
+	ns.mu.Lock()
+	defer ns.mu.Unlock()
+
+	if _, err := ns.recorrer(quien, ruta); err != nil {
+		return err
+	}
+	permisos, err := ns.permisosDe(ruta)
+	if err != nil {
+		return err
+	}
+	if !quien.esSuperusuario() {
+		if owner != "" && owner != permisos.Owner {
+			return errPermiso
+		}
+		if group != "" && (quien.Nombre != permisos.Owner || !quien.enGrupo(group)) {
+			return errPermiso
+		}
+	}
+	return ns.registrar(Edicion{Op: "chown", File: ruta, Owner: owner, Group: group})
+}
+
+// completarPermisos les da dueño a los archivos y directorios guardados antes de que
+// hubiera permisos: quedan del superusuario, con los modos por defecto
+func completarPermisos(directorio *Directorio) {
+	if directorio.Owner == "" {
+		directorio.Permisos = Permisos{Owner: superusuario, Group: supergrupo, Mode: modoDeDirectorio}
+	}
+	for _, fileInfo := range directorio.Files {
+		if fileInfo.Owner == "" {
+			fileInfo.Permisos = Permisos{Owner: superusuario, Group: supergrupo, Mode: modoDeArchivo}
+		}
+	}
+	for _, hijo := range directorio.Dirs {
+		completarPermisos(hijo)
+	}
+}
+
+// chmod: cambia los permisos de un archivo o directorio
+func chmodNameNode(pedido *protocolo.Pedido) error {
+	var chmod protocolo.PedidoChmod
+	if err := pedido.Leer(&chmod); err != nil {
+		return err
+	}
+	if chmod.Modo > modoMaximo {
+		return protocolo.Errorf(protocolo.CodigoInvalido, "modo invalido: %o", chmod.Modo)
+	}
+	log.Printf("[INFO] Procesando CHMOD en Namenode para %s: %o (usuario %s)\n", chmod.Ruta, chmod.Modo, pedido.Usuario)
+	if err := namespace.Chmod(usuarioDe(pedido), chmod.Ruta, chmod.Modo); err != nil {
+		log.Printf("[ERROR] No se pudo cambiar el modo de %s: %v\n", chmod.Ruta, err)
+		return errorDelNamespace(err)
+	}
+	return nil
+}
+
+// chown: cambia el dueño o el grupo de un archivo o directorio
+func chownNameNode(pedido *protocolo.Pedido) error {
+	var chown protocolo.PedidoChown
+	if err := pedido.Leer(&chown); err != nil {
+		return err
+	}
+	if chown.Usuario == "" && chown.Grupo == "" {
+		return protocolo.Errorf(protocolo.CodigoInvalido, "falta el usuario o el grupo")
+	}
+	log.Printf("[INFO] Procesando CHOWN en Namenode para %s: %s:%s (usuario %s)\n", chown.Ruta, chown.Usuario, chown.Grupo, pedido.Usuario)
+	if err := namespace.Chown(usuarioDe(pedido), chown.Ruta, chown.Usuario, chown.Grupo); err != nil {
+		log.Printf("[ERROR] No se pudo cambiar el dueño de %s: %v\n", chown.Ruta, err)
+		return errorDelNamespace(err)
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"testing"
+)
+
+var (
+	ana  = Usuario{Nombre: "ana", Grupos: []string{"equipo"}}
+	beto = Usuario{Nombre: "beto", Grupos: []string{"equipo"}}
+	otro = Usuario{Nombre: "otro"}
+)
+
+// namespaceConHome deja /home/ana de ana, con modo 755, y /home/ana/f de ana, con modo 644
+func namespaceConHome(t *testing.T) *Namespace {
+	t.Helper()
+	ns := cargarNamespace(t, t.TempDir())
+	if err := ns.Mkdir(root, "/home/ana", true); err != nil {
+		t.Fatal(err)
+	}
+	if err := ns.Chown(root, "/home/ana", "ana", "equipo"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ns.Put(ana, "/home/ana/f", archivoDePrueba(1, 1)); err != nil {
+		t.Fatal(err)
+	}
+	return ns
+}
+
+func comprobarPermiso(t *testing.T, que string, err error, permitido bool) {
+	t.Helper()
+	if permitido && err != nil {
+		t.Errorf("%s: %v", que, err)
+	}
+	if !permitido && err != errPermiso {
+		t.Errorf("%s = %v, se esperaba %v", que, err, errPermiso)
+	}
+}
+
+func TestPermisosDeEscritura(t *testing.T) {
+	ns := namespaceConHome(t)
+	_, err := ns.Put(otro, "/home/ana/g", archivoDePrueba(1, 1))
+	comprobarPermiso(t, "otro crea en /home/ana", err, false)
+	_, err = ns.Put(otro, "/home/ana/f", archivoDePrueba(1, 1))
+	comprobarPermiso(t, "otro reemplaza /home/ana/f", err, false)
+	_, err = ns.Remove(otro, "/home/ana/f", false)
+	comprobarPermiso(t, "otro borra /home/ana/f", err, false)
+	comprobarPermiso(t, "otro mueve /home/ana/f", ns.Rename(otro, "/home/ana/f", "/f"), false)
+	comprobarPermiso(t, "otro crea en /", ns.Mkdir(otro, "/x", false), false)
+
+	// Con w para el grupo, beto puede crear
+	comprobarPermiso(t, "ana cambia el modo", ns.Chmod(ana, "/home/ana", 0775), true)
+	_, err = ns.Put(beto, "/home/ana/de-beto", archivoDePrueba(1, 1))
+	comprobarPermiso(t, "beto crea en /home/ana", err, true)
+	_, err = ns.Put(otro, "/home/ana/g", archivoDePrueba(1, 1))
+	comprobarPermiso(t, "otro crea en /home/ana con 775", err, false)
+}
+
+func TestPermisosDeLectura(t *testing.T) {
+	ns := namespaceConHome(t)
+	_, err := ns.Open(otro, "/home/ana/f")
+	comprobarPermiso(t, "otro lee /home/ana/f con 644", err, true)
+	comprobarPermiso(t, "ana cambia el modo de f", ns.Chmod(ana, "/home/ana/f", 0640), true)
+	_, err = ns.Open(otro, "/home/ana/f")
+	comprobarPermiso(t, "otro lee /home/ana/f con 640", err, false)
+	_, err = ns.Open(beto, "/home/ana/f")
+	comprobarPermiso(t, "beto lee /home/ana/f con 640", err, true)
+
+	// Sin x en el directorio no se puede llegar a lo que tiene adentro
+	comprobarPermiso(t, "ana cambia el modo de /home/ana", ns.Chmod(ana, "/home/ana", 0700), true)
+	_, err = ns.Open(beto, "/home/ana/f")
+	comprobarPermiso(t, "beto lee /home/ana/f sin x en /home/ana", err, false)
+	_, err = ns.List(beto, "/home/ana")
+	comprobarPermiso(t, "beto lista /home/ana con 700", err, false)
+	_, err = ns.Open(root, "/home/ana/f")
+	comprobarPermiso(t, "el superusuario lee /home/ana/f", err, true)
+}
+
+func TestChmodYChown(t *testing.T) {
+	ns := namespaceConHome(t)
+	comprobarPermiso(t, "otro cambia el modo de f", ns.Chmod(otro, "/home/ana/f", 0777), false)
+	comprobarPermiso(t, "ana le da f a beto", ns.Chown(ana, "/home/ana/f", "beto", ""), false)
+	comprobarPermiso(t, "ana pasa f a un grupo que no es suyo", ns.Chown(ana, "/home/ana/f", "", "otros"), false)
+	comprobarPermiso(t, "ana pasa f a su grupo", ns.Chown(ana, "/home/ana/f", "", "equipo"), true)
+	comprobarPermiso(t, "beto pasa f de ana a su grupo", ns.Chown(beto, "/home/ana/f", "", "equipo"), false)
+	comprobarPermiso(t, "el superusuario le da f a beto", ns.Chown(root, "/home/ana/f", "beto", ""), true)
+	entrada, err := ns.Stat(root, "/home/ana/f")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entrada.Owner != "beto" || entrada.Group != "equipo" {
+		t.Errorf("f quedó de %s:%s", entrada.Owner, entrada.Group)
+	}
+	comprobarPermiso(t, "ana pone una cuota", ns.SetQuota(ana, "/home/ana", 10), false)
+	comprobarPermiso(t, "el superusuario pone una cuota", ns.SetQuota(root, "/home/ana", 10), true)
+}
+
+// estadoConCertificado simula una conexión mTLS con un certificado verificado de cn
+func estadoConCertificado(cn string) *tls.ConnectionState {
+	certificado := &x509.Certificate{Subject: pkix.Name{CommonName: cn}}
+	return &tls.ConnectionState{PeerCertificates: []*x509.Certificate{certificado}, VerifiedChains: [][]*x509.Certificate{{certificado}}}
+}
+
+func TestIdentificar(t *testing.T) {
+	gruposDeUsuarios = map[string][]string{"admin": {supergrupo}}
+	defer func() { gruposDeUsuarios = map[string][]string{} }()
+
+	casos := []struct {
+		nombre         string
+		declarado      string
+		estado         *tls.ConnectionState
+		sinCertificado bool
+		esperado       string
+	}{
+		{"usuario declarado", "ana", nil, false, "ana"},
+		{"sin usuario", "", nil, false, usuarioAnonimo},
+		{"superusuario declarado", root.Nombre, nil, false, usuarioAnonimo},
+		{"supergrupo declarado", "admin", nil, false, usuarioAnonimo},
+		{"TLS sin certificado del cliente", root.Nombre, &tls.ConnectionState{}, false, usuarioAnonimo},
+		{"superusuario declarado con -superusuario-sin-certificado", root.Nombre, nil, true, root.Nombre},
+		{"certificado del superusuario", "ana", estadoConCertificado(root.Nombre), false, root.Nombre},
+		{"el certificado manda sobre lo declarado", root.Nombre, estadoConCertificado("ana"), false, "ana"},
+	}
+	for _, caso := range casos {
+		superusuarioSinCertificado = caso.sinCertificado
+		if obtenido := identificar(caso.declarado, caso.estado); obtenido != caso.esperado {
+			t.Errorf("%s: identificar(%q) = %q, se esperaba %q", caso.nombre, caso.declarado, obtenido, caso.esperado)
+		}
+	}
+	superusuarioSinCertificado = false
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"log"
+	"os"
+	"time"
+
+	"github.com/UriNoHi/Distributed-file-system-DFS-/protocolo"
+)
+
+// secretoDelCluster es el que comparten el Namenode y los DataNodes para firmar las
+// credenciales de los DataNodes (ver protocolo.FirmarCredencial)
+var secretoDelCluster []byte
+
+// cargarSecreto lee el secreto del cluster. Si el archivo no existe lo genera: hay que
+// copiarlo a los DataNodes para que el Namenode los acepte.
+func cargarSecreto(ruta string) error {
+	if _, err := os.Stat(ruta); os.IsNotExist(err) {
+		aleatorio := make([]byte, 32)
+		if _, err := rand.Read(aleatorio); err != nil {
+			return err
+		}
+		if err := os.WriteFile(ruta, []byte(hex.EncodeToString(aleatorio)+"\n"), 0600); err != nil {
+			return err
+		}
+		log.Printf("[WARNING] Se generó el secreto del cluster en %s: copiarlo a cada DataNode (-secreto)\n", ruta)
+	}
+	secreto, err := protocolo.LeerSecreto(ruta)
+	if err != nil {
+		return err
+	}
+	secretoDelCluster = secreto
+	return nil
+}
+
+// autenticarDataNode verifica la credencial de un mensaje del DataNode direccion. Sin ella
+// no se lo registra, no se le dan las claves de los tokens ni se aceptan sus reportes.
+func autenticarDataNode(pedido *protocolo.Pedido, direccion string, credencial string) error {
+	err := protocolo.VerificarCredencial(credencial, pedido.Op, direccion, secretoDelCluster, time.Now())
+	if err != nil {
+		log.Printf("[WARNING] %s de %s rechazado: %v\n", pedido.Op, direccion, err)
+	}
+	return err
+}
+
+// register: un DataNode se anuncia al iniciar. Si la dirección no estaba en la lista de nodos
+// se agrega, así no hace falta editar nodeList ni reiniciar el Namenode.
+func procesarRegistro(pedido *protocolo.Pedido) error {
+	var registro protocolo.PedidoRegistro
+	if err := pedido.Leer(&registro); err != nil {
+		return err
+	}
+	address, storageID, capacity := registro.Direccion, registro.StorageID, registro.Capacidad
+	if err := autenticarDataNode(pedido, address, registro.Credencial); err != nil {
+		return err
+	}
+	if address == "" || storageID == "" || capacity < 0 {
+		log.Println("[ERROR] Registro inválido:", string(pedido.Cuerpo))
+		return protocolo.Errorf(protocolo.CodigoInvalido, "registro invalido")
+	}
+
+	nodeStatusMutex.Lock()
+	// Si el mismo almacenamiento se registró antes con otra dirección, el nodo se movió
+	for oldAddress, status := range nodeStatus {
+		if oldAddress != address && status.StorageID == storageID {
+			log.Printf("[WARNING] El almacenamiento %s se movió de %s a %s\n", storageID, oldAddress, address)
+			delete(nodeStatus, oldAddress)
+			quitarNodo(oldAddress)
+		}
+	}
+
+	status, exists := nodeStatus[address]
+	if !exists {
+		status = &NodeStatus{Address: address}
+		nodeStatus[address] = status
+	}
+	if !contieneNodo(address) {
+		nodes = append(nodes, address)
+	}
+	if status.StorageID != "" && status.StorageID != storageID {
+		log.Printf("[WARNING] El DataNode %s cambió de almacenamiento: %s -> %s\n", address, status.StorageID, storageID)
+	}
+	status.StorageID = storageID
+	status.Capacity = capacity
+	status.LastHeartbeat = time.Now()
+	status.Estado = estadoVivo
+	nodeStatusMutex.Unlock()
+
+	log.Printf("[INFO] DataNode registrado: %s (almacenamiento %s, capacidad %d)\n", address, storageID, capacity)
+	return nil
+}
+
+// contieneNodo y quitarNodo se llaman con nodeStatusMutex tomado
+func contieneNodo(address string) bool {
+	for _, node := range nodes {
+		if node == address {
+			return true
+		}
+	}
+	return false
+}
+
+func quitarNodo(address string) {
+	for i, node := range nodes {
+		if node == address {
+			nodes = append(nodes[:i], nodes[i+1:]...)
+			return
+		}
+	}
+}
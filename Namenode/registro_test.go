@@ -0,0 +1,82 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/UriNoHi/Distributed-file-system-DFS-/protocolo"
+)
+
+// pedidoDeDataNode arma el pedido op de un DataNode con cuerpo
+func pedidoDeDataNode(t *testing.T, op string, cuerpo any) *protocolo.Pedido {
+	t.Helper()
+	data, err := json.Marshal(cuerpo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &protocolo.Pedido{Op: op, Cuerpo: data, Usuario: usuarioAnonimo}
+}
+
+func registrado(direccion string) bool {
+	nodeStatusMutex.Lock()
+	defer nodeStatusMutex.Unlock()
+	_, existe := nodeStatus[direccion]
+	return existe
+}
+
+// Un registro o un heartbeat sin la credencial del secreto del cluster no recibe las claves
+// de los tokens, y el DataNode no queda registrado
+func TestRegistroSinCredencial(t *testing.T) {
+	secretoDelCluster = []byte("secreto-del-cluster-de-prueba")
+	rotarClaves()
+	const direccion = "falso:9000"
+	defer func() {
+		nodeStatusMutex.Lock()
+		delete(nodeStatus, direccion)
+		quitarNodo(direccion)
+		nodeStatusMutex.Unlock()
+	}()
+
+	credencialAjena := protocolo.FirmarCredencial(protocolo.OpRegister, direccion, []byte("otro-secreto-que-no-es-el-del-cluster"), time.Now())
+	credencialDeHeartbeat := protocolo.FirmarCredencial(protocolo.OpHeartbeat, direccion, secretoDelCluster, time.Now())
+	credencialDeOtroNodo := protocolo.FirmarCredencial(protocolo.OpRegister, "otro:9000", secretoDelCluster, time.Now())
+	for nombre, credencial := range map[string]string{
+		"sin credencial":           "",
+		"firmada con otro secreto": credencialAjena,
+		"de un heartbeat":          credencialDeHeartbeat,
+		"de otro DataNode":         credencialDeOtroNodo,
+		"mal formada":              "no-es-una-credencial",
+	} {
+		registro := protocolo.PedidoRegistro{Direccion: direccion, StorageID: "DS-falso", Capacidad: 1, Credencial: credencial}
+		respuesta, err := atenderPedido(pedidoDeDataNode(t, protocolo.OpRegister, registro))
+		if err == nil || protocolo.CodigoDe(err) != protocolo.CodigoPermiso || respuesta != nil {
+			t.Errorf("registro %s: respuesta %v, error %v", nombre, respuesta, err)
+		}
+		if registrado(direccion) {
+			t.Fatalf("registro %s: el DataNode quedó registrado", nombre)
+		}
+	}
+
+	registro := protocolo.PedidoRegistro{Direccion: direccion, StorageID: "DS-bueno", Capacidad: 1,
+		Credencial: protocolo.FirmarCredencial(protocolo.OpRegister, direccion, secretoDelCluster, time.Now())}
+	respuesta, err := atenderPedido(pedidoDeDataNode(t, protocolo.OpRegister, registro))
+	if err != nil {
+		t.Fatalf("registro con credencial: %v", err)
+	}
+	if claves, ok := respuesta.(protocolo.RespuestaClaves); !ok || len(claves.Claves) == 0 {
+		t.Fatalf("el registro con credencial no devolvió las claves: %v", respuesta)
+	}
+
+	// Ya registrado, un heartbeat con su dirección pero sin credencial tampoco recibe claves
+	heartbeat := protocolo.PedidoHeartbeat{Direccion: direccion}
+	respuesta, err = atenderPedido(pedidoDeDataNode(t, protocolo.OpHeartbeat, heartbeat))
+	if err == nil || protocolo.CodigoDe(err) != protocolo.CodigoPermiso || respuesta != nil {
+		t.Errorf("heartbeat sin credencial: respuesta %v, error %v", respuesta, err)
+	}
+	// Ni puede reportar bloques corruptos en su nombre
+	reporte := protocolo.PedidoReporteIncremental{Direccion: direccion, Bloque: "blk_1_1"}
+	if _, err := atenderPedido(pedidoDeDataNode(t, protocolo.OpBlockCorrupt, reporte)); err == nil || protocolo.CodigoDe(err) != protocolo.CodigoPermiso {
+		t.Errorf("reporte sin credencial: error %v", err)
+	}
+}
@@ -0,0 +1,290 @@
+package main
+
+import (
+	"context"
+	"log"
+	"net"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/UriNoHi/Distributed-file-system-DFS-/protocolo"
+	"github.com/UriNoHi/Distributed-file-system-DFS-/protocolo/rpc"
+)
+
+const (
+	intervaloReplicacion = 10 * time.Second
+	timeoutReplicacion   = 60 * time.Second // si no llega el blockreceived se vuelve a intentar
+	graciaDeEscritura    = 30 * time.Second // no se revisan archivos que el cliente puede estar escribiendo
+	timeoutDataNode      = 10 * time.Second // para las órdenes de replicar y borrar
+)
+
+type replicacionPendiente struct {
+	fileName string
+	block    int
+	nombre   string
+	destino  string
+	inicio   time.Time
+}
+
+// replicacionesPendientes se indexa por "<destino> <nombre del bloque>"
+var replicacionesPendientes = map[string]replicacionPendiente{}
+var replicacionesMutex sync.Mutex
+
+// monitorDeReplicacion busca periódicamente bloques con menos réplicas sanas que las
+// pedidas para su archivo (por DataNodes muertos o réplicas que faltan en los reportes)
+// y ordena a un DataNode que tenga una copia sana que la copie a otro nodo vivo.
+// También borra las réplicas que sobran cuando se bajó la replicación con setrep.
+func monitorDeReplicacion() {
+	for {
+		time.Sleep(intervaloReplicacion)
+		revisarReplicacion()
+	}
+}
+
+func revisarReplicacion() {
+	estados := snapshotDeNodos()
+
+	replicacionesMutex.Lock()
+	for clave, pendiente := range replicacionesPendientes {
+		if time.Since(pendiente.inicio) > timeoutReplicacion {
+			log.Printf("[WARNING] Venció la replicación de %s hacia %s, se reintenta\n", clave, pendiente.destino)
+			delete(replicacionesPendientes, clave)
+		}
+	}
+	replicacionesMutex.Unlock()
+
+	for fileName, fileInfo := range namespace.Snapshot() {
+		if time.Since(fileInfo.ModTime) < graciaDeEscritura {
+			continue
+		}
+		for _, dataInfo := range fileInfo.Blocks {
+			revisarBloque(fileName, fileInfo.Replication, dataInfo, estados)
+		}
+	}
+}
+
+// revisarBloque compara las réplicas sanas de un bloque contra la replicación pedida
+func revisarBloque(fileName string, replicacion int, dataInfo DataInfo, estados map[string]NodeStatus) {
+	nombre := dataInfo.nombre()
+
+	sanas := []string{}
+	perdidas := []string{}
+	for _, dataNode := range dataInfo.DataNodes {
+		// Se pierde la réplica si el nodo murió o si su reporte de bloques no la incluye
+		if estados[dataNode].Estado != estadoMuerto && tieneBloque(dataNode, nombre) {
+			sanas = append(sanas, dataNode)
+		} else {
+			perdidas = append(perdidas, dataNode)
+		}
+	}
+
+	if len(sanas) == 0 {
+		log.Printf("[ERROR] El bloque %s no tiene ninguna réplica sana\n", nombre)
+		return
+	}
+
+	// Con suficientes réplicas sanas, las perdidas y las que sobran salen de la metadata
+	if len(sanas) >= replicacion {
+		sobrantes := sanas[replicacion:]
+		for _, dataNode := range sobrantes {
+			ordenarBorrado(dataNode, nombre)
+		}
+		if len(perdidas) == 0 && len(sobrantes) == 0 {
+			return
+		}
+		for _, dataNode := range perdidas {
+			log.Printf("[INFO] Se descarta la réplica perdida de %s en %s\n", nombre, dataNode)
+		}
+		if err := namespace.DropReplicas(fileName, dataInfo.Block, append(perdidas, sobrantes...)); err != nil {
+			log.Printf("[ERROR] No se pudo actualizar las réplicas de %s: %v\n", nombre, err)
+		}
+		return
+	}
+
+	ocupados := map[string]bool{}
+	for _, dataNode := range dataInfo.DataNodes {
+		ocupados[dataNode] = true
+	}
+	faltantes := replicacion - len(sanas) - replicacionesEnCurso(nombre, ocupados)
+	for ; faltantes > 0; faltantes-- {
+		destino := elegirDestino(estados, ocupados)
+		if destino == "" {
+			log.Printf("[WARNING] No hay DataNode libre para re-replicar %s\n", nombre)
+			break
+		}
+		if err := ordenarReplicacion(sanas[0], fileName, dataInfo, destino); err != nil {
+			log.Printf("[ERROR] No se pudo ordenar la replicación de %s: %v\n", nombre, err)
+			break
+		}
+		ocupados[destino] = true
+	}
+}
+
+// tieneBloque indica si el DataNode reportó el bloque. Si todavía no mandó reporte se asume que sí.
+func tieneBloque(address string, nombre string) bool {
+	bloquesReportadosMutex.Lock()
+	defer bloquesReportadosMutex.Unlock()
+	reportados, exists := bloquesReportados[address]
+	return !exists || reportados[nombre]
+}
+
+// bloqueGuardado indica si algún DataNode reportó el bloque
+func bloqueGuardado(nombre string) bool {
+	bloquesReportadosMutex.Lock()
+	defer bloquesReportadosMutex.Unlock()
+	for _, reportados := range bloquesReportados {
+		if reportados[nombre] {
+			return true
+		}
+	}
+	return false
+}
+
+func snapshotDeNodos() map[string]NodeStatus {
+	nodeStatusMutex.Lock()
+	defer nodeStatusMutex.Unlock()
+	copia := map[string]NodeStatus{}
+	for address, status := range nodeStatus {
+		s := *status
+		s.Estado = calcularEstado(status.LastHeartbeat)
+		copia[address] = s
+	}
+	return copia
+}
+
+// elegirDestino devuelve el nodo vivo con más espacio libre que no tenga ya una réplica del bloque
+func elegirDestino(estados map[string]NodeStatus, ocupados map[string]bool) string {
+	candidatos := []NodeStatus{}
+	for address, status := range estados {
+		if status.Estado == estadoVivo && !ocupados[address] {
+			candidatos = append(candidatos, status)
+		}
+	}
+	if len(candidatos) == 0 {
+		return ""
+	}
+	sort.Slice(candidatos, func(i, j int) bool {
+		return candidatos[i].Capacity-candidatos[i].Used > candidatos[j].Capacity-candidatos[j].Used
+	})
+	return candidatos[0].Address
+}
+
+// replicacionesEnCurso cuenta las copias de un bloque que ya se ordenaron y marca sus destinos como ocupados
+func replicacionesEnCurso(nombre string, ocupados map[string]bool) int {
+	replicacionesMutex.Lock()
+	defer replicacionesMutex.Unlock()
+	cantidad := 0
+	for _, pendiente := range replicacionesPendientes {
+		if pendiente.nombre == nombre {
+			ocupados[pendiente.destino] = true
+			cantidad++
+		}
+	}
+	return cantidad
+}
+
+// ordenarReplicacion le pide al DataNode origen que copie su bloque al destino
+func ordenarReplicacion(origen string, fileName string, dataInfo DataInfo, destino string) error {
+	nombre := dataInfo.nombre()
+	log.Printf("[INFO] Re-replicando %s: %s -> %s\n", nombre, origen, destino)
+
+	// Se anota antes de enviar para no perder un blockreceived que llegue muy rápido
+	clave := destino + " " + nombre
+	replicacionesMutex.Lock()
+	replicacionesPendientes[clave] = replicacionPendiente{fileName: fileName, block: dataInfo.Block, nombre: nombre, destino: destino, inicio: time.Now()}
+	replicacionesMutex.Unlock()
+
+	replicate := protocolo.PedidoReplicate{
+		Bloque:        nombre,
+		Destino:       destino,
+		BloqueDestino: nombre,
+		Token:         tokenDeBloque(nombre, protocolo.AccesoReplicar, usuarioNamenode),
+		TokenDestino:  tokenDeBloque(nombre, protocolo.AccesoEscribir, usuarioNamenode),
+	}
+	err := enviarADataNode(origen, protocolo.OpReplicate, replicate)
+	if err != nil {
+		replicacionesMutex.Lock()
+		delete(replicacionesPendientes, clave)
+		replicacionesMutex.Unlock()
+	}
+	return err
+}
+
+// ordenarBorrado le pide a un DataNode que borre una réplica que sobra
+func ordenarBorrado(dataNode string, nombre string) {
+	log.Printf("[INFO] Borrando réplica sobrante de %s en %s\n", nombre, dataNode)
+	rm := protocolo.PedidoBloque{Bloque: nombre, Token: tokenDeBloque(nombre, protocolo.AccesoBorrar, usuarioNamenode)}
+	if err := enviarADataNode(dataNode, protocolo.OpRmBlock, rm); err != nil {
+		log.Printf("[ERROR] No se pudo borrar %s en %s: %v\n", nombre, dataNode, err)
+	}
+}
+
+// enviarADataNode manda un pedido a un DataNode y espera que lo acepte
+func enviarADataNode(address string, op string, cuerpo any) error {
+	dataNode, err := conectarDataNode(address)
+	if err != nil {
+		return err
+	}
+	defer dataNode.Close()
+	dataNode.SetDeadline(time.Now().Add(timeoutDataNode))
+	return dataNode.Pedir(op, cuerpo, nil)
+}
+
+// conectarDataNode abre una conexión con un DataNode con el protocolo del cluster
+func conectarDataNode(address string) (protocolo.Cliente, error) {
+	if !legacy {
+		return rpc.ConectarDataNode(address, configTLS)
+	}
+	conn, err := protocolo.Dial(context.Background(), &net.Dialer{Timeout: timeoutDataNode}, address, configTLS)
+	if err != nil {
+		return nil, err
+	}
+	conn.SetDeadline(time.Now().Add(timeoutDataNode))
+	conexion, err := protocolo.Conectar(conn)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return conexion, nil
+}
+
+// confirmarReplicacion se llama con cada blockreceived. Si corresponde a una replicación
+// pendiente, el nuevo DataNode se agrega como réplica del bloque en la metadata.
+func confirmarReplicacion(address string, nombre string) {
+	replicacionesMutex.Lock()
+	pendiente, exists := replicacionesPendientes[address+" "+nombre]
+	delete(replicacionesPendientes, address+" "+nombre)
+	replicacionesMutex.Unlock()
+	if !exists {
+		return
+	}
+
+	if err := namespace.AddReplica(pendiente.fileName, pendiente.block, address); err != nil {
+		log.Printf("[ERROR] No se pudo registrar la nueva réplica de %s: %v\n", nombre, err)
+		return
+	}
+	log.Printf("[INFO] Nueva réplica de %s en %s\n", nombre, address)
+}
+
+// setrep: solo cambia la replicación pedida; el monitor de replicación agrega o borra las copias
+func setReplication(pedido *protocolo.Pedido) error {
+	var setrep protocolo.PedidoSetrep
+	if err := pedido.Leer(&setrep); err != nil {
+		return err
+	}
+	fileName, replicacion := setrep.Ruta, setrep.Replicacion
+	if replicacion < 1 {
+		log.Println("[ERROR] Factor de replicación inválido:", replicacion)
+		return protocolo.Errorf(protocolo.CodigoInvalido, "replicacion invalida")
+	}
+	anterior, err := namespace.SetReplication(usuarioDe(pedido), fileName, replicacion)
+	if err == errArchivoNoExiste {
+		return protocolo.Errorf(protocolo.CodigoNoExiste, "no existe el archivo %s", fileName)
+	}
+	if err != nil {
+		return errorDelNamespace(err)
+	}
+	log.Printf("[INFO] Replicación de %s: %d -> %d\n", fileName, anterior, replicacion)
+	return nil
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"crypto/rand"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/UriNoHi/Distributed-file-system-DFS-/protocolo"
+)
+
+// Los DataNodes solo aceptan operaciones sobre un bloque con un token de acceso firmado por
+// el Namenode (ver protocolo.TokenDeBloque). El Namenode lo agrega a cada bloque que le da
+// al cliente: para escribir en put y addblock, para leer en get y locations, para borrar en
+// rm. Un put largo renueva los de escribir que le vencieron con addblock (ver escrituras.go),
+// mientras ningún DataNode haya reportado el bloque. Los pedidos que el mismo Namenode les
+// manda a los DataNodes también llevan el suyo; el de replicar solo lo firma para esos
+// pedidos, nunca se lo da a un cliente.
+//
+// Las claves se generan al iniciar y cada rotacionDeClaves se agrega una nueva, que es la
+// que firma desde entonces. Las anteriores se siguen mandando mientras puedan quedar tokens
+// firmados con ellas. Los DataNodes las reciben en la respuesta del registro y de cada
+// heartbeat, que solo se contestan si traen la credencial firmada con el secreto del cluster
+// (ver registro.go); sin TLS viajan sin cifrar, igual que los bloques.
+
+const (
+	vidaDeToken      = 10 * time.Minute
+	rotacionDeClaves = time.Hour
+	largoDeClave     = 32
+)
+
+// Los tokens de los pedidos que manda el Namenode
+const usuarioNamenode = "namenode"
+
+// clavesDeBloques son las claves vigentes; la última es la que firma
+var clavesDeBloques []protocolo.ClaveDeBloques
+var clavesMutex sync.Mutex
+
+// rotarClaves agrega una clave nueva y descarta las que ya vencieron
+func rotarClaves() {
+	clave := protocolo.ClaveDeBloques{
+		// El ID no se repite aunque el Namenode se reinicie
+		ID:     time.Now().UnixNano(),
+		Clave:  make([]byte, largoDeClave),
+		Expira: time.Now().Add(rotacionDeClaves + vidaDeToken).Unix(),
+	}
+	if _, err := rand.Read(clave.Clave); err != nil {
+		log.Fatalf("[ERROR] No se pudo generar la clave de los tokens: %v", err)
+	}
+
+	clavesMutex.Lock()
+	defer clavesMutex.Unlock()
+	vigentes := []protocolo.ClaveDeBloques{}
+	for _, anterior := range clavesDeBloques {
+		if time.Now().Unix() < anterior.Expira {
+			vigentes = append(vigentes, anterior)
+		}
+	}
+	clavesDeBloques = append(vigentes, clave)
+	log.Printf("[INFO] Nueva clave para los tokens de bloques: %d (%d vigentes)\n", clave.ID, len(clavesDeBloques))
+}
+
+// rotarClavesPeriodicamente cambia la clave que firma los tokens cada rotacionDeClaves
+func rotarClavesPeriodicamente() {
+	for {
+		time.Sleep(rotacionDeClaves)
+		rotarClaves()
+	}
+}
+
+// clavesVigentes es la respuesta al registro y a los heartbeats de los DataNodes
+func clavesVigentes() protocolo.RespuestaClaves {
+	clavesMutex.Lock()
+	defer clavesMutex.Unlock()
+	return protocolo.RespuestaClaves{Claves: append([]protocolo.ClaveDeBloques{}, clavesDeBloques...)}
+}
+
+// tokenDeBloque firma un token para que usuario haga acceso sobre el bloque
+func tokenDeBloque(nombre string, acceso string, usuario string) string {
+	clavesMutex.Lock()
+	clave := clavesDeBloques[len(clavesDeBloques)-1]
+	clavesMutex.Unlock()
+	token := protocolo.TokenDeBloque{Bloque: nombre, Acceso: acceso, Expira: time.Now().Add(vidaDeToken).Unix(), Usuario: usuario}
+	return protocolo.FirmarToken(token, clave)
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/UriNoHi/Distributed-file-system-DFS-/protocolo"
+)
+
+// pedirLocations hace locations de todo ruta como quien
+func pedirLocations(t *testing.T, quien Usuario, ruta string) ([]protocolo.Bloque, error) {
+	t.Helper()
+	respuesta, err := pedirComo(t, quien, protocolo.OpLocations, protocolo.PedidoLocations{Ruta: ruta, Largo: -1})
+	if err != nil {
+		return nil, err
+	}
+	return respuesta.(protocolo.RespuestaBloques).Bloques, nil
+}
+
+// renovarBloqueDe hace addblock con renovar del bloque i de la escritura como quien
+func renovarBloqueDe(t *testing.T, quien Usuario, escritura int64, i int) ([]protocolo.Bloque, error) {
+	t.Helper()
+	respuesta, err := pedirComo(t, quien, protocolo.OpAddBlock, protocolo.PedidoEscritura{Escritura: escritura, Renovar: true, Bloque: i})
+	if err != nil {
+		return nil, err
+	}
+	return respuesta.(protocolo.RespuestaBloques).Bloques, nil
+}
+
+// Un put que tardó más que sus tokens pide otros para escribir los bloques que todavía
+// no guardó; uno ya guardado, o de una escritura ajena, no se puede volver a escribir
+func TestRenovarTokenDeEscritura(t *testing.T) {
+	usarNamespace(t, namespaceConHome(t))
+	dataNodeVivo(t)
+	rotarClaves()
+	claves := map[int64]protocolo.ClaveDeBloques{}
+	for _, clave := range clavesVigentes().Claves {
+		claves[clave.ID] = clave
+	}
+
+	escritura, asignados := empezarEscritura(t, ana, "/home/ana/g", 2)
+	bloques, err := renovarBloqueDe(t, ana, escritura, 1)
+	if err != nil || len(bloques) != 1 || bloques[0].Nombre != asignados[1].Nombre {
+		t.Fatalf("la dueña renueva el token del bloque 1 de g: %v, %v", bloques, err)
+	}
+	if _, err := protocolo.VerificarToken(bloques[0].Token, bloques[0].Nombre, protocolo.AccesoEscribir, claves, time.Now()); err != nil {
+		t.Errorf("el token renovado no sirve para escribir: %v", err)
+	}
+	if _, err := renovarBloqueDe(t, otro, escritura, 1); err == nil || protocolo.CodigoDe(err) != protocolo.CodigoPermiso {
+		t.Errorf("otro renueva el token de g: error %v", err)
+	}
+	if _, err := renovarBloqueDe(t, ana, escritura, 2); err == nil || protocolo.CodigoDe(err) != protocolo.CodigoInvalido {
+		t.Errorf("token de un bloque que no se asignó: error %v", err)
+	}
+
+	const direccion = "dn1"
+	bloquesReportadosMutex.Lock()
+	bloquesReportados[direccion] = map[string]bool{asignados[0].Nombre: true}
+	bloquesReportadosMutex.Unlock()
+	defer func() {
+		bloquesReportadosMutex.Lock()
+		delete(bloquesReportados, direccion)
+		bloquesReportadosMutex.Unlock()
+	}()
+	if _, err := renovarBloqueDe(t, ana, escritura, 0); err == nil || protocolo.CodigoDe(err) != protocolo.CodigoPermiso {
+		t.Errorf("token de escritura para un bloque ya guardado: error %v", err)
+	}
+
+	bloques, err = pedirLocations(t, otro, "/home/ana/f")
+	if err != nil {
+		t.Fatalf("otro lee f con 644: %v", err)
+	}
+	if _, err := protocolo.VerificarToken(bloques[0].Token, bloques[0].Nombre, protocolo.AccesoEscribir, claves, time.Now()); err == nil {
+		t.Errorf("locations dio un token para escribir")
+	}
+}
@@ -0,0 +1,387 @@
+package dfs
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/UriNoHi/Distributed-file-system-DFS-/protocolo"
+)
+
+// Reader lee un archivo del DFS como un os.File abierto para lectura: implementa
+// io.Reader, io.ReaderAt, io.Seeker e io.Closer. Todo lo leído se verifica con sus
+// checksums antes de entregarlo. Read baja bloques enteros y guarda en memoria el último
+// leído; ReadAt pide a los DataNodes solo el rango que necesita.
+// ReadAt se puede llamar desde varias goroutines; Read y Seek comparten la posición actual.
+type Reader struct {
+	c         *Client
+	ctx       context.Context
+	ruta      string
+	blockSize int64
+	size      int64
+
+	mu      sync.Mutex
+	bloques []BlockLocation // se renuevan cuando vencen sus tokens
+	offset  int64           // posición de Read y Seek
+	cache   *bloqueEnMemoria
+	closed  bool
+}
+
+// bloqueEnMemoria es un bloque ya verificado; sus datos no se modifican después de bajarlo
+type bloqueEnMemoria struct {
+	indice int
+	datos  []byte
+}
+
+// Open abre un archivo del DFS para leerlo. El contexto vale para todas las lecturas.
+func (c *Client) Open(ctx context.Context, ruta string) (*Reader, error) {
+	ruta = rutaAbsoluta(ruta)
+	fi, err := c.Stat(ctx, ruta)
+	if err != nil {
+		return nil, err
+	}
+	bloques, err := c.Blocks(ctx, ruta)
+	if err != nil {
+		return nil, err
+	}
+	// Si el archivo se reemplazó entre el stat y el get, la lista no coincide con el tamaño
+	if int64(len(bloques)) != (fi.Size+fi.BlockSize-1)/fi.BlockSize {
+		return nil, &Error{Op: "open", Path: ruta, Err: fmt.Errorf("el archivo cambió mientras se abría")}
+	}
+	return &Reader{c: c, ctx: ctx, ruta: ruta, bloques: bloques, blockSize: fi.BlockSize, size: fi.Size}, nil
+}
+
+// Size devuelve el tamaño del archivo cuando se abrió
+func (r *Reader) Size() int64 { return r.size }
+
+func (r *Reader) Read(p []byte) (int, error) {
+	r.mu.Lock()
+	offset := r.offset
+	closed := r.closed
+	r.mu.Unlock()
+	if closed {
+		return 0, &Error{Op: "read", Path: r.ruta, Err: ErrClosed}
+	}
+
+	n := 0
+	for n < len(p) && offset+int64(n) < r.size {
+		actual := offset + int64(n)
+		i := int(actual / r.blockSize)
+		datos, err := r.bloque(i)
+		if err != nil {
+			return n, &Error{Op: "read", Path: r.ruta, Err: err}
+		}
+		n += copy(p[n:], datos[actual-int64(i)*r.blockSize:])
+	}
+	r.mu.Lock()
+	r.offset = offset + int64(n)
+	r.mu.Unlock()
+	// A diferencia de ReadAt, Read no avisa EOF si leyó algo
+	if n == 0 && len(p) > 0 {
+		return 0, io.EOF
+	}
+	return n, nil
+}
+
+// ReadAt lee len(p) bytes desde offset; de cada bloque pide solo la parte que cae en p
+func (r *Reader) ReadAt(p []byte, offset int64) (int, error) {
+	if offset < 0 {
+		return 0, &Error{Op: "read", Path: r.ruta, Err: ErrInvalid}
+	}
+	r.mu.Lock()
+	closed := r.closed
+	r.mu.Unlock()
+	if closed {
+		return 0, &Error{Op: "read", Path: r.ruta, Err: ErrClosed}
+	}
+	n, err := r.c.leerRango(r.ctx, r.ubicaciones(), r.blockSize, p, offset)
+	if err != nil && protocolo.CodigoDe(err) == protocolo.CodigoToken && r.renovarBloques() == nil {
+		n, err = r.c.leerRango(r.ctx, r.ubicaciones(), r.blockSize, p, offset)
+	}
+	if err != nil {
+		return n, &Error{Op: "read", Path: r.ruta, Err: err}
+	}
+	if n < len(p) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+// leerRango llena p con los bytes desde offset que caen en bloques. Los bloques tienen
+// que tener Offset y Length; devuelve menos de len(p) si el archivo termina antes.
+func (c *Client) leerRango(ctx context.Context, bloques []BlockLocation, blockSize int64, p []byte, offset int64) (int, error) {
+	n := 0
+	for _, bloque := range bloques {
+		actual := offset + int64(n)
+		if n == len(p) {
+			break
+		}
+		if actual < bloque.Offset || actual >= bloque.Offset+bloque.Length {
+			continue
+		}
+		largo := min(int64(len(p)-n), bloque.Offset+bloque.Length-actual)
+		destino := bufferDeBloque(p[n : int64(n)+largo])
+		if err := c.readRange(ctx, bloque, destino, actual-bloque.Offset, largo); err != nil {
+			return n, &BlockError{Index: int(bloque.Offset / blockSize), Name: bloque.Name, Err: err}
+		}
+		n += int(largo)
+	}
+	return n, nil
+}
+
+// ReadAt lee len(p) bytes de un archivo desde offset sin abrirlo: le pide al Namenode
+// solo los bloques del rango. Como io.ReaderAt, si el archivo termina antes devuelve io.EOF.
+func (c *Client) ReadAt(ctx context.Context, ruta string, p []byte, offset int64) (int, error) {
+	ruta = rutaAbsoluta(ruta)
+	fi, err := c.Stat(ctx, ruta)
+	if err != nil {
+		return 0, err
+	}
+	bloques, err := c.Locations(ctx, ruta, offset, int64(len(p)))
+	if err != nil {
+		return 0, err
+	}
+	n, err := c.leerRango(ctx, bloques, fi.BlockSize, p, offset)
+	if err != nil && protocolo.CodigoDe(err) == protocolo.CodigoToken {
+		// Un rango largo puede tardar más que los tokens
+		if bloques, errRenovar := c.Locations(ctx, ruta, offset, int64(len(p))); errRenovar == nil {
+			n, err = c.leerRango(ctx, bloques, fi.BlockSize, p, offset)
+		}
+	}
+	if err != nil {
+		return n, &Error{Op: "read", Path: ruta, Err: err}
+	}
+	if n < len(p) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+// bloque devuelve los datos del bloque i, bajándolo de la primera réplica sana si no es el último leído
+func (r *Reader) bloque(i int) ([]byte, error) {
+	r.mu.Lock()
+	if r.closed {
+		r.mu.Unlock()
+		return nil, ErrClosed
+	}
+	if r.cache != nil && r.cache.indice == i {
+		datos := r.cache.datos
+		r.mu.Unlock()
+		return datos, nil
+	}
+	r.mu.Unlock()
+
+	// Todos los bloques salvo el último están llenos
+	bloques := r.ubicaciones()
+	esperado := r.blockSize
+	if i == len(bloques)-1 {
+		esperado = r.size - int64(i)*r.blockSize
+	}
+	buffer := make([]byte, esperado)
+	largo, err := r.c.readBlock(r.ctx, bloques[i], bufferDeBloque(buffer), 0, esperado)
+	if err != nil && protocolo.CodigoDe(err) == protocolo.CodigoToken && r.renovarBloques() == nil {
+		bloques = r.ubicaciones()
+		largo, err = r.c.readBlock(r.ctx, bloques[i], bufferDeBloque(buffer), 0, esperado)
+	}
+	if err == nil && largo != esperado {
+		err = fmt.Errorf("el bloque tiene %d bytes y se esperaban %d", largo, esperado)
+	}
+	if err != nil {
+		return nil, &BlockError{Index: i, Name: bloques[i].Name, Err: err}
+	}
+
+	r.mu.Lock()
+	r.cache = &bloqueEnMemoria{indice: i, datos: buffer}
+	r.mu.Unlock()
+	return buffer, nil
+}
+
+func (r *Reader) ubicaciones() []BlockLocation {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.bloques
+}
+
+// renovarBloques le vuelve a pedir los bloques al Namenode cuando los DataNodes rechazan los
+// tokens, que vencen aunque el archivo siga abierto. Si el archivo cambió, no se renuevan.
+func (r *Reader) renovarBloques() error {
+	bloques, err := r.c.Blocks(r.ctx, r.ruta)
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(bloques) != len(r.bloques) {
+		return fmt.Errorf("el archivo cambió mientras estaba abierto")
+	}
+	for i := range bloques {
+		if bloques[i].Name != r.bloques[i].Name {
+			return fmt.Errorf("el archivo cambió mientras estaba abierto")
+		}
+	}
+	r.bloques = bloques
+	return nil
+}
+
+// Seek cambia la posición de la próxima lectura con Read, como os.File.Seek
+func (r *Reader) Seek(offset int64, whence int) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return 0, &Error{Op: "seek", Path: r.ruta, Err: ErrClosed}
+	}
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += r.offset
+	case io.SeekEnd:
+		offset += r.size
+	default:
+		return 0, &Error{Op: "seek", Path: r.ruta, Err: ErrInvalid}
+	}
+	if offset < 0 {
+		return 0, &Error{Op: "seek", Path: r.ruta, Err: ErrInvalid}
+	}
+	r.offset = offset
+	return offset, nil
+}
+
+func (r *Reader) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return &Error{Op: "close", Path: r.ruta, Err: ErrClosed}
+	}
+	r.closed = true
+	r.cache = nil
+	return nil
+}
+
+// bufferDeBloque recibe un bloque en memoria; readBlock nunca escribe más de su largo
+type bufferDeBloque []byte
+
+func (b bufferDeBloque) WriteAt(p []byte, offset int64) (int, error) {
+	return copy(b[offset:], p), nil
+}
+
+// Writer escribe un archivo nuevo en el DFS. Lo escrito se junta en memoria hasta llenar
+// un bloque; recién ahí se le pide el bloque al Namenode y se manda por el pipeline de
+// DataNodes. El archivo aparece en el DFS al cerrar; si algo falla, se abandona.
+// No se puede usar desde varias goroutines a la vez.
+type Writer struct {
+	c         *Client
+	ctx       context.Context
+	ruta      string
+	escritura int64 // número de escritura que asignó el Namenode
+	blockSize int64
+	buffer    []byte
+	size      int64
+	cantidad  int // bloques ya enviados
+	closed    bool
+	err       error // el primer error; después de fallar no se puede seguir escribiendo
+}
+
+// Create abre un archivo del DFS para escribirlo. Si ya existe, se reemplaza al cerrar.
+func (c *Client) Create(ctx context.Context, ruta string, opts *WriteOptions) (*Writer, error) {
+	ruta = rutaAbsoluta(ruta)
+	if opts == nil {
+		opts = &WriteOptions{}
+	}
+	if opts.Replication < 0 || opts.BlockSize < 0 {
+		return nil, &Error{Op: "create", Path: ruta, Err: ErrInvalid}
+	}
+	create := protocolo.PedidoCreate{Ruta: ruta, Replicacion: opts.Replication, BlockSize: opts.BlockSize}
+	var respuesta protocolo.RespuestaCreate
+	if err := c.pedir(ctx, protocolo.OpCreate, create, &respuesta); err != nil {
+		return nil, &Error{Op: "create", Path: ruta, Err: err}
+	}
+	if respuesta.BlockSize < 1 {
+		return nil, &Error{Op: "create", Path: ruta, Err: fmt.Errorf("tamaño de bloque inválido: %d", respuesta.BlockSize)}
+	}
+	return &Writer{c: c, ctx: ctx, ruta: ruta, escritura: respuesta.Escritura, blockSize: respuesta.BlockSize}, nil
+}
+
+func (w *Writer) Write(p []byte) (int, error) {
+	if w.closed {
+		return 0, &Error{Op: "write", Path: w.ruta, Err: ErrClosed}
+	}
+	if w.err != nil {
+		return 0, w.err
+	}
+	n := 0
+	for n < len(p) {
+		if w.buffer == nil {
+			w.buffer = make([]byte, 0, w.blockSize)
+		}
+		copiados := min(len(p)-n, cap(w.buffer)-len(w.buffer))
+		w.buffer = append(w.buffer, p[n:n+copiados]...)
+		n += copiados
+		if len(w.buffer) == cap(w.buffer) {
+			if err := w.enviarBloque(); err != nil {
+				return n, err
+			}
+		}
+	}
+	return n, nil
+}
+
+// enviarBloque pide al Namenode el bloque siguiente y le manda lo que hay en el buffer
+func (w *Writer) enviarBloque() error {
+	var respuesta protocolo.RespuestaBloques
+	err := w.c.pedir(w.ctx, protocolo.OpAddBlock, protocolo.PedidoEscritura{Escritura: w.escritura}, &respuesta)
+	if err == nil {
+		bloques := bloquesRemotos(respuesta.Bloques)
+		if len(bloques) != 1 {
+			err = fmt.Errorf("el Namenode asignó %d bloques y se pidió uno", len(bloques))
+		} else {
+			datos := bytes.NewReader(w.buffer)
+			err = w.c.guardarBloque(w.ctx, w.cantidad, bloques[0], io.NewSectionReader(datos, 0, datos.Size()))
+			if err != nil {
+				err = &BlockError{Index: w.cantidad, Name: bloques[0].Name, Err: err}
+			}
+		}
+	}
+	if err != nil {
+		w.err = &Error{Op: "write", Path: w.ruta, Err: err}
+		w.abandonar()
+		return w.err
+	}
+	w.size += int64(len(w.buffer))
+	w.cantidad++
+	w.buffer = w.buffer[:0]
+	return nil
+}
+
+// Close manda el último bloque y agrega el archivo al DFS
+func (w *Writer) Close() error {
+	if w.closed {
+		return &Error{Op: "close", Path: w.ruta, Err: ErrClosed}
+	}
+	w.closed = true
+	if w.err != nil {
+		return w.err
+	}
+	if len(w.buffer) > 0 {
+		if err := w.enviarBloque(); err != nil {
+			return err
+		}
+	}
+	w.buffer = nil
+	if err := w.c.pedir(w.ctx, protocolo.OpComplete, protocolo.PedidoEscritura{Escritura: w.escritura, Size: w.size}, nil); err != nil {
+		w.abandonar()
+		return &Error{Op: "close", Path: w.ruta, Err: err}
+	}
+	return nil
+}
+
+// abandonar le avisa al Namenode que descarte la escritura y los bloques ya enviados
+func (w *Writer) abandonar() {
+	// Con el contexto cancelado igual hay que avisar, si no los bloques quedan hasta que venza
+	ctx := context.WithoutCancel(w.ctx)
+	if err := w.c.pedir(ctx, protocolo.OpAbandon, protocolo.PedidoEscritura{Escritura: w.escritura}, nil); err != nil && !errors.Is(err, ErrNotExist) {
+		w.err = errors.Join(w.err, fmt.Errorf("no se pudo abandonar la escritura: %w", err))
+	}
+}
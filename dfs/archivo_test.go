@@ -0,0 +1,190 @@
+package dfs
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/UriNoHi/Distributed-file-system-DFS-/protocolo"
+)
+
+// abrirDePrueba guarda datos en /f con bloques de blockSizeDePrueba y lo abre
+func abrirDePrueba(t *testing.T, datos []byte) *Reader {
+	t.Helper()
+	cluster := nuevoCluster(t, 2, 0)
+	cluster.guardar("/f", datos, blockSizeDePrueba)
+	r, err := cluster.cliente(t, 2).Open(context.Background(), "/f")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { r.Close() })
+	return r
+}
+
+// Read sigue de un bloque al siguiente aunque una lectura caiga entre los dos
+func TestReaderCruzaBloques(t *testing.T) {
+	datos := datosDePrueba(3*blockSizeDePrueba + 300)
+	r := abrirDePrueba(t, datos)
+
+	leido := []byte{}
+	p := make([]byte, 700)
+	for {
+		n, err := r.Read(p)
+		leido = append(leido, p[:n]...)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n == 0 {
+			t.Fatal("Read no leyó nada antes del final")
+		}
+	}
+	if !bytes.Equal(leido, datos) {
+		t.Errorf("se leyeron %d bytes distintos de los %d del archivo", len(leido), len(datos))
+	}
+}
+
+// ReadAt que pasa el final del archivo devuelve lo que hay con io.EOF
+func TestReaderReadAtAlFinal(t *testing.T) {
+	datos := datosDePrueba(2*blockSizeDePrueba + 300)
+	r := abrirDePrueba(t, datos)
+	size := int64(len(datos))
+
+	p := make([]byte, 500)
+	n, err := r.ReadAt(p, size-200)
+	if n != 200 || err != io.EOF || !bytes.Equal(p[:n], datos[size-200:]) {
+		t.Errorf("ReadAt de 500 bytes a 200 del final = %d, %v", n, err)
+	}
+	n, err = r.ReadAt(p, blockSizeDePrueba-100)
+	if n != len(p) || err != nil || !bytes.Equal(p, datos[blockSizeDePrueba-100:blockSizeDePrueba+400]) {
+		t.Errorf("ReadAt entre dos bloques = %d, %v", n, err)
+	}
+	if n, err := r.ReadAt(p, size); n != 0 || err != io.EOF {
+		t.Errorf("ReadAt desde el final = %d, %v", n, err)
+	}
+}
+
+// Seek desde el final deja leer la cola; una posición negativa no se acepta ni mueve la actual
+func TestReaderSeek(t *testing.T) {
+	datos := datosDePrueba(2*blockSizeDePrueba + 300)
+	r := abrirDePrueba(t, datos)
+	size := int64(len(datos))
+
+	if posicion, err := r.Seek(-400, io.SeekEnd); posicion != size-400 || err != nil {
+		t.Fatalf("Seek(-400, SeekEnd) = %d, %v", posicion, err)
+	}
+	cola, err := io.ReadAll(r)
+	if err != nil || !bytes.Equal(cola, datos[size-400:]) {
+		t.Errorf("después de Seek(-400, SeekEnd) se leyeron %d bytes, %v", len(cola), err)
+	}
+
+	for _, seek := range []struct {
+		offset int64
+		whence int
+	}{{-size - 1, io.SeekEnd}, {-1, io.SeekStart}, {-size - 1, io.SeekCurrent}} {
+		if _, err := r.Seek(seek.offset, seek.whence); !errors.Is(err, ErrInvalid) {
+			t.Errorf("Seek(%d, %d) = %v, se esperaba %v", seek.offset, seek.whence, err, ErrInvalid)
+		}
+	}
+	if posicion, _ := r.Seek(0, io.SeekCurrent); posicion != size {
+		t.Errorf("un Seek inválido movió la posición a %d", posicion)
+	}
+}
+
+// El archivo aparece recién con Close, con todo lo escrito
+func TestWriterCloseCompleta(t *testing.T) {
+	cluster := nuevoCluster(t, 2, 0)
+	w, err := cluster.cliente(t, 2).Create(context.Background(), "/w", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	datos := datosDePrueba(2*blockSizeDePrueba + 300)
+	for i := 0; i < len(datos); i += 700 {
+		if _, err := w.Write(datos[i:min(i+700, len(datos))]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, existe := cluster.contenido("/w"); existe {
+		t.Errorf("el archivo existe antes de Close")
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if guardado, existe := cluster.contenido("/w"); !existe || !bytes.Equal(guardado, datos) {
+		t.Errorf("después de Close el archivo existe %v con %d bytes de %d", existe, len(guardado), len(datos))
+	}
+}
+
+// Si falla un bloque, la escritura se abandona: Close devuelve el error y no crea el archivo
+func TestWriterAbandona(t *testing.T) {
+	cluster := nuevoCluster(t, 2, 0)
+	w, err := cluster.cliente(t, 2).Create(context.Background(), "/w", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Los IDs de bloque empiezan en 1: falla el segundo bloque
+	cluster.fallar(protocolo.NombreDeBloque(2, 1))
+	datos := datosDePrueba(2*blockSizeDePrueba + 300)
+	_, err = w.Write(datos)
+	var errBloque *BlockError
+	if !errors.As(err, &errBloque) || errBloque.Index != 1 {
+		t.Fatalf("Write con el bloque 1 fallado: error %v", err)
+	}
+	if _, err := w.Write(datos[:1]); err == nil {
+		t.Errorf("Write después de fallar no devolvió error")
+	}
+	if err := w.Close(); err == nil {
+		t.Errorf("Close después de fallar no devolvió error")
+	}
+	if _, existe := cluster.contenido("/w"); existe || cluster.escriturasAbandonadas() != 1 {
+		t.Errorf("escritura fallada: el archivo existe %v, %d escrituras abandonadas", existe, cluster.escriturasAbandonadas())
+	}
+}
+
+// Client.ReadAt lee de cada bloque solo la parte del rango que cae en él, y ningún bloque
+// de afuera, aunque el rango empiece o termine justo en el borde de un bloque
+func TestClientReadAtPideSoloElRango(t *testing.T) {
+	cluster := nuevoCluster(t, 2, 0)
+	datos := datosDePrueba(3*blockSizeDePrueba + 300)
+	cluster.guardar("/f", datos, blockSizeDePrueba)
+	cliente := cluster.cliente(t, 2)
+
+	type lectura struct {
+		bloque        int
+		offset, largo int64
+	}
+	for _, caso := range []struct {
+		offset, largo int64
+		lecturas      []lectura
+	}{
+		{blockSizeDePrueba, 100, []lectura{{1, 0, 100}}},
+		{blockSizeDePrueba - 100, 100, []lectura{{0, blockSizeDePrueba - 100, 100}}},
+		{blockSizeDePrueba - 24, 100, []lectura{{0, blockSizeDePrueba - 24, 24}, {1, 0, 76}}},
+		{blockSizeDePrueba, 2 * blockSizeDePrueba, []lectura{{1, 0, blockSizeDePrueba}, {2, 0, blockSizeDePrueba}}},
+		{3 * blockSizeDePrueba, 300, []lectura{{3, 0, 300}}},
+	} {
+		p := make([]byte, caso.largo)
+		n, err := cliente.ReadAt(context.Background(), "/f", p, caso.offset)
+		if n != len(p) || err != nil || !bytes.Equal(p, datos[caso.offset:caso.offset+caso.largo]) {
+			t.Errorf("ReadAt de %d bytes en %d = %d, %v", caso.largo, caso.offset, n, err)
+		}
+		_, pedidas, _ := cluster.contadores()
+		esperadas := map[string]lectura{}
+		for _, l := range caso.lecturas {
+			esperadas[cluster.nombreDeBloque("/f", l.bloque)] = l
+		}
+		if len(pedidas) != len(esperadas) {
+			t.Errorf("ReadAt de %d bytes en %d leyó %d bloques, se esperaban %d", caso.largo, caso.offset, len(pedidas), len(esperadas))
+		}
+		for _, pedida := range pedidas {
+			l, existe := esperadas[pedida.Bloque]
+			if !existe || pedida.Offset != l.offset || pedida.Largo != l.largo {
+				t.Errorf("ReadAt de %d bytes en %d pidió %d bytes en %d de %s", caso.largo, caso.offset, pedida.Largo, pedida.Offset, pedida.Bloque)
+			}
+		}
+	}
+}
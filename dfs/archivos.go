@@ -0,0 +1,329 @@
+package dfs
+
+import (
+	"context"
+	"io/fs"
+	"log"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/UriNoHi/Distributed-file-system-DFS-/protocolo"
+)
+
+// FileInfo describe un archivo o directorio del DFS, como lo devuelven Stat y List
+type FileInfo struct {
+	Name        string
+	Size        int64
+	Replication int
+	ModTime     time.Time
+	BlockSize   int64 // solo archivos
+	Dir         bool
+	Mode        fs.FileMode // permisos, con fs.ModeDir en los directorios
+	Owner       string
+	Group       string
+}
+
+func (fi FileInfo) IsDir() bool { return fi.Dir }
+
+// BlockLocation es un bloque de un archivo: el nombre con el que se guarda en los
+// DataNodes, los DataNodes que tienen una réplica y qué bytes del archivo tiene
+type BlockLocation struct {
+	Name     string
+	Replicas []string
+	Offset   int64
+	Length   int64
+
+	token string // el que dio el Namenode para acceder al bloque en los DataNodes; vence
+}
+
+// Stat devuelve la información de un archivo o directorio
+func (c *Client) Stat(ctx context.Context, ruta string) (*FileInfo, error) {
+	ruta = rutaAbsoluta(ruta)
+	var entrada protocolo.Entrada
+	if err := c.pedir(ctx, protocolo.OpStat, protocolo.PedidoRuta{Ruta: ruta}, &entrada); err != nil {
+		return nil, &Error{Op: "stat", Path: ruta, Err: err}
+	}
+	fi := fileInfoDe(entrada)
+	fi.Name = path.Base(ruta)
+	return &fi, nil
+}
+
+// List devuelve el contenido de un directorio
+func (c *Client) List(ctx context.Context, ruta string) ([]FileInfo, error) {
+	ruta = rutaAbsoluta(ruta)
+	var ls protocolo.RespuestaLs
+	if err := c.pedir(ctx, protocolo.OpLs, protocolo.PedidoRuta{Ruta: ruta}, &ls); err != nil {
+		return nil, &Error{Op: "ls", Path: ruta, Err: err}
+	}
+	entradas := []FileInfo{}
+	for _, entrada := range ls.Entradas {
+		entradas = append(entradas, fileInfoDe(entrada))
+	}
+	return entradas, nil
+}
+
+func fileInfoDe(entrada protocolo.Entrada) FileInfo {
+	return FileInfo{
+		Name:        entrada.Nombre,
+		Size:        entrada.Size,
+		Replication: entrada.Replicacion,
+		ModTime:     time.Unix(entrada.ModTime, 0),
+		BlockSize:   entrada.BlockSize,
+		Dir:         entrada.Dir,
+		Mode:        modoDe(entrada),
+		Owner:       entrada.Usuario,
+		Group:       entrada.Grupo,
+	}
+}
+
+func modoDe(entrada protocolo.Entrada) fs.FileMode {
+	modo := fs.FileMode(entrada.Modo) & fs.ModePerm
+	if entrada.Dir {
+		modo |= fs.ModeDir
+	}
+	return modo
+}
+
+// Blocks devuelve los bloques de un archivo y dónde está cada réplica
+func (c *Client) Blocks(ctx context.Context, ruta string) ([]BlockLocation, error) {
+	ruta = rutaAbsoluta(ruta)
+	// El tamaño de bloque dice qué bytes del archivo tiene cada bloque
+	fi, err := c.Stat(ctx, ruta)
+	if err != nil {
+		return nil, err
+	}
+	var get protocolo.RespuestaBloques
+	if err := c.pedir(ctx, protocolo.OpGet, protocolo.PedidoRuta{Ruta: ruta}, &get); err != nil {
+		return nil, &Error{Op: "get", Path: ruta, Err: err}
+	}
+	bloques := bloquesRemotos(get.Bloques)
+	for i := range bloques {
+		bloques[i].Offset = int64(i) * fi.BlockSize
+		bloques[i].Length = max(0, min(fi.BlockSize, fi.Size-bloques[i].Offset))
+	}
+	return bloques, nil
+}
+
+// Locations devuelve solo los bloques que tienen algún byte entre offset y offset+largo.
+// Con largo negativo el rango llega hasta el final del archivo.
+func (c *Client) Locations(ctx context.Context, ruta string, offset int64, largo int64) ([]BlockLocation, error) {
+	ruta = rutaAbsoluta(ruta)
+	if offset < 0 {
+		return nil, &Error{Op: "locations", Path: ruta, Err: ErrInvalid}
+	}
+	pedido := protocolo.PedidoLocations{Ruta: ruta, Offset: offset, Largo: max(largo, -1)}
+	var locations protocolo.RespuestaBloques
+	if err := c.pedir(ctx, protocolo.OpLocations, pedido, &locations); err != nil {
+		return nil, &Error{Op: "locations", Path: ruta, Err: err}
+	}
+	return bloquesRemotos(locations.Bloques), nil
+}
+
+// Mkdir crea un directorio; el padre tiene que existir
+func (c *Client) Mkdir(ctx context.Context, ruta string) error {
+	ruta = rutaAbsoluta(ruta)
+	if err := c.pedir(ctx, protocolo.OpMkdir, protocolo.PedidoMkdir{Ruta: ruta}, nil); err != nil {
+		return &Error{Op: "mkdir", Path: ruta, Err: err}
+	}
+	return nil
+}
+
+// MkdirAll crea un directorio y los padres que falten
+func (c *Client) MkdirAll(ctx context.Context, ruta string) error {
+	ruta = rutaAbsoluta(ruta)
+	if err := c.pedir(ctx, protocolo.OpMkdir, protocolo.PedidoMkdir{Ruta: ruta, Padres: true}, nil); err != nil {
+		return &Error{Op: "mkdir", Path: ruta, Err: err}
+	}
+	return nil
+}
+
+// RemoveDir borra un directorio vacío
+func (c *Client) RemoveDir(ctx context.Context, ruta string) error {
+	ruta = rutaAbsoluta(ruta)
+	if err := c.pedir(ctx, protocolo.OpRmdir, protocolo.PedidoRuta{Ruta: ruta}, nil); err != nil {
+		return &Error{Op: "rmdir", Path: ruta, Err: err}
+	}
+	return nil
+}
+
+// Remove borra un archivo y sus bloques
+func (c *Client) Remove(ctx context.Context, ruta string) error {
+	return c.borrar(ctx, ruta, false)
+}
+
+// RemoveAll borra un archivo o un directorio con todo su contenido
+func (c *Client) RemoveAll(ctx context.Context, ruta string) error {
+	return c.borrar(ctx, ruta, true)
+}
+
+func (c *Client) borrar(ctx context.Context, ruta string, recursivo bool) error {
+	ruta = rutaAbsoluta(ruta)
+	var rm protocolo.RespuestaBloques
+	if err := c.pedir(ctx, protocolo.OpRm, protocolo.PedidoRm{Ruta: ruta, Recursivo: recursivo}, &rm); err != nil {
+		return &Error{Op: "rm", Path: ruta, Err: err}
+	}
+	// El archivo ya no está en la metadata; si algún DataNode no responde,
+	// sus bloques quedan huérfanos y los marca el fsck
+	c.borrarBloques(ctx, bloquesRemotos(rm.Bloques))
+	return nil
+}
+
+// Rename mueve o renombra un archivo o directorio. Si el destino es un directorio
+// existente, el origen se mueve adentro.
+func (c *Client) Rename(ctx context.Context, origen string, destino string) error {
+	origen, destino = rutaAbsoluta(origen), rutaAbsoluta(destino)
+	if err := c.pedir(ctx, protocolo.OpMv, protocolo.PedidoMv{Origen: origen, Destino: destino}, nil); err != nil {
+		return &Error{Op: "mv", Path: origen, Err: err}
+	}
+	return nil
+}
+
+// SetReplication cambia las réplicas por bloque de un archivo. El Namenode agrega
+// o borra las copias en segundo plano.
+func (c *Client) SetReplication(ctx context.Context, ruta string, replicacion int) error {
+	ruta = rutaAbsoluta(ruta)
+	if replicacion < 1 {
+		return &Error{Op: "setrep", Path: ruta, Err: ErrInvalid}
+	}
+	if err := c.pedir(ctx, protocolo.OpSetrep, protocolo.PedidoSetrep{Ruta: ruta, Replicacion: replicacion}, nil); err != nil {
+		return &Error{Op: "setrep", Path: ruta, Err: err}
+	}
+	return nil
+}
+
+// Chmod cambia los permisos de un archivo o directorio. Solo lo puede hacer el dueño
+// o el superusuario; solo cuentan los bits de fs.ModePerm.
+func (c *Client) Chmod(ctx context.Context, ruta string, modo fs.FileMode) error {
+	ruta = rutaAbsoluta(ruta)
+	if modo&^(fs.ModePerm|fs.ModeDir) != 0 {
+		return &Error{Op: "chmod", Path: ruta, Err: ErrInvalid}
+	}
+	pedido := protocolo.PedidoChmod{Ruta: ruta, Modo: uint32(modo & fs.ModePerm)}
+	if err := c.pedir(ctx, protocolo.OpChmod, pedido, nil); err != nil {
+		return &Error{Op: "chmod", Path: ruta, Err: err}
+	}
+	return nil
+}
+
+// Chown cambia el dueño y el grupo de un archivo o directorio; el que va vacío no cambia.
+// Solo el superusuario cambia el dueño; el dueño puede cambiar el grupo por otro de los suyos.
+func (c *Client) Chown(ctx context.Context, ruta string, owner string, group string) error {
+	ruta = rutaAbsoluta(ruta)
+	if owner == "" && group == "" {
+		return &Error{Op: "chown", Path: ruta, Err: ErrInvalid}
+	}
+	if err := c.pedir(ctx, protocolo.OpChown, protocolo.PedidoChown{Ruta: ruta, Usuario: owner, Grupo: group}, nil); err != nil {
+		return &Error{Op: "chown", Path: ruta, Err: err}
+	}
+	return nil
+}
+
+// ContentSummary es lo que hay debajo de una ruta, contándose a sí misma, y las cuotas
+// del directorio; 0 es sin cuota
+type ContentSummary struct {
+	DirCount      int64
+	FileCount     int64
+	Length        int64 // bytes de los archivos
+	SpaceConsumed int64 // bytes contando todas las réplicas
+	Quota         int64 // máximo de archivos y directorios
+	SpaceQuota    int64 // máximo de SpaceConsumed
+}
+
+// SetQuota limita cuántos archivos y directorios puede haber debajo de un directorio,
+// contándose a sí mismo; 0 saca la cuota. Solo lo puede hacer el superusuario.
+func (c *Client) SetQuota(ctx context.Context, ruta string, cuota int64) error {
+	return c.cambiarCuota(ctx, protocolo.OpSetQuota, ruta, cuota)
+}
+
+// SetSpaceQuota limita cuántos bytes, contando las réplicas, pueden ocupar los archivos
+// debajo de un directorio; 0 saca la cuota. Solo lo puede hacer el superusuario.
+func (c *Client) SetSpaceQuota(ctx context.Context, ruta string, bytes int64) error {
+	return c.cambiarCuota(ctx, protocolo.OpSetSpaceQuota, ruta, bytes)
+}
+
+func (c *Client) cambiarCuota(ctx context.Context, op string, ruta string, cuota int64) error {
+	ruta = rutaAbsoluta(ruta)
+	if cuota < 0 {
+		return &Error{Op: op, Path: ruta, Err: ErrInvalid}
+	}
+	if err := c.pedir(ctx, op, protocolo.PedidoCuota{Ruta: ruta, Cuota: cuota}, nil); err != nil {
+		return &Error{Op: op, Path: ruta, Err: err}
+	}
+	return nil
+}
+
+// Count cuenta los directorios, archivos y bytes debajo de una ruta y devuelve sus cuotas
+func (c *Client) Count(ctx context.Context, ruta string) (*ContentSummary, error) {
+	ruta = rutaAbsoluta(ruta)
+	var count protocolo.RespuestaCount
+	if err := c.pedir(ctx, protocolo.OpCount, protocolo.PedidoRuta{Ruta: ruta}, &count); err != nil {
+		return nil, &Error{Op: "count", Path: ruta, Err: err}
+	}
+	return &ContentSummary{
+		DirCount:      count.Directorios,
+		FileCount:     count.Archivos,
+		Length:        count.Bytes,
+		SpaceConsumed: count.Espacio,
+		Quota:         count.Cuota,
+		SpaceQuota:    count.CuotaEspacio,
+	}, nil
+}
+
+// Nodes devuelve el estado de cada DataNode, como lo describe el Namenode
+func (c *Client) Nodes(ctx context.Context) ([]string, error) {
+	var nodes protocolo.RespuestaLineas
+	if err := c.pedir(ctx, protocolo.OpNodes, nil, &nodes); err != nil {
+		return nil, &Error{Op: "nodes", Err: err}
+	}
+	return nodes.Lineas, nil
+}
+
+// Fsck devuelve los problemas que encuentra el Namenode al comparar los bloques
+// reportados por los DataNodes con la metadata
+func (c *Client) Fsck(ctx context.Context) ([]string, error) {
+	var fsck protocolo.RespuestaLineas
+	if err := c.pedir(ctx, protocolo.OpFsck, nil, &fsck); err != nil {
+		return nil, &Error{Op: "fsck", Err: err}
+	}
+	return fsck.Lineas, nil
+}
+
+// bloquesRemotos convierte los bloques de una respuesta del Namenode
+func bloquesRemotos(remotos []protocolo.Bloque) []BlockLocation {
+	bloques := []BlockLocation{}
+	for _, bloque := range remotos {
+		bloques = append(bloques, BlockLocation{Name: bloque.Nombre, Replicas: bloque.Replicas, Offset: bloque.Offset, Length: bloque.Largo, token: bloque.Token})
+	}
+	return bloques
+}
+
+// borrarBloques pide a cada réplica que borre su copia del bloque
+func (c *Client) borrarBloques(ctx context.Context, bloques []BlockLocation) {
+	for _, bloque := range bloques {
+		for _, dnAddress := range bloque.Replicas {
+			if err := c.pedirADataNode(ctx, dnAddress, protocolo.OpRmBlock, protocolo.PedidoBloque{Bloque: bloque.Name, Token: bloque.token}); err != nil {
+				log.Printf("[WARNING] No se pudo borrar %s de %s: %v\n", bloque.Name, dnAddress, err)
+			}
+		}
+	}
+}
+
+// pedirADataNode manda un pedido sin datos a un DataNode y espera que lo acepte
+func (c *Client) pedirADataNode(ctx context.Context, dnAddress string, op string, cuerpo any) error {
+	dataNode, err := c.conectarCon(ctx, dnAddress, false)
+	if err != nil {
+		return err
+	}
+	defer dataNode.Close()
+	defer vigilar(ctx, dataNode)()
+	return dataNode.Pedir(op, cuerpo, nil)
+}
+
+// rutaAbsoluta convierte una ruta relativa en absoluta desde la raíz del DFS
+func rutaAbsoluta(ruta string) string {
+	if !strings.HasPrefix(ruta, "/") {
+		ruta = "/" + ruta
+	}
+	return path.Clean(ruta)
+}
@@ -0,0 +1,224 @@
+// Package dfs es la biblioteca cliente del DFS: habla con el Namenode para la metadata
+// y con los DataNodes para los bloques. El REPL de Cliente es un envoltorio sobre ella.
+package dfs
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net"
+	"os/user"
+	"sync"
+	"time"
+
+	"github.com/UriNoHi/Distributed-file-system-DFS-/protocolo"
+	"github.com/UriNoHi/Distributed-file-system-DFS-/protocolo/rpc"
+)
+
+// Cantidad de bloques que se envían o reciben a la vez por defecto en Put y Get
+const transferenciasPorDefecto = 4
+
+// Client es una conexión con el Namenode. Se puede usar desde varias goroutines:
+// los pedidos al Namenode van de a uno y las transferencias de bloques en paralelo.
+type Client struct {
+	// ParallelTransfers es la cantidad de bloques que se transfieren a la vez
+	ParallelTransfers int
+
+	namenode string
+	usuario  string
+	legacy   bool
+	tls      *tls.Config
+	dialer   net.Dialer
+
+	mu       sync.Mutex
+	conexion protocolo.Cliente
+	closed   bool
+}
+
+// Options elige cómo se conecta el cliente
+type Options struct {
+	// Legacy usa el protocolo de frames en lugar de gRPC, para nodos iniciados con -legacy
+	Legacy bool
+
+	// TLS cifra las conexiones con el Namenode y los DataNodes, para clusters iniciados con
+	// certificados. protocolo.ConfigTLS la arma desde archivos PEM; con mTLS tiene que incluir
+	// el certificado del cliente.
+	TLS *tls.Config
+
+	// User es el usuario con el que se hacen los pedidos; vacío usa el del sistema operativo.
+	// Si el Namenode usa mTLS, vale el nombre del certificado del cliente.
+	User string
+}
+
+// Dial se conecta al Namenode en la dirección ip:puerto
+func Dial(ctx context.Context, namenode string) (*Client, error) {
+	return DialWithOptions(ctx, namenode, nil)
+}
+
+// DialWithOptions se conecta al Namenode como Dial, con las opciones dadas
+func DialWithOptions(ctx context.Context, namenode string, opts *Options) (*Client, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+	usuario := opts.User
+	if usuario == "" {
+		if actual, err := user.Current(); err == nil {
+			usuario = actual.Username
+		}
+	}
+	c := &Client{ParallelTransfers: transferenciasPorDefecto, namenode: namenode, usuario: usuario, legacy: opts.Legacy, tls: opts.TLS}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.conectar(ctx); err != nil {
+		return nil, &Error{Op: "dial", Path: namenode, Err: err}
+	}
+	return c, nil
+}
+
+// Close cierra la conexión con el Namenode
+func (c *Client) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closed = true
+	if c.conexion == nil {
+		return nil
+	}
+	err := c.conexion.Close()
+	c.conexion = nil
+	return err
+}
+
+// conectar abre la conexión con el Namenode y negocia la versión del protocolo.
+// Se llama con c.mu tomado.
+func (c *Client) conectar(ctx context.Context) error {
+	conexion, err := c.conectarCon(ctx, c.namenode, true)
+	if err != nil {
+		return err
+	}
+	c.conexion = conexion
+	return nil
+}
+
+// conectarCon abre una conexión con un nodo del DFS, el Namenode o un DataNode, y espera a
+// que esté lista respetando el contexto; después, los deadlines son cosa de quien la usa.
+// El usuario solo se le declara al Namenode, que es el que controla los permisos.
+func (c *Client) conectarCon(ctx context.Context, address string, namenode bool) (protocolo.Cliente, error) {
+	usuario := ""
+	if namenode {
+		usuario = c.usuario
+	}
+	if c.legacy {
+		conexion, err := conectarConFrames(ctx, &c.dialer, address, c.tls, usuario)
+		if err != nil {
+			return nil, err
+		}
+		return conexion, nil
+	}
+	conectar := rpc.ConectarDataNode
+	if namenode {
+		conectar = rpc.ConectarNamenode
+	}
+	conexion, err := conectar(address, c.tls)
+	if err != nil {
+		return nil, err
+	}
+	if err := conexion.Listo(ctx); err != nil {
+		conexion.Close()
+		return nil, err
+	}
+	if usuario != "" {
+		conexion.ComoUsuario(usuario)
+	}
+	return conexion, nil
+}
+
+// conectarConFrames abre la conexión del protocolo de frames y saluda al nodo como usuario
+func conectarConFrames(ctx context.Context, dialer *net.Dialer, address string, config *tls.Config, usuario string) (*protocolo.Conexion, error) {
+	conn, err := protocolo.Dial(ctx, dialer, address, config)
+	if err != nil {
+		return nil, err
+	}
+	dejarDeVigilar := vigilar(ctx, conn)
+	conexion, err := protocolo.ConectarComo(conn, usuario)
+	dejarDeVigilar()
+	if err != nil {
+		conn.Close()
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, err
+	}
+	return conexion, nil
+}
+
+// pedir manda un pedido al Namenode y decodifica el cuerpo de la respuesta en respuesta,
+// que puede ser nil. Una respuesta con error se devuelve traducida por errorRemoto. Si la
+// conexión falla o se cancela el contexto a mitad del pedido, se descarta y el próximo
+// pedido se reconecta.
+func (c *Client) pedir(ctx context.Context, op string, cuerpo any, respuesta any) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return net.ErrClosed
+	}
+	if c.conexion == nil {
+		if err := c.conectar(ctx); err != nil {
+			return err
+		}
+	}
+
+	dejarDeVigilar := vigilar(ctx, c.conexion)
+	err := c.conexion.Pedir(op, cuerpo, respuesta)
+	if !dejarDeVigilar() {
+		// El contexto cortó la conexión, aunque haya llegado la respuesta: una conexión
+		// gRPC cortada no se recupera, así que no sirve para el próximo pedido
+		c.conexion.Close()
+		c.conexion = nil
+	}
+	var rechazo *protocolo.Error
+	if errors.As(err, &rechazo) {
+		// El Namenode contestó: la conexión sigue sirviendo
+		return errorRemoto(rechazo)
+	}
+	if err != nil {
+		if c.conexion != nil {
+			c.conexion.Close()
+			c.conexion = nil
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return err
+	}
+	return nil
+}
+
+// paralelas devuelve cuántos bloques se transfieren a la vez
+func (c *Client) paralelas() int {
+	if c.ParallelTransfers < 1 {
+		return 1
+	}
+	return c.ParallelTransfers
+}
+
+// conDeadline es una conexión que se puede cortar con un deadline: net.Conn o protocolo.Cliente
+type conDeadline interface {
+	SetDeadline(t time.Time) error
+}
+
+// vigilar corta las operaciones pendientes sobre conn cuando se cancela el contexto o vence
+// su deadline. Devuelve la función que deja de vigilar y saca el deadline; esa función
+// devuelve false si el contexto llegó a cortar la conexión.
+func vigilar(ctx context.Context, conn conDeadline) func() bool {
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+	parar := context.AfterFunc(ctx, func() { conn.SetDeadline(time.Now()) })
+	return func() bool {
+		if !parar() {
+			return false
+		}
+		conn.SetDeadline(time.Time{})
+		return true
+	}
+}
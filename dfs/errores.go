@@ -0,0 +1,94 @@
+package dfs
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/UriNoHi/Distributed-file-system-DFS-/protocolo"
+)
+
+// Errores que devuelve el Client. Se comparan con errors.Is, porque siempre llegan
+// envueltos en un *Error con la operación y la ruta.
+var (
+	ErrNotExist    = errors.New("no existe el archivo")
+	ErrExist       = errors.New("ya existe")
+	ErrNotDir      = errors.New("no es un directorio")
+	ErrIsDir       = errors.New("es un directorio")
+	ErrNotEmpty    = errors.New("el directorio no está vacío")
+	ErrInvalid     = errors.New("argumento inválido")
+	ErrNoDataNodes = errors.New("no hay DataNodes vivos")
+	ErrNoReplicas  = errors.New("ninguna réplica respondió")
+	ErrClosed      = errors.New("el archivo está cerrado")
+	ErrPermission  = errors.New("permiso denegado")
+	ErrQuota       = errors.New("se excede la cuota")
+)
+
+// Error describe en qué operación y sobre qué ruta falló un pedido al DFS
+type Error struct {
+	Op   string
+	Path string
+	Err  error
+}
+
+func (e *Error) Error() string {
+	if e.Path == "" {
+		return "dfs " + e.Op + ": " + e.Err.Error()
+	}
+	return "dfs " + e.Op + " " + e.Path + ": " + e.Err.Error()
+}
+
+func (e *Error) Unwrap() error { return e.Err }
+
+// BlockError es el error de un bloque en particular durante un Put o un Get.
+// El primer bloque que falla corta la transferencia de los demás.
+type BlockError struct {
+	Index int
+	Name  string
+	Err   error
+}
+
+func (e *BlockError) Error() string {
+	return fmt.Sprintf("bloque %d (%s): %v", e.Index, e.Name, e.Err)
+}
+
+func (e *BlockError) Unwrap() error { return e.Err }
+
+// RemoteError es un rechazo del Namenode o de un DataNode que no corresponde a ninguno
+// de los errores conocidos. Code es el código del protocolo con el que llegó.
+type RemoteError struct {
+	Code    protocolo.Codigo
+	Message string
+}
+
+func (e *RemoteError) Error() string { return e.Message }
+
+// erroresDelProtocolo traduce los códigos de error del protocolo a los errores del paquete
+var erroresDelProtocolo = map[protocolo.Codigo]error{
+	protocolo.CodigoNoExiste:       ErrNotExist,
+	protocolo.CodigoExiste:         ErrExist,
+	protocolo.CodigoNoEsDirectorio: ErrNotDir,
+	protocolo.CodigoEsDirectorio:   ErrIsDir,
+	protocolo.CodigoNoVacio:        ErrNotEmpty,
+	protocolo.CodigoSinDataNodes:   ErrNoDataNodes,
+	protocolo.CodigoInvalido:       ErrInvalid,
+	protocolo.CodigoPermiso:        ErrPermission,
+	protocolo.CodigoCuota:          ErrQuota,
+}
+
+// errorRemoto convierte una respuesta con error en un error tipado, con el mensaje
+// del otro lado si dice algo más que el error conocido
+func errorRemoto(rechazo *protocolo.Error) error {
+	conocido, ok := erroresDelProtocolo[rechazo.Codigo]
+	if !ok {
+		return &RemoteError{Code: rechazo.Codigo, Message: rechazo.Error()}
+	}
+	if rechazo.Mensaje == "" || rechazo.Mensaje == conocido.Error() {
+		return conocido
+	}
+	// El Namenode puede mandar el error conocido con más detalle: no se repite
+	if detalle, ok := strings.CutPrefix(rechazo.Mensaje, conocido.Error()); ok {
+		return fmt.Errorf("%w%s", conocido, detalle)
+	}
+	return fmt.Errorf("%w: %s", conocido, rechazo.Mensaje)
+}
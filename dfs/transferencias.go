@@ -0,0 +1,438 @@
+package dfs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/UriNoHi/Distributed-file-system-DFS-/protocolo"
+)
+
+// WriteOptions elige cómo se guarda un archivo nuevo. Los valores en cero usan
+// los valores por defecto del Namenode.
+type WriteOptions struct {
+	Replication int
+	BlockSize   int64
+}
+
+// Put sube el archivo local a la ruta remota, reemplazándola si ya existe. Si falla, la
+// ruta remota queda como estaba.
+func (c *Client) Put(ctx context.Context, local string, remoto string, opts *WriteOptions) error {
+	remoto = rutaAbsoluta(remoto)
+	file, err := os.Open(local)
+	if err != nil {
+		return &Error{Op: "put", Path: remoto, Err: err}
+	}
+	defer file.Close()
+	//Los bloques se leen del disco recién al enviarlos, acá solo se cuentan
+	fileInfo, err := file.Stat()
+	if err != nil {
+		return &Error{Op: "put", Path: remoto, Err: err}
+	}
+	return c.escribir(ctx, remoto, file, fileInfo.Size(), opts)
+}
+
+// escribir pide los bloques al Namenode y manda cada uno a sus réplicas. Es una escritura
+// de create/addblock/complete: si el archivo ya existía, se sigue leyendo la versión
+// anterior hasta que llegaron todos los bloques nuevos, y si algo falla queda como estaba.
+func (c *Client) escribir(ctx context.Context, remoto string, datos io.ReaderAt, size int64, opts *WriteOptions) error {
+	if opts == nil {
+		opts = &WriteOptions{}
+	}
+	if opts.Replication < 0 || opts.BlockSize < 0 {
+		return &Error{Op: "put", Path: remoto, Err: ErrInvalid}
+	}
+
+	//Si no se indicó el tamaño de bloque, el Namenode contesta con el del cluster
+	create := protocolo.PedidoCreate{Ruta: remoto, Replicacion: opts.Replication, BlockSize: opts.BlockSize}
+	var respuesta protocolo.RespuestaCreate
+	if err := c.pedir(ctx, protocolo.OpCreate, create, &respuesta); err != nil {
+		return &Error{Op: "put", Path: remoto, Err: err}
+	}
+	escritura, blockSize := respuesta.Escritura, respuesta.BlockSize
+	if err := c.escribirBloques(ctx, escritura, datos, size, blockSize); err != nil {
+		// Con el contexto cancelado igual hay que avisar, si no los bloques quedan hasta que venza
+		abandon := protocolo.PedidoEscritura{Escritura: escritura}
+		if errAbandon := c.pedir(context.WithoutCancel(ctx), protocolo.OpAbandon, abandon, nil); errAbandon != nil && !errors.Is(errAbandon, ErrNotExist) {
+			err = errors.Join(err, fmt.Errorf("no se pudo abandonar la escritura: %w", errAbandon))
+		}
+		return &Error{Op: "put", Path: remoto, Err: err}
+	}
+	return nil
+}
+
+// escribirBloques pide al Namenode un bloque por cada blockSize bytes, los manda y completa
+// la escritura
+func (c *Client) escribirBloques(ctx context.Context, escritura int64, datos io.ReaderAt, size int64, blockSize int64) error {
+	if blockSize < 1 {
+		return fmt.Errorf("tamaño de bloque inválido: %d", blockSize)
+	}
+	cantBlocks := (size + blockSize - 1) / blockSize
+
+	//Consulta al Namenode dónde guardar cada bloque
+	bloques := []BlockLocation{}
+	for i := int64(0); i < cantBlocks; i++ {
+		var respuesta protocolo.RespuestaBloques
+		if err := c.pedir(ctx, protocolo.OpAddBlock, protocolo.PedidoEscritura{Escritura: escritura}, &respuesta); err != nil {
+			return err
+		}
+		asignados := bloquesRemotos(respuesta.Bloques)
+		if len(asignados) != 1 {
+			return fmt.Errorf("el Namenode asignó %d bloques y se pidió uno", len(asignados))
+		}
+		bloques = append(bloques, asignados[0])
+	}
+	renovar := func(i int) (BlockLocation, error) {
+		return c.renovarTokenDeEscritura(ctx, escritura, i, bloques[i])
+	}
+	if err := c.storeDataNodes(ctx, c.paralelas(), bloques, datos, size, blockSize, renovar); err != nil {
+		return err
+	}
+	return c.pedir(ctx, protocolo.OpComplete, protocolo.PedidoEscritura{Escritura: escritura, Size: size}, nil)
+}
+
+// Get baja el archivo remoto al archivo local. Si falla, no deja el archivo local a medias.
+func (c *Client) Get(ctx context.Context, remoto string, local string) error {
+	remoto = rutaAbsoluta(remoto)
+	//El tamaño de bloque dice en qué offset del archivo local va cada bloque
+	fi, err := c.Stat(ctx, remoto)
+	if err != nil {
+		return err
+	}
+	bloques, err := c.Blocks(ctx, remoto)
+	if err != nil {
+		return err
+	}
+
+	//Cada bloque se escribe en el archivo local a medida que llega
+	localFile, err := os.Create(local)
+	if err != nil {
+		return &Error{Op: "get", Path: remoto, Err: err}
+	}
+	err = c.readDataNodes(ctx, remoto, c.paralelas(), bloques, localFile, fi.BlockSize)
+	if cerrar := localFile.Close(); err == nil {
+		err = cerrar
+	}
+	if err != nil {
+		os.Remove(local)
+		return &Error{Op: "get", Path: remoto, Err: err}
+	}
+	return nil
+}
+
+// transferirEnParalelo llama a transferir con cada bloque de 0 a cantidad-1, con hasta
+// paralelas bloques a la vez. Si un bloque falla, cancela el contexto que les pasa a los
+// demás, no empieza más bloques y devuelve el error de ese bloque.
+// Si se cancela ctx tampoco se empiezan más bloques.
+func transferirEnParalelo(ctx context.Context, paralelas int, bloques []BlockLocation, transferir func(ctx context.Context, bloque int) error) error {
+	ctxBloques, cancelar := context.WithCancel(ctx)
+	defer cancelar()
+	var primero error
+	var primeroMutex sync.Mutex
+	pendientes := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < min(paralelas, len(bloques)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for bloque := range pendientes {
+				err := transferir(ctxBloques, bloque)
+				if err == nil {
+					continue
+				}
+				// Los bloques que corta la cancelación fallan después, con el error del contexto
+				primeroMutex.Lock()
+				if primero == nil && ctxBloques.Err() == nil {
+					primero = &BlockError{Index: bloque, Name: bloques[bloque].Name, Err: err}
+					cancelar()
+				}
+				primeroMutex.Unlock()
+			}
+		}()
+	}
+enviar:
+	for bloque := 0; bloque < len(bloques); bloque++ {
+		select {
+		case pendientes <- bloque:
+		case <-ctxBloques.Done():
+			break enviar
+		}
+	}
+	close(pendientes)
+	wg.Wait()
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return primero
+}
+
+// storeDataNodes envía los bloques a sus réplicas, varios a la vez, leyendo cada uno
+// directo de datos en su offset. Si el token de un bloque venció, lo renueva con renovar
+// y lo reintenta.
+func (c *Client) storeDataNodes(ctx context.Context, paralelas int, bloques []BlockLocation, datos io.ReaderAt, size int64, blockSize int64, renovar func(i int) (BlockLocation, error)) error {
+	return transferirEnParalelo(ctx, paralelas, bloques, func(ctx context.Context, i int) error {
+		offset := int64(i) * blockSize
+		seccion := io.NewSectionReader(datos, offset, min(blockSize, size-offset))
+		err := c.guardarBloque(ctx, i, bloques[i], seccion)
+		if err != nil && protocolo.CodigoDe(err) == protocolo.CodigoToken {
+			if renovado, errRenovar := renovar(i); errRenovar == nil {
+				err = c.guardarBloque(ctx, i, renovado, seccion)
+			}
+		}
+		return err
+	})
+}
+
+// guardarBloque manda el bloque i a sus réplicas. Falla si no llegó a ninguna;
+// las réplicas que falten las completa el Namenode.
+func (c *Client) guardarBloque(ctx context.Context, i int, bloque BlockLocation, datos *io.SectionReader) error {
+	err := ErrNoReplicas
+	// El bloque se manda una sola vez a la primera réplica, que lo reenvía por el pipeline
+	// a las demás. Si la primera no responde se arranca el pipeline desde la siguiente.
+	for j, dnAddress := range bloque.Replicas {
+		var durables []string
+		durables, err = c.enviarBloque(ctx, dnAddress, bloque, io.NewSectionReader(datos, 0, datos.Size()), datos.Size(), bloque.Replicas[j+1:])
+		if err == nil && len(durables) == 0 {
+			err = fmt.Errorf("ninguna réplica del pipeline guardó el bloque")
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			log.Printf("[WARNING] Error al enviar el bloque %d al Datanode %s: %v\n", i, dnAddress, err)
+			continue
+		}
+		if len(durables) < len(bloque.Replicas) {
+			log.Printf("[WARNING] El bloque %d quedó en %d de %d réplicas (%s), el Namenode completa las que faltan\n",
+				i, len(durables), len(bloque.Replicas), strings.Join(durables, ","))
+		}
+		return nil
+	}
+	return err
+}
+
+// enviarBloque manda store con los datos del bloque y, al final, sus checksums. El Datanode
+// los reenvía a las réplicas siguientes y contesta con las que lo guardaron.
+func (c *Client) enviarBloque(ctx context.Context, dnAddress string, bloque BlockLocation, datos io.Reader, largo int64, siguientes []string) ([]string, error) {
+	dataNode, err := c.conectarCon(ctx, dnAddress, false)
+	if err != nil {
+		return nil, err
+	}
+	defer dataNode.Close()
+	defer vigilar(ctx, dataNode)()
+
+	//Primero envio el pedido
+	envio, err := dataNode.Guardar(protocolo.PedidoStore{Bloque: bloque.Name, Size: largo, Siguientes: siguientes, Token: bloque.token})
+	if err != nil {
+		return nil, err
+	}
+
+	//Luego envio el bloque de datos y sus checksums, calculados mientras pasan
+	calculador := protocolo.NuevoCalculadorDeChecksums()
+	if _, err := io.CopyN(envio, io.TeeReader(datos, calculador), largo); err != nil {
+		return nil, err
+	}
+	if _, err := envio.Write(calculador.Checksums()); err != nil {
+		return nil, err
+	}
+
+	//Por último espero la confirmación con las réplicas que lo guardaron
+	ack, err := envio.Esperar()
+	if err != nil {
+		return nil, fmt.Errorf("no llegó la confirmación: %w", err)
+	}
+	return ack.Durables, nil
+}
+
+// readDataNodes descarga los bloques, varios a la vez, y escribe cada uno en su offset
+// del archivo local. Todos los bloques salvo el último tienen blockSize bytes. Si el token
+// de un bloque venció, lo renueva y lo reintenta.
+func (c *Client) readDataNodes(ctx context.Context, remoto string, paralelas int, bloques []BlockLocation, localFile *os.File, blockSize int64) error {
+	if len(bloques) == 0 {
+		return localFile.Truncate(0)
+	}
+	largos := make([]int64, len(bloques))
+	err := transferirEnParalelo(ctx, paralelas, bloques, func(ctx context.Context, i int) error {
+		offset := int64(i) * blockSize
+		largo, err := c.readBlock(ctx, bloques[i], localFile, offset, blockSize)
+		if err != nil && protocolo.CodigoDe(err) == protocolo.CodigoToken {
+			if renovado, errRenovar := c.renovarToken(ctx, remoto, bloques[i], offset); errRenovar == nil {
+				largo, err = c.readBlock(ctx, renovado, localFile, offset, blockSize)
+			}
+		}
+		if err != nil {
+			return err
+		}
+		if i < len(bloques)-1 && largo != blockSize {
+			return fmt.Errorf("el bloque tiene %d bytes y se esperaban %d", largo, blockSize)
+		}
+		largos[i] = largo
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Una réplica que falló a mitad de camino pudo haber escrito de más
+	return localFile.Truncate(int64(len(bloques)-1)*blockSize + largos[len(largos)-1])
+}
+
+// renovarToken vuelve a pedir al Namenode el bloque de ruta que empieza en offset, con un
+// token nuevo para leerlo. Los tokens vencen, y un get largo sigue usando los que recibió
+// al empezar.
+func (c *Client) renovarToken(ctx context.Context, ruta string, bloque BlockLocation, offset int64) (BlockLocation, error) {
+	pedido := protocolo.PedidoLocations{Ruta: ruta, Offset: offset, Largo: 1}
+	var locations protocolo.RespuestaBloques
+	if err := c.pedir(ctx, protocolo.OpLocations, pedido, &locations); err != nil {
+		log.Printf("[WARNING] No se pudo renovar el token de %s: %v\n", bloque.Name, err)
+		return bloque, err
+	}
+	for _, renovado := range bloquesRemotos(locations.Bloques) {
+		if renovado.Name == bloque.Name {
+			return renovado, nil
+		}
+	}
+	return bloque, fmt.Errorf("el bloque %s ya no es parte de %s", bloque.Name, ruta)
+}
+
+// renovarTokenDeEscritura le pide al Namenode el bloque i de la escritura con un token nuevo
+// para guardarlo, como renovarToken para un put largo
+func (c *Client) renovarTokenDeEscritura(ctx context.Context, escritura int64, i int, bloque BlockLocation) (BlockLocation, error) {
+	pedido := protocolo.PedidoEscritura{Escritura: escritura, Renovar: true, Bloque: i}
+	var respuesta protocolo.RespuestaBloques
+	if err := c.pedir(ctx, protocolo.OpAddBlock, pedido, &respuesta); err != nil {
+		log.Printf("[WARNING] No se pudo renovar el token de %s: %v\n", bloque.Name, err)
+		return bloque, err
+	}
+	renovados := bloquesRemotos(respuesta.Bloques)
+	if len(renovados) != 1 || renovados[0].Name != bloque.Name {
+		return bloque, fmt.Errorf("el Namenode no devolvió el bloque %s", bloque.Name)
+	}
+	return renovados[0], nil
+}
+
+// readBlock lee un bloque de la primera réplica que responda y lo escribe en offset.
+// Si una réplica falla, la siguiente vuelve a escribir desde el mismo offset. Nunca se
+// escriben más de maximo bytes, así no se pisa el bloque siguiente que se baja en paralelo.
+func (c *Client) readBlock(ctx context.Context, bloque BlockLocation, destino io.WriterAt, offset int64, maximo int64) (int64, error) {
+	err := ErrNoReplicas
+	for _, dnAddress := range bloque.Replicas {
+		var largo int64
+		largo, err = c.readBlockFrom(ctx, dnAddress, bloque, destino, offset, maximo)
+		if err == nil {
+			return largo, nil
+		}
+		if ctx.Err() != nil {
+			return 0, ctx.Err()
+		}
+		log.Printf("[WARNING] Falló la lectura de %s desde %s, probando otra réplica: %v\n", bloque.Name, dnAddress, err)
+	}
+	return 0, err
+}
+
+func (c *Client) readBlockFrom(ctx context.Context, dnAddress string, bloque BlockLocation, destino io.WriterAt, offset int64, maximo int64) (int64, error) {
+	dataNode, err := c.conectarCon(ctx, dnAddress, false)
+	if err != nil {
+		return 0, err
+	}
+	defer dataNode.Close()
+	defer vigilar(ctx, dataNode)()
+
+	rango, datos, err := dataNode.Leer(protocolo.PedidoRead{Bloque: bloque.Name, Largo: -1, Token: bloque.token})
+	if err != nil {
+		return 0, fmt.Errorf("el Datanode no pudo leer el bloque: %w", err)
+	}
+	if rango.Inicio != 0 || rango.Largo < 0 || rango.Largo > maximo {
+		return 0, fmt.Errorf("tamaño de bloque inválido: %d", rango.Largo)
+	}
+
+	//Los datos van directo al destino mientras se calculan sus checksums
+	if err := recibirChunks(datos, io.NewOffsetWriter(destino, offset), rango.Largo); err != nil {
+		return 0, err
+	}
+	return rango.Largo, nil
+}
+
+// readRange lee largo bytes del bloque desde offset (dentro del bloque) de la primera
+// réplica que responda y los escribe en destino desde su posición 0
+func (c *Client) readRange(ctx context.Context, bloque BlockLocation, destino io.WriterAt, offset int64, largo int64) error {
+	err := ErrNoReplicas
+	for _, dnAddress := range bloque.Replicas {
+		err = c.readRangeFrom(ctx, dnAddress, bloque, destino, offset, largo)
+		if err == nil {
+			return nil
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		log.Printf("[WARNING] Falló la lectura de %s desde %s, probando otra réplica: %v\n", bloque.Name, dnAddress, err)
+	}
+	return err
+}
+
+// readRangeFrom pide el rango al Datanode, que manda los chunks enteros que lo cubren,
+// desde inicio, para que se puedan verificar sus checksums; a destino solo llegan los
+// bytes pedidos.
+func (c *Client) readRangeFrom(ctx context.Context, dnAddress string, bloque BlockLocation, destino io.WriterAt, offset int64, largo int64) error {
+	dataNode, err := c.conectarCon(ctx, dnAddress, false)
+	if err != nil {
+		return err
+	}
+	defer dataNode.Close()
+	defer vigilar(ctx, dataNode)()
+
+	rango, datos, err := dataNode.Leer(protocolo.PedidoRead{Bloque: bloque.Name, Offset: offset, Largo: largo, Token: bloque.token})
+	if err != nil {
+		return fmt.Errorf("el Datanode no pudo leer el bloque: %w", err)
+	}
+	if rango.Inicio > offset || rango.Inicio+rango.Largo < offset+largo {
+		return fmt.Errorf("rango inválido: %d-%d", rango.Inicio, rango.Inicio+rango.Largo)
+	}
+
+	ventana := &recorte{destino: destino, desde: offset - rango.Inicio, hasta: offset - rango.Inicio + largo}
+	return recibirChunks(datos, ventana, rango.Largo)
+}
+
+// recibirChunks lee largo bytes de datos y los checksums que los siguen, y los compara.
+// Si el Datanode descubre que su réplica está dañada, manda un error en lugar de los checksums.
+func recibirChunks(datos io.Reader, destino io.Writer, largo int64) error {
+	calculador := protocolo.NuevoCalculadorDeChecksums()
+	if _, err := io.CopyN(io.MultiWriter(destino, calculador), datos, largo); err != nil {
+		return fmt.Errorf("error al leer bloque: %w", err)
+	}
+	checksums := make([]byte, protocolo.CantidadDeChecksums(largo)*protocolo.BytesDeChecksum)
+	if _, err := io.ReadFull(datos, checksums); err != nil {
+		return fmt.Errorf("error al leer checksums: %w", err)
+	}
+	if err := protocolo.CompararChecksums(calculador.Checksums(), checksums); err != nil {
+		return fmt.Errorf("bloque dañado: %w", err)
+	}
+	return nil
+}
+
+// recorte deja pasar a destino solo los bytes entre desde y hasta de lo que se le escribe,
+// corridos para que el byte desde quede en la posición 0 de destino
+type recorte struct {
+	destino  io.WriterAt
+	desde    int64
+	hasta    int64
+	posicion int64
+}
+
+func (r *recorte) Write(p []byte) (int, error) {
+	inicio := max(r.desde, r.posicion)
+	fin := min(r.hasta, r.posicion+int64(len(p)))
+	if inicio < fin {
+		if _, err := r.destino.WriteAt(p[inicio-r.posicion:fin-r.posicion], inicio-r.desde); err != nil {
+			return 0, err
+		}
+	}
+	r.posicion += int64(len(p))
+	return len(p), nil
+}
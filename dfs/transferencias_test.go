@@ -0,0 +1,440 @@
+package dfs
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net"
+	"os"
+	"path"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/UriNoHi/Distributed-file-system-DFS-/protocolo"
+)
+
+const blockSizeDePrueba = 1024
+
+func TestMain(m *testing.M) {
+	log.SetOutput(io.Discard)
+	os.Exit(m.Run())
+}
+
+// clusterDePrueba es un Namenode y DataNodes de mentira que hablan el protocolo de frames y
+// guardan todo en memoria. Cada bloque tiene una sola réplica, rotando entre los DataNodes.
+type clusterDePrueba struct {
+	namenode  string
+	dataNodes []string
+	demora    time.Duration // lo que tarda cada transferencia en un DataNode
+
+	mu              sync.Mutex
+	archivos        map[string]*archivoDePrueba
+	escrituras      map[int64]*archivoDePrueba
+	bloques         map[string][]byte // lo que guardaron los DataNodes, por nombre de bloque
+	ultimaEscritura int64
+	ultimoBloque    int64
+	abandonadas     int
+	stores          int                    // stores que llegaron a algún DataNode
+	lecturas        []protocolo.PedidoRead // reads que llegaron a algún DataNode
+	activas         int                    // transferencias en curso en los DataNodes
+	maxActivas      int
+	fallan          map[string]bool // bloques que los DataNodes no guardan ni leen
+}
+
+type archivoDePrueba struct {
+	ruta      string
+	blockSize int64
+	size      int64
+	bloques   []protocolo.Bloque
+}
+
+func nuevoCluster(t *testing.T, dataNodes int, demora time.Duration) *clusterDePrueba {
+	c := &clusterDePrueba{
+		demora:     demora,
+		archivos:   map[string]*archivoDePrueba{},
+		escrituras: map[int64]*archivoDePrueba{},
+		bloques:    map[string][]byte{},
+		fallan:     map[string]bool{},
+	}
+	c.namenode = escuchar(t, c.atenderNamenode)
+	for i := 0; i < dataNodes; i++ {
+		c.dataNodes = append(c.dataNodes, escuchar(t, c.atenderDataNode))
+	}
+	return c
+}
+
+// escuchar atiende con atender los pedidos de cada conexión hasta que termina el test
+func escuchar(t *testing.T, atender func(direccion string, conexion *protocolo.Conexion, pedido *protocolo.Pedido) error) string {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	direccion := listener.Addr().String()
+	var mu sync.Mutex
+	abiertas := []net.Conn{}
+	t.Cleanup(func() {
+		listener.Close()
+		mu.Lock()
+		defer mu.Unlock()
+		for _, conn := range abiertas {
+			conn.Close()
+		}
+	})
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			mu.Lock()
+			abiertas = append(abiertas, conn)
+			mu.Unlock()
+			go func() {
+				defer conn.Close()
+				conexion, err := protocolo.Aceptar(conn)
+				if err != nil {
+					return
+				}
+				for {
+					pedido, err := conexion.LeerPedido()
+					if err != nil {
+						return
+					}
+					if err := atender(direccion, conexion, pedido); err != nil {
+						return
+					}
+				}
+			}()
+		}
+	}()
+	return direccion
+}
+
+// cliente se conecta al cluster con hasta paralelas transferencias a la vez
+func (c *clusterDePrueba) cliente(t *testing.T, paralelas int) *Client {
+	t.Helper()
+	cliente, err := DialWithOptions(context.Background(), c.namenode, &Options{Legacy: true, User: "ana"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cliente.ParallelTransfers = paralelas
+	t.Cleanup(func() { cliente.Close() })
+	return cliente
+}
+
+// guardar agrega al cluster el archivo ruta con datos, partido en bloques de blockSize
+func (c *clusterDePrueba) guardar(ruta string, datos []byte, blockSize int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	archivo := &archivoDePrueba{ruta: ruta, blockSize: blockSize, size: int64(len(datos))}
+	for offset := int64(0); offset < int64(len(datos)); offset += blockSize {
+		bloque := c.nuevoBloque(len(archivo.bloques))
+		c.bloques[bloque.Nombre] = datos[offset:min(offset+blockSize, int64(len(datos)))]
+		archivo.bloques = append(archivo.bloques, bloque)
+	}
+	c.archivos[ruta] = archivo
+}
+
+// nuevoBloque asigna el bloque i de un archivo; se llama con c.mu tomado
+func (c *clusterDePrueba) nuevoBloque(i int) protocolo.Bloque {
+	c.ultimoBloque++
+	return protocolo.Bloque{Nombre: protocolo.NombreDeBloque(c.ultimoBloque, 1), Replicas: []string{c.dataNodes[i%len(c.dataNodes)]}}
+}
+
+// contenido junta lo que guardaron los DataNodes en los bloques del archivo, en orden
+func (c *clusterDePrueba) contenido(ruta string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	archivo, existe := c.archivos[ruta]
+	if !existe {
+		return nil, false
+	}
+	contenido := []byte{}
+	for _, bloque := range archivo.bloques {
+		contenido = append(contenido, c.bloques[bloque.Nombre]...)
+	}
+	return contenido, true
+}
+
+func (c *clusterDePrueba) fallar(bloque string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.fallan[bloque] = true
+}
+
+// nombreDeBloque devuelve el nombre del bloque i del archivo ruta
+func (c *clusterDePrueba) nombreDeBloque(ruta string, i int) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.archivos[ruta].bloques[i].Nombre
+}
+
+func (c *clusterDePrueba) escriturasAbandonadas() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.abandonadas
+}
+
+// contadores devuelve los stores y reads que llegaron a los DataNodes y la mayor cantidad de
+// transferencias que hubo a la vez, y los vuelve a cero
+func (c *clusterDePrueba) contadores() (int, []protocolo.PedidoRead, int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stores, lecturas, maxActivas := c.stores, c.lecturas, c.maxActivas
+	c.stores, c.lecturas, c.maxActivas = 0, nil, 0
+	return stores, lecturas, maxActivas
+}
+
+func (c *clusterDePrueba) atenderNamenode(_ string, conexion *protocolo.Conexion, pedido *protocolo.Pedido) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	noExiste := protocolo.Errorf(protocolo.CodigoNoExiste, "no existe")
+	switch pedido.Op {
+	case protocolo.OpCreate:
+		var create protocolo.PedidoCreate
+		if err := pedido.Leer(&create); err != nil {
+			return conexion.ResponderError(pedido, err)
+		}
+		if create.BlockSize == 0 {
+			create.BlockSize = blockSizeDePrueba
+		}
+		c.ultimaEscritura++
+		c.escrituras[c.ultimaEscritura] = &archivoDePrueba{ruta: create.Ruta, blockSize: create.BlockSize}
+		return conexion.Responder(pedido, protocolo.RespuestaCreate{Escritura: c.ultimaEscritura, BlockSize: create.BlockSize})
+
+	case protocolo.OpAddBlock, protocolo.OpComplete, protocolo.OpAbandon:
+		var cuerpo protocolo.PedidoEscritura
+		if err := pedido.Leer(&cuerpo); err != nil {
+			return conexion.ResponderError(pedido, err)
+		}
+		escritura := c.escrituras[cuerpo.Escritura]
+		if escritura == nil {
+			return conexion.ResponderError(pedido, noExiste)
+		}
+		switch pedido.Op {
+		case protocolo.OpAddBlock:
+			bloque := c.nuevoBloque(len(escritura.bloques))
+			escritura.bloques = append(escritura.bloques, bloque)
+			return conexion.Responder(pedido, protocolo.RespuestaBloques{Bloques: []protocolo.Bloque{bloque}})
+		case protocolo.OpComplete:
+			escritura.size = cuerpo.Size
+			c.archivos[escritura.ruta] = escritura
+		default:
+			c.abandonadas++
+		}
+		delete(c.escrituras, cuerpo.Escritura)
+		return conexion.Responder(pedido, nil)
+
+	case protocolo.OpStat, protocolo.OpGet:
+		var ruta protocolo.PedidoRuta
+		if err := pedido.Leer(&ruta); err != nil {
+			return conexion.ResponderError(pedido, err)
+		}
+		archivo := c.archivos[ruta.Ruta]
+		if archivo == nil {
+			return conexion.ResponderError(pedido, noExiste)
+		}
+		if pedido.Op == protocolo.OpGet {
+			return conexion.Responder(pedido, protocolo.RespuestaBloques{Bloques: archivo.bloques})
+		}
+		return conexion.Responder(pedido, protocolo.Entrada{Nombre: path.Base(ruta.Ruta), Size: archivo.size, Replicacion: 1, BlockSize: archivo.blockSize})
+
+	case protocolo.OpLocations:
+		var locations protocolo.PedidoLocations
+		if err := pedido.Leer(&locations); err != nil {
+			return conexion.ResponderError(pedido, err)
+		}
+		archivo := c.archivos[locations.Ruta]
+		if archivo == nil {
+			return conexion.ResponderError(pedido, noExiste)
+		}
+		fin := archivo.size
+		if locations.Largo >= 0 {
+			fin = min(fin, locations.Offset+locations.Largo)
+		}
+		bloques := []protocolo.Bloque{}
+		for i, bloque := range archivo.bloques {
+			bloque.Offset = int64(i) * archivo.blockSize
+			bloque.Largo = min(archivo.blockSize, archivo.size-bloque.Offset)
+			if bloque.Offset+bloque.Largo > locations.Offset && bloque.Offset < fin {
+				bloques = append(bloques, bloque)
+			}
+		}
+		return conexion.Responder(pedido, protocolo.RespuestaBloques{Bloques: bloques})
+	}
+	return conexion.ResponderError(pedido, protocolo.Errorf(protocolo.CodigoInvalido, "operación desconocida: %q", pedido.Op))
+}
+
+// atenderDataNode guarda y lee bloques como un DataNode, sin pipeline. Una lectura manda los
+// chunks enteros que cubren lo pedido.
+func (c *clusterDePrueba) atenderDataNode(direccion string, conexion *protocolo.Conexion, pedido *protocolo.Pedido) error {
+	switch pedido.Op {
+	case protocolo.OpStore:
+		var store protocolo.PedidoStore
+		if err := pedido.Leer(&store); err != nil {
+			return err
+		}
+		recibido := make([]byte, store.Size+protocolo.CantidadDeChecksums(store.Size)*protocolo.BytesDeChecksum)
+		if _, err := io.ReadFull(conexion.Datos(), recibido); err != nil {
+			return err
+		}
+		c.mu.Lock()
+		c.stores++
+		falla := c.fallan[store.Bloque]
+		c.mu.Unlock()
+		if falla {
+			return conexion.ResponderError(pedido, protocolo.Errorf(protocolo.CodigoInterno, "disco lleno"))
+		}
+		c.transferir()
+		datos, checksums := recibido[:store.Size], recibido[store.Size:]
+		if err := protocolo.CompararChecksums(checksumsDe(datos), checksums); err != nil {
+			return conexion.ResponderError(pedido, protocolo.Errorf(protocolo.CodigoBloqueCorrupto, "%v", err))
+		}
+		c.mu.Lock()
+		c.bloques[store.Bloque] = datos
+		c.mu.Unlock()
+		return conexion.Responder(pedido, protocolo.RespuestaStore{Durables: []string{direccion}})
+
+	case protocolo.OpReadBlock:
+		var read protocolo.PedidoRead
+		if err := pedido.Leer(&read); err != nil {
+			return err
+		}
+		c.mu.Lock()
+		c.lecturas = append(c.lecturas, read)
+		datos, existe := c.bloques[read.Bloque]
+		falla := c.fallan[read.Bloque]
+		c.mu.Unlock()
+		if !existe || falla {
+			return conexion.ResponderError(pedido, protocolo.Errorf(protocolo.CodigoNoExiste, "no existe el bloque"))
+		}
+		c.transferir()
+		inicio := read.Offset / protocolo.BytesPorChecksum * protocolo.BytesPorChecksum
+		fin := int64(len(datos))
+		if read.Largo >= 0 {
+			fin = min(fin, protocolo.CantidadDeChecksums(read.Offset+read.Largo)*protocolo.BytesPorChecksum)
+		}
+		if err := conexion.Responder(pedido, protocolo.RespuestaRead{Inicio: inicio, Largo: fin - inicio}); err != nil {
+			return err
+		}
+		envio := conexion.EscritorDeDatos()
+		if _, err := envio.Write(datos[inicio:fin]); err != nil {
+			return err
+		}
+		_, err := envio.Write(checksumsDe(datos[inicio:fin]))
+		return err
+	}
+	return conexion.ResponderError(pedido, protocolo.Errorf(protocolo.CodigoInvalido, "operación desconocida: %q", pedido.Op))
+}
+
+// transferir simula lo que tarda una transferencia y cuenta cuántas hay a la vez
+func (c *clusterDePrueba) transferir() {
+	c.mu.Lock()
+	c.activas++
+	c.maxActivas = max(c.maxActivas, c.activas)
+	c.mu.Unlock()
+	time.Sleep(c.demora)
+	c.mu.Lock()
+	c.activas--
+	c.mu.Unlock()
+}
+
+func checksumsDe(datos []byte) []byte {
+	calculador := protocolo.NuevoCalculadorDeChecksums()
+	calculador.Write(datos)
+	return calculador.Checksums()
+}
+
+// datosDePrueba devuelve size bytes en los que cada bloque es distinto de los demás
+func datosDePrueba(size int) []byte {
+	datos := make([]byte, size)
+	for i := range datos {
+		datos[i] = byte(i % 251)
+	}
+	return datos
+}
+
+func archivoLocal(t *testing.T, datos []byte) string {
+	t.Helper()
+	local := filepath.Join(t.TempDir(), "local")
+	if err := os.WriteFile(local, datos, 0644); err != nil {
+		t.Fatal(err)
+	}
+	return local
+}
+
+// Put y Get mueven más bloques que ParallelTransfers, nunca más de esa cantidad a la vez,
+// y cada bloque queda en su offset
+func TestTransferenciasEnParalelo(t *testing.T) {
+	const paralelas = 3
+	cluster := nuevoCluster(t, 3, 20*time.Millisecond)
+	cliente := cluster.cliente(t, paralelas)
+	ctx := context.Background()
+	datos := datosDePrueba(10*blockSizeDePrueba + 100)
+
+	if err := cliente.Put(ctx, archivoLocal(t, datos), "/f", nil); err != nil {
+		t.Fatal(err)
+	}
+	if guardado, _ := cluster.contenido("/f"); !bytes.Equal(guardado, datos) {
+		t.Errorf("los bloques guardados no son el archivo: %d bytes de %d", len(guardado), len(datos))
+	}
+	stores, _, maxActivas := cluster.contadores()
+	if stores != 11 || maxActivas < 2 || maxActivas > paralelas {
+		t.Errorf("put: %d stores con hasta %d a la vez, se esperaban 11 con hasta %d", stores, maxActivas, paralelas)
+	}
+
+	local := filepath.Join(t.TempDir(), "bajado")
+	if err := cliente.Get(ctx, "/f", local); err != nil {
+		t.Fatal(err)
+	}
+	if bajado, err := os.ReadFile(local); err != nil || !bytes.Equal(bajado, datos) {
+		t.Errorf("el archivo bajado no es el que se subió: %d bytes de %d, %v", len(bajado), len(datos), err)
+	}
+	_, lecturas, maxActivas := cluster.contadores()
+	if len(lecturas) != 11 || maxActivas < 2 || maxActivas > paralelas {
+		t.Errorf("get: %d reads con hasta %d a la vez, se esperaban 11 con hasta %d", len(lecturas), maxActivas, paralelas)
+	}
+}
+
+// Un bloque que falla corta los demás: no se empiezan más, el put se abandona y el get no
+// deja el archivo local
+func TestErrorCortaLasTransferencias(t *testing.T) {
+	const paralelas = 2
+	cluster := nuevoCluster(t, 2, 50*time.Millisecond)
+	cliente := cluster.cliente(t, paralelas)
+	ctx := context.Background()
+	datos := datosDePrueba(10 * blockSizeDePrueba)
+
+	// Los IDs de bloque empiezan en 1: falla el segundo bloque del put
+	cluster.fallar(protocolo.NombreDeBloque(2, 1))
+	err := cliente.Put(ctx, archivoLocal(t, datos), "/f", nil)
+	var errBloque *BlockError
+	if !errors.As(err, &errBloque) || errBloque.Index != 1 {
+		t.Fatalf("put con el bloque 1 fallado: error %v", err)
+	}
+	if stores, _, _ := cluster.contadores(); stores > paralelas+1 {
+		t.Errorf("put: se mandaron %d de 10 bloques después del error", stores)
+	}
+	if _, existe := cluster.contenido("/f"); existe || cluster.escriturasAbandonadas() != 1 {
+		t.Errorf("put fallado: el archivo existe %v, %d escrituras abandonadas", existe, cluster.escriturasAbandonadas())
+	}
+
+	cluster.guardar("/g", datos, blockSizeDePrueba)
+	cluster.fallar(cluster.nombreDeBloque("/g", 1))
+	local := filepath.Join(t.TempDir(), "bajado")
+	err = cliente.Get(ctx, "/g", local)
+	if !errors.As(err, &errBloque) || errBloque.Index != 1 {
+		t.Fatalf("get con el bloque 1 fallado: error %v", err)
+	}
+	if _, lecturas, _ := cluster.contadores(); len(lecturas) > paralelas+1 {
+		t.Errorf("get: se pidieron %d de 10 bloques después del error", len(lecturas))
+	}
+	if _, err := os.Stat(local); !os.IsNotExist(err) {
+		t.Errorf("get fallado dejó el archivo local: %v", err)
+	}
+}
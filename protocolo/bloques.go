@@ -0,0 +1,31 @@
+package protocolo
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// NombreDeBloque devuelve el nombre con el que se guardan las réplicas de un bloque en los
+// DataNodes: "blk_<id>_<genstamp>". No depende de la ruta del archivo.
+func NombreDeBloque(id int64, genStamp int64) string {
+	return fmt.Sprintf("blk_%d_%d", id, genStamp)
+}
+
+// ParsearBloque separa un nombre "blk_<id>_<genstamp>". Los bloques guardados
+// antes de los IDs no tienen ese formato y devuelven ok=false.
+func ParsearBloque(nombre string) (id int64, genStamp int64, ok bool) {
+	partes := strings.Split(nombre, "_")
+	if len(partes) != 3 || partes[0] != "blk" {
+		return 0, 0, false
+	}
+	id, err := strconv.ParseInt(partes[1], 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	genStamp, err = strconv.ParseInt(partes[2], 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	return id, genStamp, true
+}
@@ -0,0 +1,15 @@
+package protocolo
+
+import "testing"
+
+func TestParsearBloque(t *testing.T) {
+	id, genStamp, ok := ParsearBloque(NombreDeBloque(42, 7))
+	if !ok || id != 42 || genStamp != 7 {
+		t.Errorf("ParsearBloque(NombreDeBloque(42, 7)) = %d, %d, %v", id, genStamp, ok)
+	}
+	for _, nombre := range []string{"", "ejemplo.txt_block_0", "blk_1", "blk_1_2_3", "blk_a_1", "blk_1_b", "bloque_1_1"} {
+		if _, _, ok := ParsearBloque(nombre); ok {
+			t.Errorf("ParsearBloque(%q) aceptó el nombre", nombre)
+		}
+	}
+}
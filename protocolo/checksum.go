@@ -0,0 +1,73 @@
+package protocolo
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"hash/crc32"
+)
+
+// Cada bloque se divide en chunks de BytesPorChecksum y cada chunk tiene su CRC32C de
+// BytesDeChecksum bytes. Los checksums viajan después de los datos del bloque, en store,
+// read y replicate, y los DataNodes los guardan al lado de cada bloque.
+const (
+	BytesPorChecksum = 512
+	BytesDeChecksum  = 4
+)
+
+var tablaCRC32C = crc32.MakeTable(crc32.Castagnoli)
+
+// CantidadDeChecksums es la cantidad de chunks de un bloque de size bytes
+func CantidadDeChecksums(size int64) int64 {
+	return (size + BytesPorChecksum - 1) / BytesPorChecksum
+}
+
+// CalculadorDeChecksums calcula los checksums de lo que se le escribe, así se pueden
+// verificar los bloques mientras pasan por un io.Copy sin tenerlos enteros en memoria
+type CalculadorDeChecksums struct {
+	chunk     hash.Hash32
+	enChunk   int
+	checksums []byte
+}
+
+func NuevoCalculadorDeChecksums() *CalculadorDeChecksums {
+	return &CalculadorDeChecksums{chunk: crc32.New(tablaCRC32C)}
+}
+
+func (c *CalculadorDeChecksums) Write(p []byte) (int, error) {
+	escritos := len(p)
+	for len(p) > 0 {
+		n := min(BytesPorChecksum-c.enChunk, len(p))
+		c.chunk.Write(p[:n])
+		c.enChunk += n
+		p = p[n:]
+		if c.enChunk == BytesPorChecksum {
+			c.checksums = binary.BigEndian.AppendUint32(c.checksums, c.chunk.Sum32())
+			c.chunk.Reset()
+			c.enChunk = 0
+		}
+	}
+	return escritos, nil
+}
+
+// Checksums devuelve los checksums de todo lo escrito, incluido el último chunk incompleto
+func (c *CalculadorDeChecksums) Checksums() []byte {
+	checksums := append([]byte{}, c.checksums...)
+	if c.enChunk > 0 {
+		checksums = binary.BigEndian.AppendUint32(checksums, c.chunk.Sum32())
+	}
+	return checksums
+}
+
+// CompararChecksums compara los checksums calculados contra los guardados, chunk por chunk
+func CompararChecksums(calculados []byte, guardados []byte) error {
+	if len(calculados) != len(guardados) {
+		return fmt.Errorf("se esperaban %d checksums y hay %d", len(calculados)/BytesDeChecksum, len(guardados)/BytesDeChecksum)
+	}
+	for i := 0; i < len(calculados); i += BytesDeChecksum {
+		if string(calculados[i:i+BytesDeChecksum]) != string(guardados[i:i+BytesDeChecksum]) {
+			return fmt.Errorf("checksum incorrecto en el chunk %d (byte %d)", i/BytesDeChecksum, i/BytesDeChecksum*BytesPorChecksum)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package protocolo
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// Los checksums no dependen de cómo se partan las escrituras y cada chunk dañado se detecta
+func TestChecksums(t *testing.T) {
+	datos := bytes.Repeat([]byte("0123456789"), 2*BytesPorChecksum/10+7)
+	entero := NuevoCalculadorDeChecksums()
+	entero.Write(datos)
+	if largo := int64(len(entero.Checksums())); largo != CantidadDeChecksums(int64(len(datos)))*BytesDeChecksum {
+		t.Fatalf("%d bytes de checksums para %d bytes de datos", largo, len(datos))
+	}
+	partido := NuevoCalculadorDeChecksums()
+	for i := 0; i < len(datos); i += 100 {
+		partido.Write(datos[i:min(i+100, len(datos))])
+	}
+	if err := CompararChecksums(partido.Checksums(), entero.Checksums()); err != nil {
+		t.Errorf("escrito de a 100 bytes: %v", err)
+	}
+
+	danado := append([]byte{}, datos...)
+	danado[BytesPorChecksum+1] ^= 1
+	calculador := NuevoCalculadorDeChecksums()
+	calculador.Write(danado)
+	err := CompararChecksums(calculador.Checksums(), entero.Checksums())
+	if err == nil || !strings.Contains(err.Error(), "chunk 1 ") {
+		t.Errorf("chunk 1 dañado: error %v", err)
+	}
+	if err := CompararChecksums(entero.Checksums()[BytesDeChecksum:], entero.Checksums()); err == nil {
+		t.Errorf("con un checksum de menos no hubo error")
+	}
+}
@@ -0,0 +1,65 @@
+package protocolo
+
+import (
+	"io"
+	"time"
+)
+
+// Cliente es el lado que pide de una conexión con un Namenode o un DataNode. Lo implementan
+// Conexion, con el protocolo de frames, y rpc.Conexion, con gRPC, así quien pide no depende
+// del transporte.
+type Cliente interface {
+	// Pedir manda un pedido sin datos y decodifica el cuerpo de su respuesta en respuesta,
+	// que puede ser nil. Una respuesta con error se devuelve como *Error.
+	Pedir(op string, cuerpo any, respuesta any) error
+	// Guardar empieza un store; los datos y sus checksums se escriben en el Envio
+	Guardar(pedido PedidoStore) (Envio, error)
+	// Leer pide un rango de un bloque y devuelve lo que se va a mandar y un lector con los
+	// datos seguidos de sus checksums. Si algo falla a mitad de los datos, el lector
+	// devuelve un *Error.
+	Leer(pedido PedidoRead) (RespuestaRead, io.Reader, error)
+	// SetDeadline corta lo que esté pendiente en t, como net.Conn. Con t en cero no hay límite.
+	SetDeadline(t time.Time) error
+	Close() error
+}
+
+// Envio es un store en curso
+type Envio interface {
+	io.Writer
+	// Esperar espera que el DataNode conteste con las réplicas que guardaron el bloque
+	Esperar() (RespuestaStore, error)
+}
+
+var _ Cliente = (*Conexion)(nil)
+
+func (c *Conexion) Guardar(pedido PedidoStore) (Envio, error) {
+	id, err := c.Enviar(OpStore, pedido)
+	if err != nil {
+		return nil, err
+	}
+	return &envio{c: c, id: id, datos: c.EscritorDeDatos()}, nil
+}
+
+type envio struct {
+	c     *Conexion
+	id    uint64
+	datos io.Writer
+}
+
+func (e *envio) Write(p []byte) (int, error) { return e.datos.Write(p) }
+
+func (e *envio) Esperar() (RespuestaStore, error) {
+	var ack RespuestaStore
+	err := e.c.Esperar(e.id, &ack)
+	return ack, err
+}
+
+func (c *Conexion) Leer(pedido PedidoRead) (RespuestaRead, io.Reader, error) {
+	var rango RespuestaRead
+	if err := c.Pedir(OpReadBlock, pedido, &rango); err != nil {
+		return RespuestaRead{}, nil, err
+	}
+	return rango, c.Datos(), nil
+}
+
+func (c *Conexion) SetDeadline(t time.Time) error { return c.conn.SetDeadline(t) }
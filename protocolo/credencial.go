@@ -0,0 +1,82 @@
+package protocolo
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// Los DataNodes se identifican ante el Namenode con un secreto que comparten todos los nodos
+// del cluster. Cada mensaje de un DataNode al Namenode (register, heartbeat y los reportes de
+// bloques) lleva una credencial: la operación, la dirección del DataNode y la hora, firmadas
+// con HMAC-SHA256 del secreto. El secreto no viaja nunca; sin él no se puede registrar un
+// DataNode, recibir las claves de los tokens ni reportar bloques en nombre de otro.
+//
+// La credencial viaja como un token: el contenido en JSON y la firma, en base64 y separados
+// por un punto. Vale por VidaDeCredencial alrededor de la hora que dice, así que los relojes
+// de los nodos tienen que estar más o menos de acuerdo.
+
+// VidaDeCredencial es cuánto puede diferir la hora de una credencial de la del Namenode
+const VidaDeCredencial = 5 * time.Minute
+
+// largoMinimoDeSecreto evita secretos que se puedan adivinar
+const largoMinimoDeSecreto = 16
+
+// CredencialDeDataNode es el contenido de una credencial. Hora es unix en segundos.
+type CredencialDeDataNode struct {
+	Op        string `json:"op"`
+	Direccion string `json:"direccion"`
+	Hora      int64  `json:"hora"`
+}
+
+// FirmarCredencial arma la credencial del DataNode direccion para el mensaje op
+func FirmarCredencial(op string, direccion string, secreto []byte, ahora time.Time) string {
+	contenido, _ := json.Marshal(CredencialDeDataNode{Op: op, Direccion: direccion, Hora: ahora.Unix()})
+	return codificacion.EncodeToString(contenido) + "." + codificacion.EncodeToString(firma(contenido, secreto))
+}
+
+// VerificarCredencial comprueba que la credencial esté firmada con el secreto, sea para op
+// y direccion y no esté vencida. Los errores son *Error con CodigoPermiso.
+func VerificarCredencial(texto string, op string, direccion string, secreto []byte, ahora time.Time) error {
+	if texto == "" {
+		return Errorf(CodigoPermiso, "falta la credencial del DataNode")
+	}
+	parteContenido, parteFirma, ok := strings.Cut(texto, ".")
+	contenido, err := codificacion.DecodeString(parteContenido)
+	if !ok || err != nil {
+		return Errorf(CodigoPermiso, "credencial mal formada")
+	}
+	firmaRecibida, err := codificacion.DecodeString(parteFirma)
+	var credencial CredencialDeDataNode
+	if err != nil || json.Unmarshal(contenido, &credencial) != nil {
+		return Errorf(CodigoPermiso, "credencial mal formada")
+	}
+	if !hmac.Equal(firmaRecibida, firma(contenido, secreto)) {
+		return Errorf(CodigoPermiso, "firma de la credencial inválida")
+	}
+	diferencia := ahora.Sub(time.Unix(credencial.Hora, 0))
+	if diferencia > VidaDeCredencial || diferencia < -VidaDeCredencial {
+		return Errorf(CodigoPermiso, "la credencial venció o el reloj del DataNode está desfasado")
+	}
+	if credencial.Op != op || credencial.Direccion != direccion {
+		return Errorf(CodigoPermiso, "la credencial es para %s de %s, no para %s de %s", credencial.Op, credencial.Direccion, op, direccion)
+	}
+	return nil
+}
+
+// LeerSecreto lee el secreto del cluster de un archivo, sin los espacios de los bordes
+func LeerSecreto(ruta string) ([]byte, error) {
+	contenido, err := os.ReadFile(ruta)
+	if err != nil {
+		return nil, err
+	}
+	secreto := bytes.TrimSpace(contenido)
+	if len(secreto) < largoMinimoDeSecreto {
+		return nil, fmt.Errorf("el secreto de %s tiene que tener al menos %d caracteres", ruta, largoMinimoDeSecreto)
+	}
+	return secreto, nil
+}
@@ -0,0 +1,63 @@
+package protocolo
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestVerificarCredencial(t *testing.T) {
+	secreto := []byte("secreto-del-cluster-de-prueba")
+	ahora := time.Now()
+	credencial := FirmarCredencial(OpHeartbeat, "dn:9001", secreto, ahora)
+
+	if err := VerificarCredencial(credencial, OpHeartbeat, "dn:9001", secreto, ahora); err != nil {
+		t.Fatalf("credencial válida rechazada: %v", err)
+	}
+	casos := []struct {
+		nombre    string
+		texto     string
+		op        string
+		direccion string
+		secreto   []byte
+		ahora     time.Time
+	}{
+		{"vacía", "", OpHeartbeat, "dn:9001", secreto, ahora},
+		{"otro secreto", credencial, OpHeartbeat, "dn:9001", []byte("otro-secreto-de-otro-cluster"), ahora},
+		{"otra operación", credencial, OpRegister, "dn:9001", secreto, ahora},
+		{"otra dirección", credencial, OpHeartbeat, "dn:9002", secreto, ahora},
+		{"vencida", credencial, OpHeartbeat, "dn:9001", secreto, ahora.Add(VidaDeCredencial + time.Minute)},
+		{"del futuro", credencial, OpHeartbeat, "dn:9001", secreto, ahora.Add(-VidaDeCredencial - time.Minute)},
+		{"mal formada", "abc", OpHeartbeat, "dn:9001", secreto, ahora},
+		{"firma cambiada", credencial + "x", OpHeartbeat, "dn:9001", secreto, ahora},
+	}
+	for _, caso := range casos {
+		err := VerificarCredencial(caso.texto, caso.op, caso.direccion, caso.secreto, caso.ahora)
+		if err == nil || CodigoDe(err) != CodigoPermiso {
+			t.Errorf("%s: error %v, se esperaba uno con CodigoPermiso", caso.nombre, err)
+		}
+	}
+}
+
+func TestLeerSecreto(t *testing.T) {
+	dir := t.TempDir()
+	ruta := filepath.Join(dir, "secreto")
+	if err := os.WriteFile(ruta, []byte("  0123456789abcdef0123\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	secreto, err := LeerSecreto(ruta)
+	if err != nil || string(secreto) != "0123456789abcdef0123" {
+		t.Fatalf("LeerSecreto = %q, %v", secreto, err)
+	}
+	corto := filepath.Join(dir, "corto")
+	if err := os.WriteFile(corto, []byte("abc\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LeerSecreto(corto); err == nil {
+		t.Error("se aceptó un secreto demasiado corto")
+	}
+	if _, err := LeerSecreto(filepath.Join(dir, "no-existe")); err == nil {
+		t.Error("se aceptó un archivo que no existe")
+	}
+}
@@ -0,0 +1,71 @@
+package protocolo
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Datos devuelve un io.Reader con el contenido de los frames de datos que llegan, uno detrás
+// de otro, como si fueran un solo flujo. Quien lee sabe cuántos bytes esperar. Si en lugar de
+// datos llega una respuesta de error, Read la devuelve como *Error.
+func (c *Conexion) Datos() io.Reader { return lectorDeDatos{c} }
+
+// EscritorDeDatos devuelve un io.Writer que manda lo que se le escribe en frames de datos
+func (c *Conexion) EscritorDeDatos() io.Writer { return escritorDeDatos{c} }
+
+type lectorDeDatos struct{ c *Conexion }
+
+func (l lectorDeDatos) Read(p []byte) (int, error) {
+	c := l.c
+	for c.datos == 0 {
+		tipo, largo, err := c.leerEncabezado()
+		if err != nil {
+			return 0, noEOF(err)
+		}
+		switch tipo {
+		case frameDatos:
+			c.datos = int64(largo)
+		case frameRespuesta:
+			if largo > maximoDeMensaje {
+				return 0, fmt.Errorf("mensaje de %d bytes, el máximo es %d", largo, maximoDeMensaje)
+			}
+			contenido := make([]byte, largo)
+			if _, err := io.ReadFull(c.lector, contenido); err != nil {
+				return 0, noEOF(err)
+			}
+			return 0, respuestaDeError(contenido)
+		default:
+			return 0, fmt.Errorf("se esperaban datos y llegó un frame de tipo %d", tipo)
+		}
+	}
+	n, err := c.lector.Read(p[:min(int64(len(p)), c.datos)])
+	c.datos -= int64(n)
+	return n, noEOF(err)
+}
+
+type escritorDeDatos struct{ c *Conexion }
+
+func (e escritorDeDatos) Write(p []byte) (int, error) {
+	escritos := 0
+	for escritos < len(p) {
+		largo := min(len(p)-escritos, maximoDeDatos)
+		if err := e.c.escribirFrame(frameDatos, p[escritos:escritos+largo]); err != nil {
+			return escritos, err
+		}
+		escritos += largo
+	}
+	return escritos, nil
+}
+
+// respuestaDeError decodifica una respuesta que llegó en lugar de lo que se esperaba
+func respuestaDeError(contenido []byte) error {
+	var r Respuesta
+	if err := json.Unmarshal(contenido, &r); err != nil {
+		return fmt.Errorf("respuesta inválida: %w", err)
+	}
+	if r.Codigo == CodigoOK {
+		return fmt.Errorf("llegó la respuesta del pedido %d antes de tiempo", r.ID)
+	}
+	return &Error{Codigo: r.Codigo, Mensaje: r.Mensaje}
+}
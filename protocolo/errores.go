@@ -0,0 +1,83 @@
+package protocolo
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Codigo dice cómo terminó un pedido
+type Codigo uint16
+
+const (
+	CodigoOK              Codigo = 0
+	CodigoInvalido        Codigo = 1  // operación desconocida, faltan argumentos o no tienen sentido
+	CodigoVersion         Codigo = 2  // no hay una versión del protocolo en común
+	CodigoNoExiste        Codigo = 3  // el archivo, directorio, escritura o bloque no existe
+	CodigoExiste          Codigo = 4  // ya existe
+	CodigoNoEsDirectorio  Codigo = 5  // una parte de la ruta es un archivo
+	CodigoEsDirectorio    Codigo = 6  // se esperaba un archivo
+	CodigoNoVacio         Codigo = 7  // el directorio tiene contenido
+	CodigoSinDataNodes    Codigo = 8  // no hay DataNodes vivos para guardar bloques
+	CodigoNodoDesconocido Codigo = 9  // el DataNode tiene que volver a registrarse
+	CodigoBloqueCorrupto  Codigo = 10 // la réplica no coincide con sus checksums
+	CodigoInterno         Codigo = 11 // falló algo del lado del que contesta, por ejemplo el disco
+	CodigoPermiso         Codigo = 12 // el usuario no tiene permiso sobre el archivo o directorio
+	CodigoToken           Codigo = 13 // falta el token de acceso al bloque, venció o no es válido
+	CodigoCuota           Codigo = 14 // se pasaría la cuota de nombres o de espacio de un directorio
+)
+
+var nombresDeCodigos = map[Codigo]string{
+	CodigoOK:              "ok",
+	CodigoInvalido:        "invalido",
+	CodigoVersion:         "version",
+	CodigoNoExiste:        "no-existe",
+	CodigoExiste:          "existe",
+	CodigoNoEsDirectorio:  "no-es-directorio",
+	CodigoEsDirectorio:    "es-directorio",
+	CodigoNoVacio:         "no-vacio",
+	CodigoSinDataNodes:    "sin-datanodes",
+	CodigoNodoDesconocido: "nodo-desconocido",
+	CodigoBloqueCorrupto:  "bloque-corrupto",
+	CodigoInterno:         "interno",
+	CodigoPermiso:         "permiso",
+	CodigoToken:           "token",
+	CodigoCuota:           "cuota",
+}
+
+func (c Codigo) String() string {
+	if nombre, ok := nombresDeCodigos[c]; ok {
+		return nombre
+	}
+	return fmt.Sprintf("codigo-%d", uint16(c))
+}
+
+// Error es una respuesta con un código distinto de CodigoOK
+type Error struct {
+	Codigo  Codigo
+	Mensaje string
+}
+
+func (e *Error) Error() string {
+	if e.Mensaje == "" {
+		return e.Codigo.String()
+	}
+	return e.Mensaje
+}
+
+// Errorf arma un *Error con el mensaje formateado
+func Errorf(codigo Codigo, formato string, args ...any) *Error {
+	return &Error{Codigo: codigo, Mensaje: fmt.Sprintf(formato, args...)}
+}
+
+// CodigoDe devuelve el código de err si es o envuelve un *Error, y CodigoInterno si no
+func CodigoDe(err error) Codigo {
+	return comoError(err).Codigo
+}
+
+func comoError(err error) *Error {
+	var e *Error
+	if errors.As(err, &e) {
+		return e
+	}
+	return &Error{Codigo: CodigoInterno, Mensaje: err.Error()}
+}
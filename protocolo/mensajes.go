@@ -0,0 +1,261 @@
+package protocolo
+
+// Operaciones del Namenode que usa el cliente. Al lado de cada una, el cuerpo del pedido
+// y el de la respuesta; "-" es sin cuerpo.
+const (
+	OpPut           = "put"           // PedidoPut -> RespuestaBloques
+	OpCreate        = "create"        // PedidoCreate -> RespuestaCreate
+	OpAddBlock      = "addblock"      // PedidoEscritura -> RespuestaBloques, con un bloque
+	OpComplete      = "complete"      // PedidoEscritura -> -
+	OpAbandon       = "abandon"       // PedidoEscritura -> -
+	OpGet           = "get"           // PedidoRuta -> RespuestaBloques
+	OpLocations     = "locations"     // PedidoLocations -> RespuestaBloques, con Offset y Largo
+	OpStat          = "stat"          // PedidoRuta -> Entrada
+	OpLs            = "ls"            // PedidoRuta -> RespuestaLs
+	OpRm            = "rm"            // PedidoRm -> RespuestaBloques, los bloques que hay que borrar
+	OpMkdir         = "mkdir"         // PedidoMkdir -> -
+	OpRmdir         = "rmdir"         // PedidoRuta -> -
+	OpMv            = "mv"            // PedidoMv -> -
+	OpSetrep        = "setrep"        // PedidoSetrep -> -
+	OpChmod         = "chmod"         // PedidoChmod -> -
+	OpChown         = "chown"         // PedidoChown -> -
+	OpSetQuota      = "setquota"      // PedidoCuota -> -
+	OpSetSpaceQuota = "setspacequota" // PedidoCuota -> -
+	OpCount         = "count"         // PedidoRuta -> RespuestaCount
+	OpBlockSize     = "blocksize"     // - -> RespuestaBlockSize
+	OpNodes         = "nodes"         // - -> RespuestaLineas
+	OpFsck          = "fsck"          // - -> RespuestaLineas
+)
+
+// Operaciones del Namenode que usan los DataNodes
+const (
+	OpRegister      = "register"      // PedidoRegistro -> RespuestaClaves
+	OpHeartbeat     = "heartbeat"     // PedidoHeartbeat -> RespuestaClaves
+	OpBlockReport   = "blockreport"   // PedidoReporteCompleto -> -
+	OpBlockReceived = "blockreceived" // PedidoReporteIncremental -> -
+	OpBlockDeleted  = "blockdeleted"  // PedidoReporteIncremental -> -
+	OpBlockCorrupt  = "blockcorrupt"  // PedidoReporteIncremental -> -
+)
+
+// Operaciones de los DataNodes. Las usan el cliente, el Namenode y los otros DataNodes.
+const (
+	// PedidoStore, seguido de los datos y sus checksums -> RespuestaStore
+	OpStore = "store"
+	// PedidoRead -> RespuestaRead, seguida de los datos y sus checksums
+	OpReadBlock = "read"
+	// PedidoBloque -> -
+	OpRmBlock = "rm"
+	// PedidoReplicate -> -, apenas se acepta; la copia sigue en segundo plano
+	OpReplicate = "replicate"
+)
+
+type PedidoRuta struct {
+	Ruta string `json:"ruta"`
+}
+
+type PedidoPut struct {
+	Ruta        string `json:"ruta"`
+	Bloques     int    `json:"bloques"`
+	Replicacion int    `json:"replicacion,omitempty"` // 0 usa la replicación por defecto
+	Size        int64  `json:"size"`
+	BlockSize   int64  `json:"blocksize,omitempty"` // 0 usa el tamaño de bloque por defecto
+}
+
+type PedidoCreate struct {
+	Ruta        string `json:"ruta"`
+	Replicacion int    `json:"replicacion,omitempty"`
+	BlockSize   int64  `json:"blocksize,omitempty"`
+}
+
+type RespuestaCreate struct {
+	Escritura int64 `json:"escritura"`
+	BlockSize int64 `json:"blocksize"`
+}
+
+// PedidoEscritura sigue una escritura empezada con create. Con Renovar, addblock no asigna
+// un bloque nuevo: devuelve el Bloque ya asignado con un token nuevo, para un put largo.
+type PedidoEscritura struct {
+	Escritura int64 `json:"escritura"`
+	Size      int64 `json:"size,omitempty"` // solo complete
+	Renovar   bool  `json:"renovar,omitempty"`
+	Bloque    int   `json:"bloque,omitempty"`
+}
+
+// PedidoLocations pide los bloques con algún byte entre Offset y Offset+Largo.
+// Con Largo negativo el rango llega hasta el final del archivo.
+type PedidoLocations struct {
+	Ruta   string `json:"ruta"`
+	Offset int64  `json:"offset"`
+	Largo  int64  `json:"largo"`
+}
+
+type PedidoRm struct {
+	Ruta      string `json:"ruta"`
+	Recursivo bool   `json:"recursivo,omitempty"`
+}
+
+type PedidoMkdir struct {
+	Ruta   string `json:"ruta"`
+	Padres bool   `json:"padres,omitempty"`
+}
+
+type PedidoMv struct {
+	Origen  string `json:"origen"`
+	Destino string `json:"destino"`
+}
+
+type PedidoSetrep struct {
+	Ruta        string `json:"ruta"`
+	Replicacion int    `json:"replicacion"`
+}
+
+type PedidoChmod struct {
+	Ruta string `json:"ruta"`
+	Modo uint32 `json:"modo"`
+}
+
+// PedidoChown cambia el dueño, el grupo o los dos; el que queda vacío no cambia
+type PedidoChown struct {
+	Ruta    string `json:"ruta"`
+	Usuario string `json:"usuario,omitempty"`
+	Grupo   string `json:"grupo,omitempty"`
+}
+
+// PedidoCuota fija la cuota de nombres (setquota) o de espacio en bytes (setspacequota)
+// de un directorio; 0 la saca
+type PedidoCuota struct {
+	Ruta  string `json:"ruta"`
+	Cuota int64  `json:"cuota"`
+}
+
+// RespuestaCount es lo que hay debajo de una ruta y las cuotas del directorio, 0 si no tiene.
+// Espacio son los bytes contando todas las réplicas, que es lo que limita la cuota de espacio;
+// la de nombres limita Directorios + Archivos.
+type RespuestaCount struct {
+	Directorios  int64 `json:"directorios"`
+	Archivos     int64 `json:"archivos"`
+	Bytes        int64 `json:"bytes"`
+	Espacio      int64 `json:"espacio"`
+	Cuota        int64 `json:"cuota,omitempty"`
+	CuotaEspacio int64 `json:"cuota_espacio,omitempty"`
+}
+
+// Bloque es un bloque de un archivo y los DataNodes que tienen una réplica.
+// Offset y Largo dicen qué bytes del archivo tiene; solo los llena locations.
+// Token es el token de acceso con el que se lo pide a los DataNodes.
+type Bloque struct {
+	Nombre   string   `json:"nombre"`
+	Replicas []string `json:"replicas"`
+	Offset   int64    `json:"offset,omitempty"`
+	Largo    int64    `json:"largo,omitempty"`
+	Token    string   `json:"token,omitempty"`
+}
+
+type RespuestaBloques struct {
+	Bloques []Bloque `json:"bloques"`
+}
+
+// Entrada describe un archivo o directorio, en stat y en ls. ModTime es unix en segundos.
+type Entrada struct {
+	Nombre      string `json:"nombre"`
+	Dir         bool   `json:"dir,omitempty"`
+	Size        int64  `json:"size"`
+	Replicacion int    `json:"replicacion"`
+	ModTime     int64  `json:"mtime"`
+	BlockSize   int64  `json:"blocksize,omitempty"`
+	Usuario     string `json:"usuario,omitempty"` // dueño
+	Grupo       string `json:"grupo,omitempty"`
+	Modo        uint32 `json:"modo,omitempty"` // bits de permisos rwxrwxrwx, como en chmod
+}
+
+type RespuestaLs struct {
+	Entradas []Entrada `json:"entradas"`
+}
+
+type RespuestaBlockSize struct {
+	BlockSize int64 `json:"blocksize"`
+}
+
+// RespuestaLineas es un informe para mostrar, una línea por DataNode o por problema
+type RespuestaLineas struct {
+	Lineas []string `json:"lineas"`
+}
+
+// Los mensajes de los DataNodes al Namenode llevan la credencial firmada con el secreto
+// del cluster (ver FirmarCredencial)
+
+type PedidoRegistro struct {
+	Direccion  string `json:"direccion"`
+	StorageID  string `json:"storage"`
+	Capacidad  int64  `json:"capacidad"`
+	Credencial string `json:"credencial"`
+}
+
+type PedidoHeartbeat struct {
+	Direccion  string `json:"direccion"`
+	Capacidad  int64  `json:"capacidad"`
+	Usado      int64  `json:"usado"`
+	Bloques    int    `json:"bloques"`
+	Credencial string `json:"credencial"`
+}
+
+// RespuestaClaves tiene las claves vigentes para verificar los tokens de acceso a bloques
+type RespuestaClaves struct {
+	Claves []ClaveDeBloques `json:"claves"`
+}
+
+type PedidoReporteCompleto struct {
+	Direccion  string   `json:"direccion"`
+	Bloques    []string `json:"bloques"`
+	Credencial string   `json:"credencial"`
+}
+
+type PedidoReporteIncremental struct {
+	Direccion  string `json:"direccion"`
+	Bloque     string `json:"bloque"`
+	Credencial string `json:"credencial"`
+}
+
+// PedidoStore anuncia un bloque de Size bytes. Siguientes son las réplicas a las que se
+// reenvía por el pipeline.
+type PedidoStore struct {
+	Bloque     string   `json:"bloque"`
+	Size       int64    `json:"size"`
+	Siguientes []string `json:"siguientes,omitempty"`
+	Token      string   `json:"token"`
+}
+
+// RespuestaStore tiene las réplicas del pipeline que guardaron el bloque, empezando por la que contesta
+type RespuestaStore struct {
+	Durables []string `json:"durables"`
+}
+
+// PedidoRead pide Largo bytes desde Offset; con Largo negativo, hasta el final del bloque
+type PedidoRead struct {
+	Bloque string `json:"bloque"`
+	Offset int64  `json:"offset,omitempty"`
+	Largo  int64  `json:"largo"`
+	Token  string `json:"token"`
+}
+
+// RespuestaRead anuncia los bytes que se mandan: los chunks enteros que cubren lo pedido,
+// desde Inicio, para que se puedan verificar con sus checksums
+type RespuestaRead struct {
+	Inicio int64 `json:"inicio"`
+	Largo  int64 `json:"largo"`
+}
+
+type PedidoBloque struct {
+	Bloque string `json:"bloque"`
+	Token  string `json:"token"`
+}
+
+// PedidoReplicate lleva dos tokens: Token para replicar Bloque y TokenDestino para guardar la
+// copia como BloqueDestino, que el DataNode le pasa al destino
+type PedidoReplicate struct {
+	Bloque        string `json:"bloque"`
+	Destino       string `json:"destino"`
+	BloqueDestino string `json:"bloque_destino"`
+	Token         string `json:"token"`
+	TokenDestino  string `json:"token_destino"`
+}
@@ -0,0 +1,325 @@
+// Package protocolo es el protocolo con el que hablan el Cliente, el Namenode y los DataNodes.
+//
+// Todo lo que pasa por una conexión va en frames:
+//
+//	<largo: 4 bytes big endian> <tipo: 1 byte> <contenido: largo bytes>
+//
+// Al conectarse, el que abre la conexión manda un frame hola con las versiones que entiende
+// y el otro contesta con la versión elegida, o con una respuesta de error y cierra. Después,
+// cada pedido es un frame pedido con un ID, una operación y su cuerpo en JSON, y se contesta
+// con un frame respuesta con el mismo ID, un código y el cuerpo de la respuesta.
+// Los bytes de los bloques y sus checksums viajan en frames de datos después del pedido o de
+// la respuesta que los anuncia; si algo falla a mitad de los datos, en su lugar llega una
+// respuesta de error con el ID del pedido.
+package protocolo
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+)
+
+// Versiones del protocolo que entiende este código. Una conexión usa la más alta que
+// entiendan los dos lados.
+const (
+	VersionActual = 1
+	VersionMinima = 1
+)
+
+type tipoDeFrame byte
+
+const (
+	frameHola      tipoDeFrame = 1
+	framePedido    tipoDeFrame = 2
+	frameRespuesta tipoDeFrame = 3
+	frameDatos     tipoDeFrame = 4
+)
+
+const (
+	largoDeEncabezado = 5
+	maximoDeMensaje   = 64 << 20 // un reporte completo de bloques puede ser grande
+	maximoDeDatos     = 64 << 10 // los datos se parten en frames de hasta este tamaño
+)
+
+// Hola es el primer frame de cada lado de la conexión. El que abre la conexión puede decir
+// con qué usuario hace sus pedidos.
+type Hola struct {
+	Version int    `json:"version"`
+	Minima  int    `json:"minima,omitempty"`
+	Usuario string `json:"usuario,omitempty"`
+}
+
+// Pedido es una operación con su cuerpo todavía sin decodificar
+type Pedido struct {
+	ID     uint64          `json:"id"`
+	Op     string          `json:"op"`
+	Cuerpo json.RawMessage `json:"cuerpo,omitempty"`
+
+	// Usuario es quien hizo el pedido. No viaja en el pedido: lo completa el que lo recibe
+	// según la conexión por la que llegó.
+	Usuario string `json:"-"`
+}
+
+// Respuesta contesta al pedido con el mismo ID. Si Codigo no es CodigoOK, Mensaje dice qué pasó.
+type Respuesta struct {
+	ID      uint64          `json:"id"`
+	Codigo  Codigo          `json:"codigo"`
+	Mensaje string          `json:"mensaje,omitempty"`
+	Cuerpo  json.RawMessage `json:"cuerpo,omitempty"`
+}
+
+// Conexion es una conexión que ya pasó el saludo. No se puede usar desde varias goroutines
+// a la vez: cada lado manda un pedido y espera su respuesta antes del siguiente.
+type Conexion struct {
+	conn     net.Conn
+	lector   *bufio.Reader
+	version  int
+	usuario  string // el que declaró el otro lado en el saludo
+	ultimoID uint64
+	datos    int64 // bytes del frame de datos actual que todavía no se leyeron
+}
+
+// Conectar saluda del lado que abrió la conexión. Si el otro lado no entiende ninguna
+// versión en común, devuelve un *Error con CodigoVersion.
+func Conectar(conn net.Conn) (*Conexion, error) {
+	return ConectarComo(conn, "")
+}
+
+// ConectarComo saluda como Conectar y avisa que los pedidos son del usuario
+func ConectarComo(conn net.Conn, usuario string) (*Conexion, error) {
+	c := &Conexion{conn: conn, lector: bufio.NewReader(conn)}
+	if err := c.escribirMensaje(frameHola, Hola{Version: VersionActual, Minima: VersionMinima, Usuario: usuario}); err != nil {
+		return nil, err
+	}
+	tipo, contenido, err := c.leerMensaje()
+	if err != nil {
+		return nil, err
+	}
+	switch tipo {
+	case frameHola:
+		var hola Hola
+		if err := json.Unmarshal(contenido, &hola); err != nil {
+			return nil, fmt.Errorf("saludo inválido: %w", err)
+		}
+		if hola.Version < VersionMinima || hola.Version > VersionActual {
+			return nil, Errorf(CodigoVersion, "el otro lado eligió la versión %d", hola.Version)
+		}
+		c.version = hola.Version
+		return c, nil
+	case frameRespuesta:
+		return nil, respuestaDeError(contenido)
+	default:
+		return nil, fmt.Errorf("saludo inválido: frame de tipo %d", tipo)
+	}
+}
+
+// Aceptar espera el saludo del lado que abrió la conexión y le contesta con la versión elegida
+func Aceptar(conn net.Conn) (*Conexion, error) {
+	c := &Conexion{conn: conn, lector: bufio.NewReader(conn)}
+	tipo, contenido, err := c.leerMensaje()
+	if err != nil {
+		return nil, err
+	}
+	var hola Hola
+	if tipo != frameHola || json.Unmarshal(contenido, &hola) != nil {
+		return nil, fmt.Errorf("saludo inválido")
+	}
+	version := min(hola.Version, VersionActual)
+	minima := hola.Minima
+	if minima == 0 {
+		minima = hola.Version
+	}
+	if version < VersionMinima || version < minima {
+		err := Errorf(CodigoVersion, "versión %d-%d no soportada, se entiende %d-%d", minima, hola.Version, VersionMinima, VersionActual)
+		c.escribirMensaje(frameRespuesta, Respuesta{Codigo: err.Codigo, Mensaje: err.Mensaje})
+		return nil, err
+	}
+	if err := c.escribirMensaje(frameHola, Hola{Version: version}); err != nil {
+		return nil, err
+	}
+	c.version = version
+	c.usuario = hola.Usuario
+	return c, nil
+}
+
+// Version devuelve la versión que se eligió en el saludo
+func (c *Conexion) Version() int { return c.version }
+
+// Usuario devuelve el usuario que declaró en el saludo el que abrió la conexión. Nadie lo
+// verifica: con mTLS vale el del certificado.
+func (c *Conexion) Usuario() string { return c.usuario }
+
+// Conn devuelve la conexión de red, para manejar los deadlines
+func (c *Conexion) Conn() net.Conn { return c.conn }
+
+func (c *Conexion) Close() error { return c.conn.Close() }
+
+// Pedir manda un pedido y decodifica el cuerpo de su respuesta en respuesta, que puede ser nil.
+// Una respuesta con error se devuelve como *Error.
+func (c *Conexion) Pedir(op string, cuerpo any, respuesta any) error {
+	id, err := c.Enviar(op, cuerpo)
+	if err != nil {
+		return err
+	}
+	return c.Esperar(id, respuesta)
+}
+
+// Enviar manda un pedido sin esperar la respuesta y devuelve su ID
+func (c *Conexion) Enviar(op string, cuerpo any) (uint64, error) {
+	c.ultimoID++
+	pedido := Pedido{ID: c.ultimoID, Op: op}
+	if cuerpo != nil {
+		var err error
+		if pedido.Cuerpo, err = json.Marshal(cuerpo); err != nil {
+			return 0, err
+		}
+	}
+	return pedido.ID, c.escribirMensaje(framePedido, pedido)
+}
+
+// Esperar lee la respuesta del pedido id
+func (c *Conexion) Esperar(id uint64, respuesta any) error {
+	tipo, contenido, err := c.leerMensaje()
+	if err != nil {
+		return err
+	}
+	if tipo != frameRespuesta {
+		return fmt.Errorf("se esperaba una respuesta y llegó un frame de tipo %d", tipo)
+	}
+	var r Respuesta
+	if err := json.Unmarshal(contenido, &r); err != nil {
+		return fmt.Errorf("respuesta inválida: %w", err)
+	}
+	if r.ID != id {
+		return fmt.Errorf("llegó la respuesta del pedido %d esperando la del %d", r.ID, id)
+	}
+	if r.Codigo != CodigoOK {
+		return &Error{Codigo: r.Codigo, Mensaje: r.Mensaje}
+	}
+	if respuesta == nil || len(r.Cuerpo) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(r.Cuerpo, respuesta); err != nil {
+		return fmt.Errorf("respuesta inválida: %w", err)
+	}
+	return nil
+}
+
+// LeerPedido espera el próximo pedido. Devuelve io.EOF si el otro lado cerró la conexión.
+func (c *Conexion) LeerPedido() (*Pedido, error) {
+	tipo, contenido, err := c.leerMensaje()
+	if err != nil {
+		return nil, err
+	}
+	if tipo != framePedido {
+		return nil, fmt.Errorf("se esperaba un pedido y llegó un frame de tipo %d", tipo)
+	}
+	var pedido Pedido
+	if err := json.Unmarshal(contenido, &pedido); err != nil {
+		return nil, fmt.Errorf("pedido inválido: %w", err)
+	}
+	return &pedido, nil
+}
+
+// Leer decodifica el cuerpo del pedido en v. Si no se puede, el error tiene CodigoInvalido
+// y se le puede contestar tal cual con ResponderError.
+func (p *Pedido) Leer(v any) error {
+	if len(p.Cuerpo) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(p.Cuerpo, v); err != nil {
+		return Errorf(CodigoInvalido, "cuerpo inválido para %s: %v", p.Op, err)
+	}
+	return nil
+}
+
+// Responder contesta el pedido con cuerpo, que puede ser nil
+func (c *Conexion) Responder(pedido *Pedido, cuerpo any) error {
+	respuesta := Respuesta{ID: pedido.ID, Codigo: CodigoOK}
+	if cuerpo != nil {
+		var err error
+		if respuesta.Cuerpo, err = json.Marshal(cuerpo); err != nil {
+			return err
+		}
+	}
+	return c.escribirMensaje(frameRespuesta, respuesta)
+}
+
+// ResponderError contesta el pedido con un error. Si err no es un *Error se manda con CodigoInterno.
+func (c *Conexion) ResponderError(pedido *Pedido, err error) error {
+	e := comoError(err)
+	return c.escribirMensaje(frameRespuesta, Respuesta{ID: pedido.ID, Codigo: e.Codigo, Mensaje: e.Mensaje})
+}
+
+// escribirMensaje manda v en JSON en un frame del tipo indicado
+func (c *Conexion) escribirMensaje(tipo tipoDeFrame, v any) error {
+	contenido, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return c.escribirFrame(tipo, contenido)
+}
+
+func (c *Conexion) escribirFrame(tipo tipoDeFrame, contenido []byte) error {
+	var encabezado [largoDeEncabezado]byte
+	binary.BigEndian.PutUint32(encabezado[:4], uint32(len(contenido)))
+	encabezado[4] = byte(tipo)
+	frame := net.Buffers{encabezado[:], contenido}
+	_, err := frame.WriteTo(c.conn)
+	return err
+}
+
+// leerMensaje lee el próximo frame que no sea de datos. Si quedaron datos sin leer
+// de un frame anterior, se descartan.
+func (c *Conexion) leerMensaje() (tipoDeFrame, []byte, error) {
+	if c.datos > 0 {
+		if _, err := c.lector.Discard(int(c.datos)); err != nil {
+			return 0, nil, err
+		}
+		c.datos = 0
+	}
+	for {
+		tipo, largo, err := c.leerEncabezado()
+		if err != nil {
+			return 0, nil, err
+		}
+		if tipo == frameDatos {
+			// Datos que nadie leyó, por ejemplo de un pedido que se abandonó
+			if _, err := c.lector.Discard(int(largo)); err != nil {
+				return 0, nil, err
+			}
+			continue
+		}
+		if largo > maximoDeMensaje {
+			return 0, nil, fmt.Errorf("mensaje de %d bytes, el máximo es %d", largo, maximoDeMensaje)
+		}
+		contenido := make([]byte, largo)
+		if _, err := io.ReadFull(c.lector, contenido); err != nil {
+			return 0, nil, noEOF(err)
+		}
+		return tipo, contenido, nil
+	}
+}
+
+// leerEncabezado devuelve io.EOF solo si la conexión se cerró justo entre dos frames
+func (c *Conexion) leerEncabezado() (tipoDeFrame, uint32, error) {
+	var encabezado [largoDeEncabezado]byte
+	if _, err := io.ReadFull(c.lector, encabezado[:]); err != nil {
+		return 0, 0, err
+	}
+	tipo := tipoDeFrame(encabezado[4])
+	if tipo < frameHola || tipo > frameDatos {
+		return 0, 0, fmt.Errorf("frame de tipo desconocido %d", tipo)
+	}
+	return tipo, binary.BigEndian.Uint32(encabezado[:4]), nil
+}
+
+func noEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
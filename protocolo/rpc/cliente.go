@@ -0,0 +1,229 @@
+package rpc
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/UriNoHi/Distributed-file-system-DFS-/protocolo"
+)
+
+// Conexion es una conexión gRPC con un Namenode o con un DataNode. Implementa
+// protocolo.Cliente con los mismos pedidos y respuestas que el protocolo de frames.
+// Todas las llamadas usan el mismo contexto, que se cancela con Close o al vencer SetDeadline.
+type Conexion struct {
+	conn     *grpc.ClientConn
+	metodos  map[string]metodo
+	dataNode DataNodeClient
+
+	ctx      context.Context
+	cancelar context.CancelFunc
+
+	mu    sync.Mutex
+	plazo *time.Timer
+}
+
+var _ protocolo.Cliente = (*Conexion)(nil)
+
+// ConectarNamenode prepara la conexión con el Namenode en address, con TLS si configTLS no
+// es nil. gRPC se conecta recién con el primer pedido; Listo espera la conexión.
+func ConectarNamenode(address string, configTLS *tls.Config) (*Conexion, error) {
+	return conectar(address, configTLS, metodosDelNamenode)
+}
+
+// ConectarDataNode prepara la conexión con un DataNode, como ConectarNamenode
+func ConectarDataNode(address string, configTLS *tls.Config) (*Conexion, error) {
+	return conectar(address, configTLS, metodosDelDataNode)
+}
+
+func conectar(address string, configTLS *tls.Config, metodos map[string]metodo) (*Conexion, error) {
+	credenciales := insecure.NewCredentials()
+	if configTLS != nil {
+		credenciales = credentials.NewTLS(configTLS)
+	}
+	conn, err := grpc.NewClient(address,
+		grpc.WithTransportCredentials(credenciales),
+		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(maximoDeMensaje), grpc.MaxCallSendMsgSize(maximoDeMensaje)))
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancelar := context.WithCancel(context.Background())
+	return &Conexion{conn: conn, metodos: metodos, dataNode: NewDataNodeClient(conn), ctx: ctx, cancelar: cancelar}, nil
+}
+
+// ComoUsuario hace que las llamadas siguientes declaren al usuario, como el saludo del
+// protocolo de frames
+func (c *Conexion) ComoUsuario(usuario string) {
+	c.ctx = metadata.AppendToOutgoingContext(c.ctx, claveUsuario, usuario)
+}
+
+// Listo se conecta y espera a que la conexión esté lista o falle
+func (c *Conexion) Listo(ctx context.Context) error {
+	c.conn.Connect()
+	for {
+		estado := c.conn.GetState()
+		switch estado {
+		case connectivity.Ready:
+			return nil
+		case connectivity.TransientFailure, connectivity.Shutdown:
+			return fmt.Errorf("no se pudo conectar con %s", c.conn.Target())
+		}
+		if !c.conn.WaitForStateChange(ctx, estado) {
+			return ctx.Err()
+		}
+	}
+}
+
+func (c *Conexion) Pedir(op string, cuerpo any, respuesta any) error {
+	m, ok := c.metodos[op]
+	if !ok {
+		return protocolo.Errorf(protocolo.CodigoInvalido, "operación desconocida: %q", op)
+	}
+	pedido, recibido := m.pedido(), m.respuesta()
+	if cuerpo != nil {
+		if err := Convertir(cuerpo, pedido); err != nil {
+			return err
+		}
+	}
+	if err := c.conn.Invoke(c.ctx, m.nombre, pedido, recibido); err != nil {
+		return ErrorDe(err)
+	}
+	if respuesta == nil {
+		return nil
+	}
+	return Convertir(recibido, respuesta)
+}
+
+func (c *Conexion) Guardar(pedido protocolo.PedidoStore) (protocolo.Envio, error) {
+	var store PedidoStore
+	if err := Convertir(pedido, &store); err != nil {
+		return nil, err
+	}
+	stream, err := c.dataNode.Store(c.ctx)
+	if err != nil {
+		return nil, ErrorDe(err)
+	}
+	e := &envio{stream: stream}
+	if err := stream.Send(&FragmentoStore{Pedido: &store}); err != nil {
+		return nil, e.errorDeEnvio(err)
+	}
+	return e, nil
+}
+
+type envio struct {
+	stream DataNode_StoreClient
+}
+
+func (e *envio) Write(p []byte) (int, error) {
+	escritos := 0
+	for escritos < len(p) {
+		largo := min(len(p)-escritos, maximoDeDatos)
+		if err := e.stream.Send(&FragmentoStore{Datos: p[escritos : escritos+largo]}); err != nil {
+			return escritos, e.errorDeEnvio(err)
+		}
+		escritos += largo
+	}
+	return escritos, nil
+}
+
+// errorDeEnvio: si el DataNode terminó la llamada, Send solo devuelve io.EOF y el motivo
+// llega como respuesta
+func (e *envio) errorDeEnvio(err error) error {
+	if err == io.EOF {
+		_, err = e.stream.CloseAndRecv()
+		if err == nil {
+			err = fmt.Errorf("el DataNode contestó antes de recibir todos los datos")
+		}
+	}
+	return ErrorDe(err)
+}
+
+func (e *envio) Esperar() (protocolo.RespuestaStore, error) {
+	var ack protocolo.RespuestaStore
+	recibido, err := e.stream.CloseAndRecv()
+	if err != nil {
+		return ack, ErrorDe(err)
+	}
+	err = Convertir(recibido, &ack)
+	return ack, err
+}
+
+func (c *Conexion) Leer(pedido protocolo.PedidoRead) (protocolo.RespuestaRead, io.Reader, error) {
+	var rango protocolo.RespuestaRead
+	var read PedidoRead
+	if err := Convertir(pedido, &read); err != nil {
+		return rango, nil, err
+	}
+	stream, err := c.dataNode.Read(c.ctx, &read)
+	if err != nil {
+		return rango, nil, ErrorDe(err)
+	}
+	primero, err := stream.Recv()
+	if err != nil {
+		return rango, nil, ErrorDe(err)
+	}
+	if primero.Respuesta == nil {
+		return rango, nil, fmt.Errorf("el DataNode no mandó el rango")
+	}
+	if err := Convertir(primero.Respuesta, &rango); err != nil {
+		return rango, nil, err
+	}
+	return rango, &lectorDeRead{stream: stream, pendiente: primero.Datos}, nil
+}
+
+// lectorDeRead junta los datos de los fragmentos como si fueran un solo flujo. Quien lee sabe
+// cuántos bytes esperar, así que si la llamada termina antes es un corte.
+type lectorDeRead struct {
+	stream    DataNode_ReadClient
+	pendiente []byte
+}
+
+func (l *lectorDeRead) Read(p []byte) (int, error) {
+	for len(l.pendiente) == 0 {
+		fragmento, err := l.stream.Recv()
+		if err == io.EOF {
+			return 0, io.ErrUnexpectedEOF
+		}
+		if err != nil {
+			return 0, ErrorDe(err)
+		}
+		l.pendiente = fragmento.Datos
+	}
+	n := copy(p, l.pendiente)
+	l.pendiente = l.pendiente[n:]
+	return n, nil
+}
+
+// SetDeadline cancela las llamadas en curso y las siguientes cuando llega t. A diferencia de
+// net.Conn, una vez vencido no se puede extender: la conexión ya no sirve.
+func (c *Conexion) SetDeadline(t time.Time) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.plazo != nil {
+		c.plazo.Stop()
+		c.plazo = nil
+	}
+	if t.IsZero() {
+		return nil
+	}
+	if espera := time.Until(t); espera > 0 {
+		c.plazo = time.AfterFunc(espera, c.cancelar)
+	} else {
+		c.cancelar()
+	}
+	return nil
+}
+
+func (c *Conexion) Close() error {
+	c.cancelar()
+	return c.conn.Close()
+}
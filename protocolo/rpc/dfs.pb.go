@@ -0,0 +1,3079 @@
+// Servicios gRPC del DFS: el Namenode, para el cliente y los DataNodes, y los DataNodes,
+// para leer y escribir bloques. Los mensajes son los mismos del protocolo de frames
+// (paquete protocolo) y sus campos se llaman igual que allá en JSON.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        (unknown)
+// source: dfs.proto
+
+package rpc
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Vacio struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *Vacio) Reset() {
+	*x = Vacio{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dfs_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Vacio) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Vacio) ProtoMessage() {}
+
+func (x *Vacio) ProtoReflect() protoreflect.Message {
+	mi := &file_dfs_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Vacio.ProtoReflect.Descriptor instead.
+func (*Vacio) Descriptor() ([]byte, []int) {
+	return file_dfs_proto_rawDescGZIP(), []int{0}
+}
+
+type PedidoRuta struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ruta string `protobuf:"bytes,1,opt,name=ruta,proto3" json:"ruta,omitempty"`
+}
+
+func (x *PedidoRuta) Reset() {
+	*x = PedidoRuta{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dfs_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PedidoRuta) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PedidoRuta) ProtoMessage() {}
+
+func (x *PedidoRuta) ProtoReflect() protoreflect.Message {
+	mi := &file_dfs_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PedidoRuta.ProtoReflect.Descriptor instead.
+func (*PedidoRuta) Descriptor() ([]byte, []int) {
+	return file_dfs_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PedidoRuta) GetRuta() string {
+	if x != nil {
+		return x.Ruta
+	}
+	return ""
+}
+
+type PedidoPut struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ruta        string `protobuf:"bytes,1,opt,name=ruta,proto3" json:"ruta,omitempty"`
+	Bloques     int32  `protobuf:"varint,2,opt,name=bloques,proto3" json:"bloques,omitempty"`
+	Replicacion int32  `protobuf:"varint,3,opt,name=replicacion,proto3" json:"replicacion,omitempty"` // 0 usa la replicación por defecto
+	Size        int64  `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
+	Blocksize   int64  `protobuf:"varint,5,opt,name=blocksize,proto3" json:"blocksize,omitempty"` // 0 usa el tamaño de bloque por defecto
+}
+
+func (x *PedidoPut) Reset() {
+	*x = PedidoPut{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dfs_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PedidoPut) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PedidoPut) ProtoMessage() {}
+
+func (x *PedidoPut) ProtoReflect() protoreflect.Message {
+	mi := &file_dfs_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PedidoPut.ProtoReflect.Descriptor instead.
+func (*PedidoPut) Descriptor() ([]byte, []int) {
+	return file_dfs_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PedidoPut) GetRuta() string {
+	if x != nil {
+		return x.Ruta
+	}
+	return ""
+}
+
+func (x *PedidoPut) GetBloques() int32 {
+	if x != nil {
+		return x.Bloques
+	}
+	return 0
+}
+
+func (x *PedidoPut) GetReplicacion() int32 {
+	if x != nil {
+		return x.Replicacion
+	}
+	return 0
+}
+
+func (x *PedidoPut) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *PedidoPut) GetBlocksize() int64 {
+	if x != nil {
+		return x.Blocksize
+	}
+	return 0
+}
+
+type PedidoCreate struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ruta        string `protobuf:"bytes,1,opt,name=ruta,proto3" json:"ruta,omitempty"`
+	Replicacion int32  `protobuf:"varint,2,opt,name=replicacion,proto3" json:"replicacion,omitempty"`
+	Blocksize   int64  `protobuf:"varint,3,opt,name=blocksize,proto3" json:"blocksize,omitempty"`
+}
+
+func (x *PedidoCreate) Reset() {
+	*x = PedidoCreate{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dfs_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PedidoCreate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PedidoCreate) ProtoMessage() {}
+
+func (x *PedidoCreate) ProtoReflect() protoreflect.Message {
+	mi := &file_dfs_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PedidoCreate.ProtoReflect.Descriptor instead.
+func (*PedidoCreate) Descriptor() ([]byte, []int) {
+	return file_dfs_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *PedidoCreate) GetRuta() string {
+	if x != nil {
+		return x.Ruta
+	}
+	return ""
+}
+
+func (x *PedidoCreate) GetReplicacion() int32 {
+	if x != nil {
+		return x.Replicacion
+	}
+	return 0
+}
+
+func (x *PedidoCreate) GetBlocksize() int64 {
+	if x != nil {
+		return x.Blocksize
+	}
+	return 0
+}
+
+type RespuestaCreate struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Escritura int64 `protobuf:"varint,1,opt,name=escritura,proto3" json:"escritura,omitempty"`
+	Blocksize int64 `protobuf:"varint,2,opt,name=blocksize,proto3" json:"blocksize,omitempty"`
+}
+
+func (x *RespuestaCreate) Reset() {
+	*x = RespuestaCreate{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dfs_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RespuestaCreate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RespuestaCreate) ProtoMessage() {}
+
+func (x *RespuestaCreate) ProtoReflect() protoreflect.Message {
+	mi := &file_dfs_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RespuestaCreate.ProtoReflect.Descriptor instead.
+func (*RespuestaCreate) Descriptor() ([]byte, []int) {
+	return file_dfs_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *RespuestaCreate) GetEscritura() int64 {
+	if x != nil {
+		return x.Escritura
+	}
+	return 0
+}
+
+func (x *RespuestaCreate) GetBlocksize() int64 {
+	if x != nil {
+		return x.Blocksize
+	}
+	return 0
+}
+
+// Con renovar, addblock devuelve el bloque ya asignado con un token nuevo
+type PedidoEscritura struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Escritura int64 `protobuf:"varint,1,opt,name=escritura,proto3" json:"escritura,omitempty"`
+	Size      int64 `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"` // solo complete
+	Renovar   bool  `protobuf:"varint,3,opt,name=renovar,proto3" json:"renovar,omitempty"`
+	Bloque    int32 `protobuf:"varint,4,opt,name=bloque,proto3" json:"bloque,omitempty"`
+}
+
+func (x *PedidoEscritura) Reset() {
+	*x = PedidoEscritura{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dfs_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PedidoEscritura) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PedidoEscritura) ProtoMessage() {}
+
+func (x *PedidoEscritura) ProtoReflect() protoreflect.Message {
+	mi := &file_dfs_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PedidoEscritura.ProtoReflect.Descriptor instead.
+func (*PedidoEscritura) Descriptor() ([]byte, []int) {
+	return file_dfs_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *PedidoEscritura) GetEscritura() int64 {
+	if x != nil {
+		return x.Escritura
+	}
+	return 0
+}
+
+func (x *PedidoEscritura) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *PedidoEscritura) GetRenovar() bool {
+	if x != nil {
+		return x.Renovar
+	}
+	return false
+}
+
+func (x *PedidoEscritura) GetBloque() int32 {
+	if x != nil {
+		return x.Bloque
+	}
+	return 0
+}
+
+// Con largo negativo el rango llega hasta el final del archivo
+type PedidoLocations struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ruta   string `protobuf:"bytes,1,opt,name=ruta,proto3" json:"ruta,omitempty"`
+	Offset int64  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	Largo  int64  `protobuf:"varint,3,opt,name=largo,proto3" json:"largo,omitempty"`
+}
+
+func (x *PedidoLocations) Reset() {
+	*x = PedidoLocations{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dfs_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PedidoLocations) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PedidoLocations) ProtoMessage() {}
+
+func (x *PedidoLocations) ProtoReflect() protoreflect.Message {
+	mi := &file_dfs_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PedidoLocations.ProtoReflect.Descriptor instead.
+func (*PedidoLocations) Descriptor() ([]byte, []int) {
+	return file_dfs_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *PedidoLocations) GetRuta() string {
+	if x != nil {
+		return x.Ruta
+	}
+	return ""
+}
+
+func (x *PedidoLocations) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *PedidoLocations) GetLargo() int64 {
+	if x != nil {
+		return x.Largo
+	}
+	return 0
+}
+
+type PedidoRm struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ruta      string `protobuf:"bytes,1,opt,name=ruta,proto3" json:"ruta,omitempty"`
+	Recursivo bool   `protobuf:"varint,2,opt,name=recursivo,proto3" json:"recursivo,omitempty"`
+}
+
+func (x *PedidoRm) Reset() {
+	*x = PedidoRm{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dfs_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PedidoRm) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PedidoRm) ProtoMessage() {}
+
+func (x *PedidoRm) ProtoReflect() protoreflect.Message {
+	mi := &file_dfs_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PedidoRm.ProtoReflect.Descriptor instead.
+func (*PedidoRm) Descriptor() ([]byte, []int) {
+	return file_dfs_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *PedidoRm) GetRuta() string {
+	if x != nil {
+		return x.Ruta
+	}
+	return ""
+}
+
+func (x *PedidoRm) GetRecursivo() bool {
+	if x != nil {
+		return x.Recursivo
+	}
+	return false
+}
+
+type PedidoMkdir struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ruta   string `protobuf:"bytes,1,opt,name=ruta,proto3" json:"ruta,omitempty"`
+	Padres bool   `protobuf:"varint,2,opt,name=padres,proto3" json:"padres,omitempty"`
+}
+
+func (x *PedidoMkdir) Reset() {
+	*x = PedidoMkdir{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dfs_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PedidoMkdir) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PedidoMkdir) ProtoMessage() {}
+
+func (x *PedidoMkdir) ProtoReflect() protoreflect.Message {
+	mi := &file_dfs_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PedidoMkdir.ProtoReflect.Descriptor instead.
+func (*PedidoMkdir) Descriptor() ([]byte, []int) {
+	return file_dfs_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *PedidoMkdir) GetRuta() string {
+	if x != nil {
+		return x.Ruta
+	}
+	return ""
+}
+
+func (x *PedidoMkdir) GetPadres() bool {
+	if x != nil {
+		return x.Padres
+	}
+	return false
+}
+
+type PedidoMv struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Origen  string `protobuf:"bytes,1,opt,name=origen,proto3" json:"origen,omitempty"`
+	Destino string `protobuf:"bytes,2,opt,name=destino,proto3" json:"destino,omitempty"`
+}
+
+func (x *PedidoMv) Reset() {
+	*x = PedidoMv{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dfs_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PedidoMv) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PedidoMv) ProtoMessage() {}
+
+func (x *PedidoMv) ProtoReflect() protoreflect.Message {
+	mi := &file_dfs_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PedidoMv.ProtoReflect.Descriptor instead.
+func (*PedidoMv) Descriptor() ([]byte, []int) {
+	return file_dfs_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *PedidoMv) GetOrigen() string {
+	if x != nil {
+		return x.Origen
+	}
+	return ""
+}
+
+func (x *PedidoMv) GetDestino() string {
+	if x != nil {
+		return x.Destino
+	}
+	return ""
+}
+
+type PedidoSetrep struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ruta        string `protobuf:"bytes,1,opt,name=ruta,proto3" json:"ruta,omitempty"`
+	Replicacion int32  `protobuf:"varint,2,opt,name=replicacion,proto3" json:"replicacion,omitempty"`
+}
+
+func (x *PedidoSetrep) Reset() {
+	*x = PedidoSetrep{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dfs_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PedidoSetrep) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PedidoSetrep) ProtoMessage() {}
+
+func (x *PedidoSetrep) ProtoReflect() protoreflect.Message {
+	mi := &file_dfs_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PedidoSetrep.ProtoReflect.Descriptor instead.
+func (*PedidoSetrep) Descriptor() ([]byte, []int) {
+	return file_dfs_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *PedidoSetrep) GetRuta() string {
+	if x != nil {
+		return x.Ruta
+	}
+	return ""
+}
+
+func (x *PedidoSetrep) GetReplicacion() int32 {
+	if x != nil {
+		return x.Replicacion
+	}
+	return 0
+}
+
+type PedidoChmod struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ruta string `protobuf:"bytes,1,opt,name=ruta,proto3" json:"ruta,omitempty"`
+	Modo uint32 `protobuf:"varint,2,opt,name=modo,proto3" json:"modo,omitempty"`
+}
+
+func (x *PedidoChmod) Reset() {
+	*x = PedidoChmod{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dfs_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PedidoChmod) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PedidoChmod) ProtoMessage() {}
+
+func (x *PedidoChmod) ProtoReflect() protoreflect.Message {
+	mi := &file_dfs_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PedidoChmod.ProtoReflect.Descriptor instead.
+func (*PedidoChmod) Descriptor() ([]byte, []int) {
+	return file_dfs_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *PedidoChmod) GetRuta() string {
+	if x != nil {
+		return x.Ruta
+	}
+	return ""
+}
+
+func (x *PedidoChmod) GetModo() uint32 {
+	if x != nil {
+		return x.Modo
+	}
+	return 0
+}
+
+// El usuario o el grupo vacío no cambia
+type PedidoChown struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ruta    string `protobuf:"bytes,1,opt,name=ruta,proto3" json:"ruta,omitempty"`
+	Usuario string `protobuf:"bytes,2,opt,name=usuario,proto3" json:"usuario,omitempty"`
+	Grupo   string `protobuf:"bytes,3,opt,name=grupo,proto3" json:"grupo,omitempty"`
+}
+
+func (x *PedidoChown) Reset() {
+	*x = PedidoChown{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dfs_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PedidoChown) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PedidoChown) ProtoMessage() {}
+
+func (x *PedidoChown) ProtoReflect() protoreflect.Message {
+	mi := &file_dfs_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PedidoChown.ProtoReflect.Descriptor instead.
+func (*PedidoChown) Descriptor() ([]byte, []int) {
+	return file_dfs_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *PedidoChown) GetRuta() string {
+	if x != nil {
+		return x.Ruta
+	}
+	return ""
+}
+
+func (x *PedidoChown) GetUsuario() string {
+	if x != nil {
+		return x.Usuario
+	}
+	return ""
+}
+
+func (x *PedidoChown) GetGrupo() string {
+	if x != nil {
+		return x.Grupo
+	}
+	return ""
+}
+
+// La cuota 0 saca la cuota
+type PedidoCuota struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ruta  string `protobuf:"bytes,1,opt,name=ruta,proto3" json:"ruta,omitempty"`
+	Cuota int64  `protobuf:"varint,2,opt,name=cuota,proto3" json:"cuota,omitempty"`
+}
+
+func (x *PedidoCuota) Reset() {
+	*x = PedidoCuota{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dfs_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PedidoCuota) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PedidoCuota) ProtoMessage() {}
+
+func (x *PedidoCuota) ProtoReflect() protoreflect.Message {
+	mi := &file_dfs_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PedidoCuota.ProtoReflect.Descriptor instead.
+func (*PedidoCuota) Descriptor() ([]byte, []int) {
+	return file_dfs_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *PedidoCuota) GetRuta() string {
+	if x != nil {
+		return x.Ruta
+	}
+	return ""
+}
+
+func (x *PedidoCuota) GetCuota() int64 {
+	if x != nil {
+		return x.Cuota
+	}
+	return 0
+}
+
+// Las cuotas en 0 son sin cuota; espacio cuenta todas las réplicas
+type RespuestaCount struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Directorios  int64 `protobuf:"varint,1,opt,name=directorios,proto3" json:"directorios,omitempty"`
+	Archivos     int64 `protobuf:"varint,2,opt,name=archivos,proto3" json:"archivos,omitempty"`
+	Bytes        int64 `protobuf:"varint,3,opt,name=bytes,proto3" json:"bytes,omitempty"`
+	Espacio      int64 `protobuf:"varint,4,opt,name=espacio,proto3" json:"espacio,omitempty"`
+	Cuota        int64 `protobuf:"varint,5,opt,name=cuota,proto3" json:"cuota,omitempty"`
+	CuotaEspacio int64 `protobuf:"varint,6,opt,name=cuota_espacio,json=cuotaEspacio,proto3" json:"cuota_espacio,omitempty"`
+}
+
+func (x *RespuestaCount) Reset() {
+	*x = RespuestaCount{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dfs_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RespuestaCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RespuestaCount) ProtoMessage() {}
+
+func (x *RespuestaCount) ProtoReflect() protoreflect.Message {
+	mi := &file_dfs_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RespuestaCount.ProtoReflect.Descriptor instead.
+func (*RespuestaCount) Descriptor() ([]byte, []int) {
+	return file_dfs_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *RespuestaCount) GetDirectorios() int64 {
+	if x != nil {
+		return x.Directorios
+	}
+	return 0
+}
+
+func (x *RespuestaCount) GetArchivos() int64 {
+	if x != nil {
+		return x.Archivos
+	}
+	return 0
+}
+
+func (x *RespuestaCount) GetBytes() int64 {
+	if x != nil {
+		return x.Bytes
+	}
+	return 0
+}
+
+func (x *RespuestaCount) GetEspacio() int64 {
+	if x != nil {
+		return x.Espacio
+	}
+	return 0
+}
+
+func (x *RespuestaCount) GetCuota() int64 {
+	if x != nil {
+		return x.Cuota
+	}
+	return 0
+}
+
+func (x *RespuestaCount) GetCuotaEspacio() int64 {
+	if x != nil {
+		return x.CuotaEspacio
+	}
+	return 0
+}
+
+// Offset y largo dicen qué bytes del archivo tiene el bloque; solo los llena Locations.
+// token es el token de acceso con el que se lo pide a los DataNodes.
+type Bloque struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Nombre   string   `protobuf:"bytes,1,opt,name=nombre,proto3" json:"nombre,omitempty"`
+	Replicas []string `protobuf:"bytes,2,rep,name=replicas,proto3" json:"replicas,omitempty"`
+	Offset   int64    `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	Largo    int64    `protobuf:"varint,4,opt,name=largo,proto3" json:"largo,omitempty"`
+	Token    string   `protobuf:"bytes,5,opt,name=token,proto3" json:"token,omitempty"`
+}
+
+func (x *Bloque) Reset() {
+	*x = Bloque{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dfs_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Bloque) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Bloque) ProtoMessage() {}
+
+func (x *Bloque) ProtoReflect() protoreflect.Message {
+	mi := &file_dfs_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Bloque.ProtoReflect.Descriptor instead.
+func (*Bloque) Descriptor() ([]byte, []int) {
+	return file_dfs_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Bloque) GetNombre() string {
+	if x != nil {
+		return x.Nombre
+	}
+	return ""
+}
+
+func (x *Bloque) GetReplicas() []string {
+	if x != nil {
+		return x.Replicas
+	}
+	return nil
+}
+
+func (x *Bloque) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *Bloque) GetLargo() int64 {
+	if x != nil {
+		return x.Largo
+	}
+	return 0
+}
+
+func (x *Bloque) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type RespuestaBloques struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Bloques []*Bloque `protobuf:"bytes,1,rep,name=bloques,proto3" json:"bloques,omitempty"`
+}
+
+func (x *RespuestaBloques) Reset() {
+	*x = RespuestaBloques{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dfs_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RespuestaBloques) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RespuestaBloques) ProtoMessage() {}
+
+func (x *RespuestaBloques) ProtoReflect() protoreflect.Message {
+	mi := &file_dfs_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RespuestaBloques.ProtoReflect.Descriptor instead.
+func (*RespuestaBloques) Descriptor() ([]byte, []int) {
+	return file_dfs_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *RespuestaBloques) GetBloques() []*Bloque {
+	if x != nil {
+		return x.Bloques
+	}
+	return nil
+}
+
+// mtime es unix en segundos
+type Entrada struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Nombre      string `protobuf:"bytes,1,opt,name=nombre,proto3" json:"nombre,omitempty"`
+	Dir         bool   `protobuf:"varint,2,opt,name=dir,proto3" json:"dir,omitempty"`
+	Size        int64  `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	Replicacion int32  `protobuf:"varint,4,opt,name=replicacion,proto3" json:"replicacion,omitempty"`
+	Mtime       int64  `protobuf:"varint,5,opt,name=mtime,proto3" json:"mtime,omitempty"`
+	Blocksize   int64  `protobuf:"varint,6,opt,name=blocksize,proto3" json:"blocksize,omitempty"`
+	Usuario     string `protobuf:"bytes,7,opt,name=usuario,proto3" json:"usuario,omitempty"`
+	Grupo       string `protobuf:"bytes,8,opt,name=grupo,proto3" json:"grupo,omitempty"`
+	Modo        uint32 `protobuf:"varint,9,opt,name=modo,proto3" json:"modo,omitempty"`
+}
+
+func (x *Entrada) Reset() {
+	*x = Entrada{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dfs_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Entrada) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Entrada) ProtoMessage() {}
+
+func (x *Entrada) ProtoReflect() protoreflect.Message {
+	mi := &file_dfs_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Entrada.ProtoReflect.Descriptor instead.
+func (*Entrada) Descriptor() ([]byte, []int) {
+	return file_dfs_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *Entrada) GetNombre() string {
+	if x != nil {
+		return x.Nombre
+	}
+	return ""
+}
+
+func (x *Entrada) GetDir() bool {
+	if x != nil {
+		return x.Dir
+	}
+	return false
+}
+
+func (x *Entrada) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *Entrada) GetReplicacion() int32 {
+	if x != nil {
+		return x.Replicacion
+	}
+	return 0
+}
+
+func (x *Entrada) GetMtime() int64 {
+	if x != nil {
+		return x.Mtime
+	}
+	return 0
+}
+
+func (x *Entrada) GetBlocksize() int64 {
+	if x != nil {
+		return x.Blocksize
+	}
+	return 0
+}
+
+func (x *Entrada) GetUsuario() string {
+	if x != nil {
+		return x.Usuario
+	}
+	return ""
+}
+
+func (x *Entrada) GetGrupo() string {
+	if x != nil {
+		return x.Grupo
+	}
+	return ""
+}
+
+func (x *Entrada) GetModo() uint32 {
+	if x != nil {
+		return x.Modo
+	}
+	return 0
+}
+
+type RespuestaLs struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Entradas []*Entrada `protobuf:"bytes,1,rep,name=entradas,proto3" json:"entradas,omitempty"`
+}
+
+func (x *RespuestaLs) Reset() {
+	*x = RespuestaLs{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dfs_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RespuestaLs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RespuestaLs) ProtoMessage() {}
+
+func (x *RespuestaLs) ProtoReflect() protoreflect.Message {
+	mi := &file_dfs_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RespuestaLs.ProtoReflect.Descriptor instead.
+func (*RespuestaLs) Descriptor() ([]byte, []int) {
+	return file_dfs_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *RespuestaLs) GetEntradas() []*Entrada {
+	if x != nil {
+		return x.Entradas
+	}
+	return nil
+}
+
+type RespuestaBlockSize struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Blocksize int64 `protobuf:"varint,1,opt,name=blocksize,proto3" json:"blocksize,omitempty"`
+}
+
+func (x *RespuestaBlockSize) Reset() {
+	*x = RespuestaBlockSize{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dfs_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RespuestaBlockSize) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RespuestaBlockSize) ProtoMessage() {}
+
+func (x *RespuestaBlockSize) ProtoReflect() protoreflect.Message {
+	mi := &file_dfs_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RespuestaBlockSize.ProtoReflect.Descriptor instead.
+func (*RespuestaBlockSize) Descriptor() ([]byte, []int) {
+	return file_dfs_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *RespuestaBlockSize) GetBlocksize() int64 {
+	if x != nil {
+		return x.Blocksize
+	}
+	return 0
+}
+
+type RespuestaLineas struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Lineas []string `protobuf:"bytes,1,rep,name=lineas,proto3" json:"lineas,omitempty"`
+}
+
+func (x *RespuestaLineas) Reset() {
+	*x = RespuestaLineas{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dfs_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RespuestaLineas) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RespuestaLineas) ProtoMessage() {}
+
+func (x *RespuestaLineas) ProtoReflect() protoreflect.Message {
+	mi := &file_dfs_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RespuestaLineas.ProtoReflect.Descriptor instead.
+func (*RespuestaLineas) Descriptor() ([]byte, []int) {
+	return file_dfs_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *RespuestaLineas) GetLineas() []string {
+	if x != nil {
+		return x.Lineas
+	}
+	return nil
+}
+
+// credencial: la del DataNode, firmada con el secreto del cluster (protocolo.FirmarCredencial)
+type PedidoRegistro struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Direccion  string `protobuf:"bytes,1,opt,name=direccion,proto3" json:"direccion,omitempty"`
+	Storage    string `protobuf:"bytes,2,opt,name=storage,proto3" json:"storage,omitempty"`
+	Capacidad  int64  `protobuf:"varint,3,opt,name=capacidad,proto3" json:"capacidad,omitempty"`
+	Credencial string `protobuf:"bytes,4,opt,name=credencial,proto3" json:"credencial,omitempty"`
+}
+
+func (x *PedidoRegistro) Reset() {
+	*x = PedidoRegistro{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dfs_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PedidoRegistro) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PedidoRegistro) ProtoMessage() {}
+
+func (x *PedidoRegistro) ProtoReflect() protoreflect.Message {
+	mi := &file_dfs_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PedidoRegistro.ProtoReflect.Descriptor instead.
+func (*PedidoRegistro) Descriptor() ([]byte, []int) {
+	return file_dfs_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *PedidoRegistro) GetDireccion() string {
+	if x != nil {
+		return x.Direccion
+	}
+	return ""
+}
+
+func (x *PedidoRegistro) GetStorage() string {
+	if x != nil {
+		return x.Storage
+	}
+	return ""
+}
+
+func (x *PedidoRegistro) GetCapacidad() int64 {
+	if x != nil {
+		return x.Capacidad
+	}
+	return 0
+}
+
+func (x *PedidoRegistro) GetCredencial() string {
+	if x != nil {
+		return x.Credencial
+	}
+	return ""
+}
+
+type PedidoHeartbeat struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Direccion  string `protobuf:"bytes,1,opt,name=direccion,proto3" json:"direccion,omitempty"`
+	Capacidad  int64  `protobuf:"varint,2,opt,name=capacidad,proto3" json:"capacidad,omitempty"`
+	Usado      int64  `protobuf:"varint,3,opt,name=usado,proto3" json:"usado,omitempty"`
+	Bloques    int32  `protobuf:"varint,4,opt,name=bloques,proto3" json:"bloques,omitempty"`
+	Credencial string `protobuf:"bytes,5,opt,name=credencial,proto3" json:"credencial,omitempty"`
+}
+
+func (x *PedidoHeartbeat) Reset() {
+	*x = PedidoHeartbeat{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dfs_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PedidoHeartbeat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PedidoHeartbeat) ProtoMessage() {}
+
+func (x *PedidoHeartbeat) ProtoReflect() protoreflect.Message {
+	mi := &file_dfs_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PedidoHeartbeat.ProtoReflect.Descriptor instead.
+func (*PedidoHeartbeat) Descriptor() ([]byte, []int) {
+	return file_dfs_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *PedidoHeartbeat) GetDireccion() string {
+	if x != nil {
+		return x.Direccion
+	}
+	return ""
+}
+
+func (x *PedidoHeartbeat) GetCapacidad() int64 {
+	if x != nil {
+		return x.Capacidad
+	}
+	return 0
+}
+
+func (x *PedidoHeartbeat) GetUsado() int64 {
+	if x != nil {
+		return x.Usado
+	}
+	return 0
+}
+
+func (x *PedidoHeartbeat) GetBloques() int32 {
+	if x != nil {
+		return x.Bloques
+	}
+	return 0
+}
+
+func (x *PedidoHeartbeat) GetCredencial() string {
+	if x != nil {
+		return x.Credencial
+	}
+	return ""
+}
+
+// expira es unix en segundos
+type ClaveDeBloques struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id     int64  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Clave  []byte `protobuf:"bytes,2,opt,name=clave,proto3" json:"clave,omitempty"`
+	Expira int64  `protobuf:"varint,3,opt,name=expira,proto3" json:"expira,omitempty"`
+}
+
+func (x *ClaveDeBloques) Reset() {
+	*x = ClaveDeBloques{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dfs_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ClaveDeBloques) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClaveDeBloques) ProtoMessage() {}
+
+func (x *ClaveDeBloques) ProtoReflect() protoreflect.Message {
+	mi := &file_dfs_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClaveDeBloques.ProtoReflect.Descriptor instead.
+func (*ClaveDeBloques) Descriptor() ([]byte, []int) {
+	return file_dfs_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ClaveDeBloques) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ClaveDeBloques) GetClave() []byte {
+	if x != nil {
+		return x.Clave
+	}
+	return nil
+}
+
+func (x *ClaveDeBloques) GetExpira() int64 {
+	if x != nil {
+		return x.Expira
+	}
+	return 0
+}
+
+// Las claves vigentes para verificar los tokens de acceso a bloques
+type RespuestaClaves struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Claves []*ClaveDeBloques `protobuf:"bytes,1,rep,name=claves,proto3" json:"claves,omitempty"`
+}
+
+func (x *RespuestaClaves) Reset() {
+	*x = RespuestaClaves{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dfs_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RespuestaClaves) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RespuestaClaves) ProtoMessage() {}
+
+func (x *RespuestaClaves) ProtoReflect() protoreflect.Message {
+	mi := &file_dfs_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RespuestaClaves.ProtoReflect.Descriptor instead.
+func (*RespuestaClaves) Descriptor() ([]byte, []int) {
+	return file_dfs_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *RespuestaClaves) GetClaves() []*ClaveDeBloques {
+	if x != nil {
+		return x.Claves
+	}
+	return nil
+}
+
+type PedidoReporteCompleto struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Direccion  string   `protobuf:"bytes,1,opt,name=direccion,proto3" json:"direccion,omitempty"`
+	Bloques    []string `protobuf:"bytes,2,rep,name=bloques,proto3" json:"bloques,omitempty"`
+	Credencial string   `protobuf:"bytes,3,opt,name=credencial,proto3" json:"credencial,omitempty"`
+}
+
+func (x *PedidoReporteCompleto) Reset() {
+	*x = PedidoReporteCompleto{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dfs_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PedidoReporteCompleto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PedidoReporteCompleto) ProtoMessage() {}
+
+func (x *PedidoReporteCompleto) ProtoReflect() protoreflect.Message {
+	mi := &file_dfs_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PedidoReporteCompleto.ProtoReflect.Descriptor instead.
+func (*PedidoReporteCompleto) Descriptor() ([]byte, []int) {
+	return file_dfs_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *PedidoReporteCompleto) GetDireccion() string {
+	if x != nil {
+		return x.Direccion
+	}
+	return ""
+}
+
+func (x *PedidoReporteCompleto) GetBloques() []string {
+	if x != nil {
+		return x.Bloques
+	}
+	return nil
+}
+
+func (x *PedidoReporteCompleto) GetCredencial() string {
+	if x != nil {
+		return x.Credencial
+	}
+	return ""
+}
+
+type PedidoReporteIncremental struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Direccion  string `protobuf:"bytes,1,opt,name=direccion,proto3" json:"direccion,omitempty"`
+	Bloque     string `protobuf:"bytes,2,opt,name=bloque,proto3" json:"bloque,omitempty"`
+	Credencial string `protobuf:"bytes,3,opt,name=credencial,proto3" json:"credencial,omitempty"`
+}
+
+func (x *PedidoReporteIncremental) Reset() {
+	*x = PedidoReporteIncremental{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dfs_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PedidoReporteIncremental) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PedidoReporteIncremental) ProtoMessage() {}
+
+func (x *PedidoReporteIncremental) ProtoReflect() protoreflect.Message {
+	mi := &file_dfs_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PedidoReporteIncremental.ProtoReflect.Descriptor instead.
+func (*PedidoReporteIncremental) Descriptor() ([]byte, []int) {
+	return file_dfs_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *PedidoReporteIncremental) GetDireccion() string {
+	if x != nil {
+		return x.Direccion
+	}
+	return ""
+}
+
+func (x *PedidoReporteIncremental) GetBloque() string {
+	if x != nil {
+		return x.Bloque
+	}
+	return ""
+}
+
+func (x *PedidoReporteIncremental) GetCredencial() string {
+	if x != nil {
+		return x.Credencial
+	}
+	return ""
+}
+
+type PedidoStore struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Bloque     string   `protobuf:"bytes,1,opt,name=bloque,proto3" json:"bloque,omitempty"`
+	Size       int64    `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	Siguientes []string `protobuf:"bytes,3,rep,name=siguientes,proto3" json:"siguientes,omitempty"`
+	Token      string   `protobuf:"bytes,4,opt,name=token,proto3" json:"token,omitempty"`
+}
+
+func (x *PedidoStore) Reset() {
+	*x = PedidoStore{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dfs_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PedidoStore) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PedidoStore) ProtoMessage() {}
+
+func (x *PedidoStore) ProtoReflect() protoreflect.Message {
+	mi := &file_dfs_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PedidoStore.ProtoReflect.Descriptor instead.
+func (*PedidoStore) Descriptor() ([]byte, []int) {
+	return file_dfs_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *PedidoStore) GetBloque() string {
+	if x != nil {
+		return x.Bloque
+	}
+	return ""
+}
+
+func (x *PedidoStore) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *PedidoStore) GetSiguientes() []string {
+	if x != nil {
+		return x.Siguientes
+	}
+	return nil
+}
+
+func (x *PedidoStore) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type FragmentoStore struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pedido *PedidoStore `protobuf:"bytes,1,opt,name=pedido,proto3" json:"pedido,omitempty"` // solo en el primero
+	Datos  []byte       `protobuf:"bytes,2,opt,name=datos,proto3" json:"datos,omitempty"`
+}
+
+func (x *FragmentoStore) Reset() {
+	*x = FragmentoStore{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dfs_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FragmentoStore) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FragmentoStore) ProtoMessage() {}
+
+func (x *FragmentoStore) ProtoReflect() protoreflect.Message {
+	mi := &file_dfs_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FragmentoStore.ProtoReflect.Descriptor instead.
+func (*FragmentoStore) Descriptor() ([]byte, []int) {
+	return file_dfs_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *FragmentoStore) GetPedido() *PedidoStore {
+	if x != nil {
+		return x.Pedido
+	}
+	return nil
+}
+
+func (x *FragmentoStore) GetDatos() []byte {
+	if x != nil {
+		return x.Datos
+	}
+	return nil
+}
+
+// Las réplicas del pipeline que guardaron el bloque, empezando por la que contesta
+type RespuestaStore struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Durables []string `protobuf:"bytes,1,rep,name=durables,proto3" json:"durables,omitempty"`
+}
+
+func (x *RespuestaStore) Reset() {
+	*x = RespuestaStore{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dfs_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RespuestaStore) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RespuestaStore) ProtoMessage() {}
+
+func (x *RespuestaStore) ProtoReflect() protoreflect.Message {
+	mi := &file_dfs_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RespuestaStore.ProtoReflect.Descriptor instead.
+func (*RespuestaStore) Descriptor() ([]byte, []int) {
+	return file_dfs_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *RespuestaStore) GetDurables() []string {
+	if x != nil {
+		return x.Durables
+	}
+	return nil
+}
+
+// Con largo negativo, hasta el final del bloque
+type PedidoRead struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Bloque string `protobuf:"bytes,1,opt,name=bloque,proto3" json:"bloque,omitempty"`
+	Offset int64  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	Largo  int64  `protobuf:"varint,3,opt,name=largo,proto3" json:"largo,omitempty"`
+	Token  string `protobuf:"bytes,4,opt,name=token,proto3" json:"token,omitempty"`
+}
+
+func (x *PedidoRead) Reset() {
+	*x = PedidoRead{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dfs_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PedidoRead) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PedidoRead) ProtoMessage() {}
+
+func (x *PedidoRead) ProtoReflect() protoreflect.Message {
+	mi := &file_dfs_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PedidoRead.ProtoReflect.Descriptor instead.
+func (*PedidoRead) Descriptor() ([]byte, []int) {
+	return file_dfs_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *PedidoRead) GetBloque() string {
+	if x != nil {
+		return x.Bloque
+	}
+	return ""
+}
+
+func (x *PedidoRead) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *PedidoRead) GetLargo() int64 {
+	if x != nil {
+		return x.Largo
+	}
+	return 0
+}
+
+func (x *PedidoRead) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type RespuestaRead struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Inicio int64 `protobuf:"varint,1,opt,name=inicio,proto3" json:"inicio,omitempty"`
+	Largo  int64 `protobuf:"varint,2,opt,name=largo,proto3" json:"largo,omitempty"`
+}
+
+func (x *RespuestaRead) Reset() {
+	*x = RespuestaRead{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dfs_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RespuestaRead) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RespuestaRead) ProtoMessage() {}
+
+func (x *RespuestaRead) ProtoReflect() protoreflect.Message {
+	mi := &file_dfs_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RespuestaRead.ProtoReflect.Descriptor instead.
+func (*RespuestaRead) Descriptor() ([]byte, []int) {
+	return file_dfs_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *RespuestaRead) GetInicio() int64 {
+	if x != nil {
+		return x.Inicio
+	}
+	return 0
+}
+
+func (x *RespuestaRead) GetLargo() int64 {
+	if x != nil {
+		return x.Largo
+	}
+	return 0
+}
+
+type FragmentoRead struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Respuesta *RespuestaRead `protobuf:"bytes,1,opt,name=respuesta,proto3" json:"respuesta,omitempty"` // solo en el primero
+	Datos     []byte         `protobuf:"bytes,2,opt,name=datos,proto3" json:"datos,omitempty"`
+}
+
+func (x *FragmentoRead) Reset() {
+	*x = FragmentoRead{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dfs_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FragmentoRead) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FragmentoRead) ProtoMessage() {}
+
+func (x *FragmentoRead) ProtoReflect() protoreflect.Message {
+	mi := &file_dfs_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FragmentoRead.ProtoReflect.Descriptor instead.
+func (*FragmentoRead) Descriptor() ([]byte, []int) {
+	return file_dfs_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *FragmentoRead) GetRespuesta() *RespuestaRead {
+	if x != nil {
+		return x.Respuesta
+	}
+	return nil
+}
+
+func (x *FragmentoRead) GetDatos() []byte {
+	if x != nil {
+		return x.Datos
+	}
+	return nil
+}
+
+type PedidoBloque struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Bloque string `protobuf:"bytes,1,opt,name=bloque,proto3" json:"bloque,omitempty"`
+	Token  string `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+}
+
+func (x *PedidoBloque) Reset() {
+	*x = PedidoBloque{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dfs_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PedidoBloque) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PedidoBloque) ProtoMessage() {}
+
+func (x *PedidoBloque) ProtoReflect() protoreflect.Message {
+	mi := &file_dfs_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PedidoBloque.ProtoReflect.Descriptor instead.
+func (*PedidoBloque) Descriptor() ([]byte, []int) {
+	return file_dfs_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *PedidoBloque) GetBloque() string {
+	if x != nil {
+		return x.Bloque
+	}
+	return ""
+}
+
+func (x *PedidoBloque) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+// token es para leer bloque y token_destino para guardar la copia como bloque_destino
+type PedidoReplicate struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Bloque        string `protobuf:"bytes,1,opt,name=bloque,proto3" json:"bloque,omitempty"`
+	Destino       string `protobuf:"bytes,2,opt,name=destino,proto3" json:"destino,omitempty"`
+	BloqueDestino string `protobuf:"bytes,3,opt,name=bloque_destino,json=bloqueDestino,proto3" json:"bloque_destino,omitempty"`
+	Token         string `protobuf:"bytes,4,opt,name=token,proto3" json:"token,omitempty"`
+	TokenDestino  string `protobuf:"bytes,5,opt,name=token_destino,json=tokenDestino,proto3" json:"token_destino,omitempty"`
+}
+
+func (x *PedidoReplicate) Reset() {
+	*x = PedidoReplicate{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dfs_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PedidoReplicate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PedidoReplicate) ProtoMessage() {}
+
+func (x *PedidoReplicate) ProtoReflect() protoreflect.Message {
+	mi := &file_dfs_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PedidoReplicate.ProtoReflect.Descriptor instead.
+func (*PedidoReplicate) Descriptor() ([]byte, []int) {
+	return file_dfs_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *PedidoReplicate) GetBloque() string {
+	if x != nil {
+		return x.Bloque
+	}
+	return ""
+}
+
+func (x *PedidoReplicate) GetDestino() string {
+	if x != nil {
+		return x.Destino
+	}
+	return ""
+}
+
+func (x *PedidoReplicate) GetBloqueDestino() string {
+	if x != nil {
+		return x.BloqueDestino
+	}
+	return ""
+}
+
+func (x *PedidoReplicate) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *PedidoReplicate) GetTokenDestino() string {
+	if x != nil {
+		return x.TokenDestino
+	}
+	return ""
+}
+
+var File_dfs_proto protoreflect.FileDescriptor
+
+var file_dfs_proto_rawDesc = []byte{
+	0x0a, 0x09, 0x64, 0x66, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x06, 0x64, 0x66, 0x73,
+	0x2e, 0x76, 0x31, 0x22, 0x07, 0x0a, 0x05, 0x56, 0x61, 0x63, 0x69, 0x6f, 0x22, 0x20, 0x0a, 0x0a,
+	0x50, 0x65, 0x64, 0x69, 0x64, 0x6f, 0x52, 0x75, 0x74, 0x61, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x75,
+	0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x75, 0x74, 0x61, 0x22, 0x8d,
+	0x01, 0x0a, 0x09, 0x50, 0x65, 0x64, 0x69, 0x64, 0x6f, 0x50, 0x75, 0x74, 0x12, 0x12, 0x0a, 0x04,
+	0x72, 0x75, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x75, 0x74, 0x61,
+	0x12, 0x18, 0x0a, 0x07, 0x62, 0x6c, 0x6f, 0x71, 0x75, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x07, 0x62, 0x6c, 0x6f, 0x71, 0x75, 0x65, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x72, 0x65,
+	0x70, 0x6c, 0x69, 0x63, 0x61, 0x63, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x0b, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x63, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04,
+	0x73, 0x69, 0x7a, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65,
+	0x12, 0x1c, 0x0a, 0x09, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x09, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x69, 0x7a, 0x65, 0x22, 0x62,
+	0x0a, 0x0c, 0x50, 0x65, 0x64, 0x69, 0x64, 0x6f, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x12, 0x12,
+	0x0a, 0x04, 0x72, 0x75, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x75,
+	0x74, 0x61, 0x12, 0x20, 0x0a, 0x0b, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x63, 0x69, 0x6f,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61,
+	0x63, 0x69, 0x6f, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x69, 0x7a,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x69,
+	0x7a, 0x65, 0x22, 0x4d, 0x0a, 0x0f, 0x52, 0x65, 0x73, 0x70, 0x75, 0x65, 0x73, 0x74, 0x61, 0x43,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x65, 0x73, 0x63, 0x72, 0x69, 0x74, 0x75,
+	0x72, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x65, 0x73, 0x63, 0x72, 0x69, 0x74,
+	0x75, 0x72, 0x61, 0x12, 0x1c, 0x0a, 0x09, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x69, 0x7a, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x69, 0x7a,
+	0x65, 0x22, 0x75, 0x0a, 0x0f, 0x50, 0x65, 0x64, 0x69, 0x64, 0x6f, 0x45, 0x73, 0x63, 0x72, 0x69,
+	0x74, 0x75, 0x72, 0x61, 0x12, 0x1c, 0x0a, 0x09, 0x65, 0x73, 0x63, 0x72, 0x69, 0x74, 0x75, 0x72,
+	0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x65, 0x73, 0x63, 0x72, 0x69, 0x74, 0x75,
+	0x72, 0x61, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x6e, 0x6f, 0x76, 0x61,
+	0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x72, 0x65, 0x6e, 0x6f, 0x76, 0x61, 0x72,
+	0x12, 0x16, 0x0a, 0x06, 0x62, 0x6c, 0x6f, 0x71, 0x75, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x06, 0x62, 0x6c, 0x6f, 0x71, 0x75, 0x65, 0x22, 0x53, 0x0a, 0x0f, 0x50, 0x65, 0x64, 0x69,
+	0x64, 0x6f, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x72,
+	0x75, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x75, 0x74, 0x61, 0x12,
+	0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x61, 0x72, 0x67, 0x6f,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x6c, 0x61, 0x72, 0x67, 0x6f, 0x22, 0x3c, 0x0a,
+	0x08, 0x50, 0x65, 0x64, 0x69, 0x64, 0x6f, 0x52, 0x6d, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x75, 0x74,
+	0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x75, 0x74, 0x61, 0x12, 0x1c, 0x0a,
+	0x09, 0x72, 0x65, 0x63, 0x75, 0x72, 0x73, 0x69, 0x76, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x09, 0x72, 0x65, 0x63, 0x75, 0x72, 0x73, 0x69, 0x76, 0x6f, 0x22, 0x39, 0x0a, 0x0b, 0x50,
+	0x65, 0x64, 0x69, 0x64, 0x6f, 0x4d, 0x6b, 0x64, 0x69, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x75,
+	0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x75, 0x74, 0x61, 0x12, 0x16,
+	0x0a, 0x06, 0x70, 0x61, 0x64, 0x72, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06,
+	0x70, 0x61, 0x64, 0x72, 0x65, 0x73, 0x22, 0x3c, 0x0a, 0x08, 0x50, 0x65, 0x64, 0x69, 0x64, 0x6f,
+	0x4d, 0x76, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x72, 0x69, 0x67, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x6f, 0x72, 0x69, 0x67, 0x65, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x65,
+	0x73, 0x74, 0x69, 0x6e, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x64, 0x65, 0x73,
+	0x74, 0x69, 0x6e, 0x6f, 0x22, 0x44, 0x0a, 0x0c, 0x50, 0x65, 0x64, 0x69, 0x64, 0x6f, 0x53, 0x65,
+	0x74, 0x72, 0x65, 0x70, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x75, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x72, 0x75, 0x74, 0x61, 0x12, 0x20, 0x0a, 0x0b, 0x72, 0x65, 0x70, 0x6c,
+	0x69, 0x63, 0x61, 0x63, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x72,
+	0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x63, 0x69, 0x6f, 0x6e, 0x22, 0x35, 0x0a, 0x0b, 0x50, 0x65,
+	0x64, 0x69, 0x64, 0x6f, 0x43, 0x68, 0x6d, 0x6f, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x75, 0x74,
+	0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x75, 0x74, 0x61, 0x12, 0x12, 0x0a,
+	0x04, 0x6d, 0x6f, 0x64, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x6d, 0x6f, 0x64,
+	0x6f, 0x22, 0x51, 0x0a, 0x0b, 0x50, 0x65, 0x64, 0x69, 0x64, 0x6f, 0x43, 0x68, 0x6f, 0x77, 0x6e,
+	0x12, 0x12, 0x0a, 0x04, 0x72, 0x75, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x72, 0x75, 0x74, 0x61, 0x12, 0x18, 0x0a, 0x07, 0x75, 0x73, 0x75, 0x61, 0x72, 0x69, 0x6f, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x75, 0x73, 0x75, 0x61, 0x72, 0x69, 0x6f, 0x12, 0x14,
+	0x0a, 0x05, 0x67, 0x72, 0x75, 0x70, 0x6f, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x67,
+	0x72, 0x75, 0x70, 0x6f, 0x22, 0x37, 0x0a, 0x0b, 0x50, 0x65, 0x64, 0x69, 0x64, 0x6f, 0x43, 0x75,
+	0x6f, 0x74, 0x61, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x75, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x72, 0x75, 0x74, 0x61, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x75, 0x6f, 0x74, 0x61,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x63, 0x75, 0x6f, 0x74, 0x61, 0x22, 0xb9, 0x01,
+	0x0a, 0x0e, 0x52, 0x65, 0x73, 0x70, 0x75, 0x65, 0x73, 0x74, 0x61, 0x43, 0x6f, 0x75, 0x6e, 0x74,
+	0x12, 0x20, 0x0a, 0x0b, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x69, 0x6f, 0x73, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x69,
+	0x6f, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x61, 0x72, 0x63, 0x68, 0x69, 0x76, 0x6f, 0x73, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x61, 0x72, 0x63, 0x68, 0x69, 0x76, 0x6f, 0x73, 0x12, 0x14,
+	0x0a, 0x05, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x62,
+	0x79, 0x74, 0x65, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x73, 0x70, 0x61, 0x63, 0x69, 0x6f, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x65, 0x73, 0x70, 0x61, 0x63, 0x69, 0x6f, 0x12, 0x14,
+	0x0a, 0x05, 0x63, 0x75, 0x6f, 0x74, 0x61, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x63,
+	0x75, 0x6f, 0x74, 0x61, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x75, 0x6f, 0x74, 0x61, 0x5f, 0x65, 0x73,
+	0x70, 0x61, 0x63, 0x69, 0x6f, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x63, 0x75, 0x6f,
+	0x74, 0x61, 0x45, 0x73, 0x70, 0x61, 0x63, 0x69, 0x6f, 0x22, 0x80, 0x01, 0x0a, 0x06, 0x42, 0x6c,
+	0x6f, 0x71, 0x75, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x6f, 0x6d, 0x62, 0x72, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6e, 0x6f, 0x6d, 0x62, 0x72, 0x65, 0x12, 0x1a, 0x0a, 0x08,
+	0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08,
+	0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73,
+	0x65, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74,
+	0x12, 0x14, 0x0a, 0x05, 0x6c, 0x61, 0x72, 0x67, 0x6f, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x05, 0x6c, 0x61, 0x72, 0x67, 0x6f, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x3c, 0x0a, 0x10,
+	0x52, 0x65, 0x73, 0x70, 0x75, 0x65, 0x73, 0x74, 0x61, 0x42, 0x6c, 0x6f, 0x71, 0x75, 0x65, 0x73,
+	0x12, 0x28, 0x0a, 0x07, 0x62, 0x6c, 0x6f, 0x71, 0x75, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x0e, 0x2e, 0x64, 0x66, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x6c, 0x6f, 0x71, 0x75,
+	0x65, 0x52, 0x07, 0x62, 0x6c, 0x6f, 0x71, 0x75, 0x65, 0x73, 0x22, 0xe1, 0x01, 0x0a, 0x07, 0x45,
+	0x6e, 0x74, 0x72, 0x61, 0x64, 0x61, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x6f, 0x6d, 0x62, 0x72, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6e, 0x6f, 0x6d, 0x62, 0x72, 0x65, 0x12, 0x10,
+	0x0a, 0x03, 0x64, 0x69, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x03, 0x64, 0x69, 0x72,
+	0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04,
+	0x73, 0x69, 0x7a, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x63,
+	0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x72, 0x65, 0x70, 0x6c, 0x69,
+	0x63, 0x61, 0x63, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x6d, 0x74, 0x69, 0x6d, 0x65, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x6d, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09,
+	0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x09, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x75, 0x73,
+	0x75, 0x61, 0x72, 0x69, 0x6f, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x75, 0x73, 0x75,
+	0x61, 0x72, 0x69, 0x6f, 0x12, 0x14, 0x0a, 0x05, 0x67, 0x72, 0x75, 0x70, 0x6f, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x67, 0x72, 0x75, 0x70, 0x6f, 0x12, 0x12, 0x0a, 0x04, 0x6d, 0x6f,
+	0x64, 0x6f, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x6d, 0x6f, 0x64, 0x6f, 0x22, 0x3a,
+	0x0a, 0x0b, 0x52, 0x65, 0x73, 0x70, 0x75, 0x65, 0x73, 0x74, 0x61, 0x4c, 0x73, 0x12, 0x2b, 0x0a,
+	0x08, 0x65, 0x6e, 0x74, 0x72, 0x61, 0x64, 0x61, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x0f, 0x2e, 0x64, 0x66, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6e, 0x74, 0x72, 0x61, 0x64, 0x61,
+	0x52, 0x08, 0x65, 0x6e, 0x74, 0x72, 0x61, 0x64, 0x61, 0x73, 0x22, 0x32, 0x0a, 0x12, 0x52, 0x65,
+	0x73, 0x70, 0x75, 0x65, 0x73, 0x74, 0x61, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x53, 0x69, 0x7a, 0x65,
+	0x12, 0x1c, 0x0a, 0x09, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x09, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x69, 0x7a, 0x65, 0x22, 0x29,
+	0x0a, 0x0f, 0x52, 0x65, 0x73, 0x70, 0x75, 0x65, 0x73, 0x74, 0x61, 0x4c, 0x69, 0x6e, 0x65, 0x61,
+	0x73, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x69, 0x6e, 0x65, 0x61, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x06, 0x6c, 0x69, 0x6e, 0x65, 0x61, 0x73, 0x22, 0x86, 0x01, 0x0a, 0x0e, 0x50, 0x65,
+	0x64, 0x69, 0x64, 0x6f, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x6f, 0x12, 0x1c, 0x0a, 0x09,
+	0x64, 0x69, 0x72, 0x65, 0x63, 0x63, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x63, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x74,
+	0x6f, 0x72, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x74, 0x6f,
+	0x72, 0x61, 0x67, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x61, 0x70, 0x61, 0x63, 0x69, 0x64, 0x61,
+	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x63, 0x61, 0x70, 0x61, 0x63, 0x69, 0x64,
+	0x61, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x63, 0x69, 0x61, 0x6c,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x63, 0x69,
+	0x61, 0x6c, 0x22, 0x9d, 0x01, 0x0a, 0x0f, 0x50, 0x65, 0x64, 0x69, 0x64, 0x6f, 0x48, 0x65, 0x61,
+	0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x63,
+	0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63,
+	0x63, 0x69, 0x6f, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x61, 0x70, 0x61, 0x63, 0x69, 0x64, 0x61,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x63, 0x61, 0x70, 0x61, 0x63, 0x69, 0x64,
+	0x61, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x75, 0x73, 0x61, 0x64, 0x6f, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x05, 0x75, 0x73, 0x61, 0x64, 0x6f, 0x12, 0x18, 0x0a, 0x07, 0x62, 0x6c, 0x6f, 0x71,
+	0x75, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x62, 0x6c, 0x6f, 0x71, 0x75,
+	0x65, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x63, 0x69, 0x61, 0x6c,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x63, 0x69,
+	0x61, 0x6c, 0x22, 0x4e, 0x0a, 0x0e, 0x43, 0x6c, 0x61, 0x76, 0x65, 0x44, 0x65, 0x42, 0x6c, 0x6f,
+	0x71, 0x75, 0x65, 0x73, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6c, 0x61, 0x76, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x05, 0x63, 0x6c, 0x61, 0x76, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x78,
+	0x70, 0x69, 0x72, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x65, 0x78, 0x70, 0x69,
+	0x72, 0x61, 0x22, 0x41, 0x0a, 0x0f, 0x52, 0x65, 0x73, 0x70, 0x75, 0x65, 0x73, 0x74, 0x61, 0x43,
+	0x6c, 0x61, 0x76, 0x65, 0x73, 0x12, 0x2e, 0x0a, 0x06, 0x63, 0x6c, 0x61, 0x76, 0x65, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x64, 0x66, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43,
+	0x6c, 0x61, 0x76, 0x65, 0x44, 0x65, 0x42, 0x6c, 0x6f, 0x71, 0x75, 0x65, 0x73, 0x52, 0x06, 0x63,
+	0x6c, 0x61, 0x76, 0x65, 0x73, 0x22, 0x6f, 0x0a, 0x15, 0x50, 0x65, 0x64, 0x69, 0x64, 0x6f, 0x52,
+	0x65, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x6f, 0x12, 0x1c,
+	0x0a, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x63, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x63, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07,
+	0x62, 0x6c, 0x6f, 0x71, 0x75, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x62,
+	0x6c, 0x6f, 0x71, 0x75, 0x65, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e,
+	0x63, 0x69, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x72, 0x65, 0x64,
+	0x65, 0x6e, 0x63, 0x69, 0x61, 0x6c, 0x22, 0x70, 0x0a, 0x18, 0x50, 0x65, 0x64, 0x69, 0x64, 0x6f,
+	0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x49, 0x6e, 0x63, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74,
+	0x61, 0x6c, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x63, 0x69, 0x6f, 0x6e, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x63, 0x69, 0x6f, 0x6e,
+	0x12, 0x16, 0x0a, 0x06, 0x62, 0x6c, 0x6f, 0x71, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x62, 0x6c, 0x6f, 0x71, 0x75, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x64,
+	0x65, 0x6e, 0x63, 0x69, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x72,
+	0x65, 0x64, 0x65, 0x6e, 0x63, 0x69, 0x61, 0x6c, 0x22, 0x6f, 0x0a, 0x0b, 0x50, 0x65, 0x64, 0x69,
+	0x64, 0x6f, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x6c, 0x6f, 0x71, 0x75,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x62, 0x6c, 0x6f, 0x71, 0x75, 0x65, 0x12,
+	0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x73,
+	0x69, 0x7a, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x69, 0x67, 0x75, 0x69, 0x65, 0x6e, 0x74, 0x65,
+	0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x69, 0x67, 0x75, 0x69, 0x65, 0x6e,
+	0x74, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x53, 0x0a, 0x0e, 0x46, 0x72, 0x61,
+	0x67, 0x6d, 0x65, 0x6e, 0x74, 0x6f, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x12, 0x2b, 0x0a, 0x06, 0x70,
+	0x65, 0x64, 0x69, 0x64, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x64, 0x66,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65, 0x64, 0x69, 0x64, 0x6f, 0x53, 0x74, 0x6f, 0x72, 0x65,
+	0x52, 0x06, 0x70, 0x65, 0x64, 0x69, 0x64, 0x6f, 0x12, 0x14, 0x0a, 0x05, 0x64, 0x61, 0x74, 0x6f,
+	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x64, 0x61, 0x74, 0x6f, 0x73, 0x22, 0x2c,
+	0x0a, 0x0e, 0x52, 0x65, 0x73, 0x70, 0x75, 0x65, 0x73, 0x74, 0x61, 0x53, 0x74, 0x6f, 0x72, 0x65,
+	0x12, 0x1a, 0x0a, 0x08, 0x64, 0x75, 0x72, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x08, 0x64, 0x75, 0x72, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x22, 0x68, 0x0a, 0x0a,
+	0x50, 0x65, 0x64, 0x69, 0x64, 0x6f, 0x52, 0x65, 0x61, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x6c,
+	0x6f, 0x71, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x62, 0x6c, 0x6f, 0x71,
+	0x75, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x61,
+	0x72, 0x67, 0x6f, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x6c, 0x61, 0x72, 0x67, 0x6f,
+	0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x3d, 0x0a, 0x0d, 0x52, 0x65, 0x73, 0x70, 0x75, 0x65,
+	0x73, 0x74, 0x61, 0x52, 0x65, 0x61, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x69, 0x6e, 0x69, 0x63, 0x69,
+	0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x69, 0x6e, 0x69, 0x63, 0x69, 0x6f, 0x12,
+	0x14, 0x0a, 0x05, 0x6c, 0x61, 0x72, 0x67, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05,
+	0x6c, 0x61, 0x72, 0x67, 0x6f, 0x22, 0x5a, 0x0a, 0x0d, 0x46, 0x72, 0x61, 0x67, 0x6d, 0x65, 0x6e,
+	0x74, 0x6f, 0x52, 0x65, 0x61, 0x64, 0x12, 0x33, 0x0a, 0x09, 0x72, 0x65, 0x73, 0x70, 0x75, 0x65,
+	0x73, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x64, 0x66, 0x73, 0x2e,
+	0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x75, 0x65, 0x73, 0x74, 0x61, 0x52, 0x65, 0x61, 0x64,
+	0x52, 0x09, 0x72, 0x65, 0x73, 0x70, 0x75, 0x65, 0x73, 0x74, 0x61, 0x12, 0x14, 0x0a, 0x05, 0x64,
+	0x61, 0x74, 0x6f, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x64, 0x61, 0x74, 0x6f,
+	0x73, 0x22, 0x3c, 0x0a, 0x0c, 0x50, 0x65, 0x64, 0x69, 0x64, 0x6f, 0x42, 0x6c, 0x6f, 0x71, 0x75,
+	0x65, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x6c, 0x6f, 0x71, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x62, 0x6c, 0x6f, 0x71, 0x75, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b,
+	0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x22,
+	0xa5, 0x01, 0x0a, 0x0f, 0x50, 0x65, 0x64, 0x69, 0x64, 0x6f, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63,
+	0x61, 0x74, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x6c, 0x6f, 0x71, 0x75, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x62, 0x6c, 0x6f, 0x71, 0x75, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x64,
+	0x65, 0x73, 0x74, 0x69, 0x6e, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x64, 0x65,
+	0x73, 0x74, 0x69, 0x6e, 0x6f, 0x12, 0x25, 0x0a, 0x0e, 0x62, 0x6c, 0x6f, 0x71, 0x75, 0x65, 0x5f,
+	0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x6f, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x62,
+	0x6c, 0x6f, 0x71, 0x75, 0x65, 0x44, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x6f, 0x12, 0x14, 0x0a, 0x05,
+	0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b,
+	0x65, 0x6e, 0x12, 0x23, 0x0a, 0x0d, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x64, 0x65, 0x73, 0x74,
+	0x69, 0x6e, 0x6f, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
+	0x44, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x6f, 0x32, 0xd9, 0x0b, 0x0a, 0x08, 0x4e, 0x61, 0x6d, 0x65,
+	0x6e, 0x6f, 0x64, 0x65, 0x12, 0x32, 0x0a, 0x03, 0x50, 0x75, 0x74, 0x12, 0x11, 0x2e, 0x64, 0x66,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65, 0x64, 0x69, 0x64, 0x6f, 0x50, 0x75, 0x74, 0x1a, 0x18,
+	0x2e, 0x64, 0x66, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x75, 0x65, 0x73, 0x74,
+	0x61, 0x42, 0x6c, 0x6f, 0x71, 0x75, 0x65, 0x73, 0x12, 0x37, 0x0a, 0x06, 0x43, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x12, 0x14, 0x2e, 0x64, 0x66, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65, 0x64, 0x69,
+	0x64, 0x6f, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x1a, 0x17, 0x2e, 0x64, 0x66, 0x73, 0x2e, 0x76,
+	0x31, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x75, 0x65, 0x73, 0x74, 0x61, 0x43, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x12, 0x3d, 0x0a, 0x08, 0x41, 0x64, 0x64, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x17, 0x2e,
+	0x64, 0x66, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65, 0x64, 0x69, 0x64, 0x6f, 0x45, 0x73, 0x63,
+	0x72, 0x69, 0x74, 0x75, 0x72, 0x61, 0x1a, 0x18, 0x2e, 0x64, 0x66, 0x73, 0x2e, 0x76, 0x31, 0x2e,
+	0x52, 0x65, 0x73, 0x70, 0x75, 0x65, 0x73, 0x74, 0x61, 0x42, 0x6c, 0x6f, 0x71, 0x75, 0x65, 0x73,
+	0x12, 0x32, 0x0a, 0x08, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x17, 0x2e, 0x64,
+	0x66, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65, 0x64, 0x69, 0x64, 0x6f, 0x45, 0x73, 0x63, 0x72,
+	0x69, 0x74, 0x75, 0x72, 0x61, 0x1a, 0x0d, 0x2e, 0x64, 0x66, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x56,
+	0x61, 0x63, 0x69, 0x6f, 0x12, 0x31, 0x0a, 0x07, 0x41, 0x62, 0x61, 0x6e, 0x64, 0x6f, 0x6e, 0x12,
+	0x17, 0x2e, 0x64, 0x66, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65, 0x64, 0x69, 0x64, 0x6f, 0x45,
+	0x73, 0x63, 0x72, 0x69, 0x74, 0x75, 0x72, 0x61, 0x1a, 0x0d, 0x2e, 0x64, 0x66, 0x73, 0x2e, 0x76,
+	0x31, 0x2e, 0x56, 0x61, 0x63, 0x69, 0x6f, 0x12, 0x33, 0x0a, 0x03, 0x47, 0x65, 0x74, 0x12, 0x12,
+	0x2e, 0x64, 0x66, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65, 0x64, 0x69, 0x64, 0x6f, 0x52, 0x75,
+	0x74, 0x61, 0x1a, 0x18, 0x2e, 0x64, 0x66, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x70,
+	0x75, 0x65, 0x73, 0x74, 0x61, 0x42, 0x6c, 0x6f, 0x71, 0x75, 0x65, 0x73, 0x12, 0x3e, 0x0a, 0x09,
+	0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x17, 0x2e, 0x64, 0x66, 0x73, 0x2e,
+	0x76, 0x31, 0x2e, 0x50, 0x65, 0x64, 0x69, 0x64, 0x6f, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x1a, 0x18, 0x2e, 0x64, 0x66, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x70,
+	0x75, 0x65, 0x73, 0x74, 0x61, 0x42, 0x6c, 0x6f, 0x71, 0x75, 0x65, 0x73, 0x12, 0x2b, 0x0a, 0x04,
+	0x53, 0x74, 0x61, 0x74, 0x12, 0x12, 0x2e, 0x64, 0x66, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65,
+	0x64, 0x69, 0x64, 0x6f, 0x52, 0x75, 0x74, 0x61, 0x1a, 0x0f, 0x2e, 0x64, 0x66, 0x73, 0x2e, 0x76,
+	0x31, 0x2e, 0x45, 0x6e, 0x74, 0x72, 0x61, 0x64, 0x61, 0x12, 0x2d, 0x0a, 0x02, 0x4c, 0x73, 0x12,
+	0x12, 0x2e, 0x64, 0x66, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65, 0x64, 0x69, 0x64, 0x6f, 0x52,
+	0x75, 0x74, 0x61, 0x1a, 0x13, 0x2e, 0x64, 0x66, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73,
+	0x70, 0x75, 0x65, 0x73, 0x74, 0x61, 0x4c, 0x73, 0x12, 0x30, 0x0a, 0x02, 0x52, 0x6d, 0x12, 0x10,
+	0x2e, 0x64, 0x66, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65, 0x64, 0x69, 0x64, 0x6f, 0x52, 0x6d,
+	0x1a, 0x18, 0x2e, 0x64, 0x66, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x75, 0x65,
+	0x73, 0x74, 0x61, 0x42, 0x6c, 0x6f, 0x71, 0x75, 0x65, 0x73, 0x12, 0x2b, 0x0a, 0x05, 0x4d, 0x6b,
+	0x64, 0x69, 0x72, 0x12, 0x13, 0x2e, 0x64, 0x66, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65, 0x64,
+	0x69, 0x64, 0x6f, 0x4d, 0x6b, 0x64, 0x69, 0x72, 0x1a, 0x0d, 0x2e, 0x64, 0x66, 0x73, 0x2e, 0x76,
+	0x31, 0x2e, 0x56, 0x61, 0x63, 0x69, 0x6f, 0x12, 0x2a, 0x0a, 0x05, 0x52, 0x6d, 0x64, 0x69, 0x72,
+	0x12, 0x12, 0x2e, 0x64, 0x66, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65, 0x64, 0x69, 0x64, 0x6f,
+	0x52, 0x75, 0x74, 0x61, 0x1a, 0x0d, 0x2e, 0x64, 0x66, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x61,
+	0x63, 0x69, 0x6f, 0x12, 0x25, 0x0a, 0x02, 0x4d, 0x76, 0x12, 0x10, 0x2e, 0x64, 0x66, 0x73, 0x2e,
+	0x76, 0x31, 0x2e, 0x50, 0x65, 0x64, 0x69, 0x64, 0x6f, 0x4d, 0x76, 0x1a, 0x0d, 0x2e, 0x64, 0x66,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x61, 0x63, 0x69, 0x6f, 0x12, 0x2d, 0x0a, 0x06, 0x53, 0x65,
+	0x74, 0x72, 0x65, 0x70, 0x12, 0x14, 0x2e, 0x64, 0x66, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65,
+	0x64, 0x69, 0x64, 0x6f, 0x53, 0x65, 0x74, 0x72, 0x65, 0x70, 0x1a, 0x0d, 0x2e, 0x64, 0x66, 0x73,
+	0x2e, 0x76, 0x31, 0x2e, 0x56, 0x61, 0x63, 0x69, 0x6f, 0x12, 0x2b, 0x0a, 0x05, 0x43, 0x68, 0x6d,
+	0x6f, 0x64, 0x12, 0x13, 0x2e, 0x64, 0x66, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65, 0x64, 0x69,
+	0x64, 0x6f, 0x43, 0x68, 0x6d, 0x6f, 0x64, 0x1a, 0x0d, 0x2e, 0x64, 0x66, 0x73, 0x2e, 0x76, 0x31,
+	0x2e, 0x56, 0x61, 0x63, 0x69, 0x6f, 0x12, 0x2b, 0x0a, 0x05, 0x43, 0x68, 0x6f, 0x77, 0x6e, 0x12,
+	0x13, 0x2e, 0x64, 0x66, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65, 0x64, 0x69, 0x64, 0x6f, 0x43,
+	0x68, 0x6f, 0x77, 0x6e, 0x1a, 0x0d, 0x2e, 0x64, 0x66, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x61,
+	0x63, 0x69, 0x6f, 0x12, 0x2e, 0x0a, 0x08, 0x53, 0x65, 0x74, 0x51, 0x75, 0x6f, 0x74, 0x61, 0x12,
+	0x13, 0x2e, 0x64, 0x66, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65, 0x64, 0x69, 0x64, 0x6f, 0x43,
+	0x75, 0x6f, 0x74, 0x61, 0x1a, 0x0d, 0x2e, 0x64, 0x66, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x61,
+	0x63, 0x69, 0x6f, 0x12, 0x33, 0x0a, 0x0d, 0x53, 0x65, 0x74, 0x53, 0x70, 0x61, 0x63, 0x65, 0x51,
+	0x75, 0x6f, 0x74, 0x61, 0x12, 0x13, 0x2e, 0x64, 0x66, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65,
+	0x64, 0x69, 0x64, 0x6f, 0x43, 0x75, 0x6f, 0x74, 0x61, 0x1a, 0x0d, 0x2e, 0x64, 0x66, 0x73, 0x2e,
+	0x76, 0x31, 0x2e, 0x56, 0x61, 0x63, 0x69, 0x6f, 0x12, 0x33, 0x0a, 0x05, 0x43, 0x6f, 0x75, 0x6e,
+	0x74, 0x12, 0x12, 0x2e, 0x64, 0x66, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65, 0x64, 0x69, 0x64,
+	0x6f, 0x52, 0x75, 0x74, 0x61, 0x1a, 0x16, 0x2e, 0x64, 0x66, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52,
+	0x65, 0x73, 0x70, 0x75, 0x65, 0x73, 0x74, 0x61, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x36, 0x0a,
+	0x09, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x0d, 0x2e, 0x64, 0x66, 0x73,
+	0x2e, 0x76, 0x31, 0x2e, 0x56, 0x61, 0x63, 0x69, 0x6f, 0x1a, 0x1a, 0x2e, 0x64, 0x66, 0x73, 0x2e,
+	0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x75, 0x65, 0x73, 0x74, 0x61, 0x42, 0x6c, 0x6f, 0x63,
+	0x6b, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x2f, 0x0a, 0x05, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x0d,
+	0x2e, 0x64, 0x66, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x61, 0x63, 0x69, 0x6f, 0x1a, 0x17, 0x2e,
+	0x64, 0x66, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x75, 0x65, 0x73, 0x74, 0x61,
+	0x4c, 0x69, 0x6e, 0x65, 0x61, 0x73, 0x12, 0x2e, 0x0a, 0x04, 0x46, 0x73, 0x63, 0x6b, 0x12, 0x0d,
+	0x2e, 0x64, 0x66, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x61, 0x63, 0x69, 0x6f, 0x1a, 0x17, 0x2e,
+	0x64, 0x66, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x75, 0x65, 0x73, 0x74, 0x61,
+	0x4c, 0x69, 0x6e, 0x65, 0x61, 0x73, 0x12, 0x3b, 0x0a, 0x08, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74,
+	0x65, 0x72, 0x12, 0x16, 0x2e, 0x64, 0x66, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65, 0x64, 0x69,
+	0x64, 0x6f, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x6f, 0x1a, 0x17, 0x2e, 0x64, 0x66, 0x73,
+	0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x75, 0x65, 0x73, 0x74, 0x61, 0x43, 0x6c, 0x61,
+	0x76, 0x65, 0x73, 0x12, 0x3d, 0x0a, 0x09, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74,
+	0x12, 0x17, 0x2e, 0x64, 0x66, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65, 0x64, 0x69, 0x64, 0x6f,
+	0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x1a, 0x17, 0x2e, 0x64, 0x66, 0x73, 0x2e,
+	0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x75, 0x65, 0x73, 0x74, 0x61, 0x43, 0x6c, 0x61, 0x76,
+	0x65, 0x73, 0x12, 0x3b, 0x0a, 0x0b, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x70, 0x6f, 0x72,
+	0x74, 0x12, 0x1d, 0x2e, 0x64, 0x66, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65, 0x64, 0x69, 0x64,
+	0x6f, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x6f,
+	0x1a, 0x0d, 0x2e, 0x64, 0x66, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x61, 0x63, 0x69, 0x6f, 0x12,
+	0x40, 0x0a, 0x0d, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64,
+	0x12, 0x20, 0x2e, 0x64, 0x66, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65, 0x64, 0x69, 0x64, 0x6f,
+	0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x49, 0x6e, 0x63, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74,
+	0x61, 0x6c, 0x1a, 0x0d, 0x2e, 0x64, 0x66, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x61, 0x63, 0x69,
+	0x6f, 0x12, 0x3f, 0x0a, 0x0c, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x64, 0x12, 0x20, 0x2e, 0x64, 0x66, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65, 0x64, 0x69, 0x64,
+	0x6f, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x49, 0x6e, 0x63, 0x72, 0x65, 0x6d, 0x65, 0x6e,
+	0x74, 0x61, 0x6c, 0x1a, 0x0d, 0x2e, 0x64, 0x66, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x61, 0x63,
+	0x69, 0x6f, 0x12, 0x3f, 0x0a, 0x0c, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x43, 0x6f, 0x72, 0x72, 0x75,
+	0x70, 0x74, 0x12, 0x20, 0x2e, 0x64, 0x66, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65, 0x64, 0x69,
+	0x64, 0x6f, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x49, 0x6e, 0x63, 0x72, 0x65, 0x6d, 0x65,
+	0x6e, 0x74, 0x61, 0x6c, 0x1a, 0x0d, 0x2e, 0x64, 0x66, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x61,
+	0x63, 0x69, 0x6f, 0x32, 0xda, 0x01, 0x0a, 0x08, 0x44, 0x61, 0x74, 0x61, 0x4e, 0x6f, 0x64, 0x65,
+	0x12, 0x39, 0x0a, 0x05, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x12, 0x16, 0x2e, 0x64, 0x66, 0x73, 0x2e,
+	0x76, 0x31, 0x2e, 0x46, 0x72, 0x61, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x6f, 0x53, 0x74, 0x6f, 0x72,
+	0x65, 0x1a, 0x16, 0x2e, 0x64, 0x66, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x75,
+	0x65, 0x73, 0x74, 0x61, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x28, 0x01, 0x12, 0x33, 0x0a, 0x04, 0x52,
+	0x65, 0x61, 0x64, 0x12, 0x12, 0x2e, 0x64, 0x66, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65, 0x64,
+	0x69, 0x64, 0x6f, 0x52, 0x65, 0x61, 0x64, 0x1a, 0x15, 0x2e, 0x64, 0x66, 0x73, 0x2e, 0x76, 0x31,
+	0x2e, 0x46, 0x72, 0x61, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x6f, 0x52, 0x65, 0x61, 0x64, 0x30, 0x01,
+	0x12, 0x29, 0x0a, 0x02, 0x52, 0x6d, 0x12, 0x14, 0x2e, 0x64, 0x66, 0x73, 0x2e, 0x76, 0x31, 0x2e,
+	0x50, 0x65, 0x64, 0x69, 0x64, 0x6f, 0x42, 0x6c, 0x6f, 0x71, 0x75, 0x65, 0x1a, 0x0d, 0x2e, 0x64,
+	0x66, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x61, 0x63, 0x69, 0x6f, 0x12, 0x33, 0x0a, 0x09, 0x52,
+	0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x12, 0x17, 0x2e, 0x64, 0x66, 0x73, 0x2e, 0x76,
+	0x31, 0x2e, 0x50, 0x65, 0x64, 0x69, 0x64, 0x6f, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74,
+	0x65, 0x1a, 0x0d, 0x2e, 0x64, 0x66, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x61, 0x63, 0x69, 0x6f,
+	0x42, 0x3f, 0x5a, 0x3d, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x55,
+	0x72, 0x69, 0x4e, 0x6f, 0x48, 0x69, 0x2f, 0x44, 0x69, 0x73, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74,
+	0x65, 0x64, 0x2d, 0x66, 0x69, 0x6c, 0x65, 0x2d, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x2d, 0x44,
+	0x46, 0x53, 0x2d, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x6f, 0x2f, 0x72, 0x70,
+	0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_dfs_proto_rawDescOnce sync.Once
+	file_dfs_proto_rawDescData = file_dfs_proto_rawDesc
+)
+
+func file_dfs_proto_rawDescGZIP() []byte {
+	file_dfs_proto_rawDescOnce.Do(func() {
+		file_dfs_proto_rawDescData = protoimpl.X.CompressGZIP(file_dfs_proto_rawDescData)
+	})
+	return file_dfs_proto_rawDescData
+}
+
+var file_dfs_proto_msgTypes = make([]protoimpl.MessageInfo, 35)
+var file_dfs_proto_goTypes = []any{
+	(*Vacio)(nil),                    // 0: dfs.v1.Vacio
+	(*PedidoRuta)(nil),               // 1: dfs.v1.PedidoRuta
+	(*PedidoPut)(nil),                // 2: dfs.v1.PedidoPut
+	(*PedidoCreate)(nil),             // 3: dfs.v1.PedidoCreate
+	(*RespuestaCreate)(nil),          // 4: dfs.v1.RespuestaCreate
+	(*PedidoEscritura)(nil),          // 5: dfs.v1.PedidoEscritura
+	(*PedidoLocations)(nil),          // 6: dfs.v1.PedidoLocations
+	(*PedidoRm)(nil),                 // 7: dfs.v1.PedidoRm
+	(*PedidoMkdir)(nil),              // 8: dfs.v1.PedidoMkdir
+	(*PedidoMv)(nil),                 // 9: dfs.v1.PedidoMv
+	(*PedidoSetrep)(nil),             // 10: dfs.v1.PedidoSetrep
+	(*PedidoChmod)(nil),              // 11: dfs.v1.PedidoChmod
+	(*PedidoChown)(nil),              // 12: dfs.v1.PedidoChown
+	(*PedidoCuota)(nil),              // 13: dfs.v1.PedidoCuota
+	(*RespuestaCount)(nil),           // 14: dfs.v1.RespuestaCount
+	(*Bloque)(nil),                   // 15: dfs.v1.Bloque
+	(*RespuestaBloques)(nil),         // 16: dfs.v1.RespuestaBloques
+	(*Entrada)(nil),                  // 17: dfs.v1.Entrada
+	(*RespuestaLs)(nil),              // 18: dfs.v1.RespuestaLs
+	(*RespuestaBlockSize)(nil),       // 19: dfs.v1.RespuestaBlockSize
+	(*RespuestaLineas)(nil),          // 20: dfs.v1.RespuestaLineas
+	(*PedidoRegistro)(nil),           // 21: dfs.v1.PedidoRegistro
+	(*PedidoHeartbeat)(nil),          // 22: dfs.v1.PedidoHeartbeat
+	(*ClaveDeBloques)(nil),           // 23: dfs.v1.ClaveDeBloques
+	(*RespuestaClaves)(nil),          // 24: dfs.v1.RespuestaClaves
+	(*PedidoReporteCompleto)(nil),    // 25: dfs.v1.PedidoReporteCompleto
+	(*PedidoReporteIncremental)(nil), // 26: dfs.v1.PedidoReporteIncremental
+	(*PedidoStore)(nil),              // 27: dfs.v1.PedidoStore
+	(*FragmentoStore)(nil),           // 28: dfs.v1.FragmentoStore
+	(*RespuestaStore)(nil),           // 29: dfs.v1.RespuestaStore
+	(*PedidoRead)(nil),               // 30: dfs.v1.PedidoRead
+	(*RespuestaRead)(nil),            // 31: dfs.v1.RespuestaRead
+	(*FragmentoRead)(nil),            // 32: dfs.v1.FragmentoRead
+	(*PedidoBloque)(nil),             // 33: dfs.v1.PedidoBloque
+	(*PedidoReplicate)(nil),          // 34: dfs.v1.PedidoReplicate
+}
+var file_dfs_proto_depIdxs = []int32{
+	15, // 0: dfs.v1.RespuestaBloques.bloques:type_name -> dfs.v1.Bloque
+	17, // 1: dfs.v1.RespuestaLs.entradas:type_name -> dfs.v1.Entrada
+	23, // 2: dfs.v1.RespuestaClaves.claves:type_name -> dfs.v1.ClaveDeBloques
+	27, // 3: dfs.v1.FragmentoStore.pedido:type_name -> dfs.v1.PedidoStore
+	31, // 4: dfs.v1.FragmentoRead.respuesta:type_name -> dfs.v1.RespuestaRead
+	2,  // 5: dfs.v1.Namenode.Put:input_type -> dfs.v1.PedidoPut
+	3,  // 6: dfs.v1.Namenode.Create:input_type -> dfs.v1.PedidoCreate
+	5,  // 7: dfs.v1.Namenode.AddBlock:input_type -> dfs.v1.PedidoEscritura
+	5,  // 8: dfs.v1.Namenode.Complete:input_type -> dfs.v1.PedidoEscritura
+	5,  // 9: dfs.v1.Namenode.Abandon:input_type -> dfs.v1.PedidoEscritura
+	1,  // 10: dfs.v1.Namenode.Get:input_type -> dfs.v1.PedidoRuta
+	6,  // 11: dfs.v1.Namenode.Locations:input_type -> dfs.v1.PedidoLocations
+	1,  // 12: dfs.v1.Namenode.Stat:input_type -> dfs.v1.PedidoRuta
+	1,  // 13: dfs.v1.Namenode.Ls:input_type -> dfs.v1.PedidoRuta
+	7,  // 14: dfs.v1.Namenode.Rm:input_type -> dfs.v1.PedidoRm
+	8,  // 15: dfs.v1.Namenode.Mkdir:input_type -> dfs.v1.PedidoMkdir
+	1,  // 16: dfs.v1.Namenode.Rmdir:input_type -> dfs.v1.PedidoRuta
+	9,  // 17: dfs.v1.Namenode.Mv:input_type -> dfs.v1.PedidoMv
+	10, // 18: dfs.v1.Namenode.Setrep:input_type -> dfs.v1.PedidoSetrep
+	11, // 19: dfs.v1.Namenode.Chmod:input_type -> dfs.v1.PedidoChmod
+	12, // 20: dfs.v1.Namenode.Chown:input_type -> dfs.v1.PedidoChown
+	13, // 21: dfs.v1.Namenode.SetQuota:input_type -> dfs.v1.PedidoCuota
+	13, // 22: dfs.v1.Namenode.SetSpaceQuota:input_type -> dfs.v1.PedidoCuota
+	1,  // 23: dfs.v1.Namenode.Count:input_type -> dfs.v1.PedidoRuta
+	0,  // 24: dfs.v1.Namenode.BlockSize:input_type -> dfs.v1.Vacio
+	0,  // 25: dfs.v1.Namenode.Nodes:input_type -> dfs.v1.Vacio
+	0,  // 26: dfs.v1.Namenode.Fsck:input_type -> dfs.v1.Vacio
+	21, // 27: dfs.v1.Namenode.Register:input_type -> dfs.v1.PedidoRegistro
+	22, // 28: dfs.v1.Namenode.Heartbeat:input_type -> dfs.v1.PedidoHeartbeat
+	25, // 29: dfs.v1.Namenode.BlockReport:input_type -> dfs.v1.PedidoReporteCompleto
+	26, // 30: dfs.v1.Namenode.BlockReceived:input_type -> dfs.v1.PedidoReporteIncremental
+	26, // 31: dfs.v1.Namenode.BlockDeleted:input_type -> dfs.v1.PedidoReporteIncremental
+	26, // 32: dfs.v1.Namenode.BlockCorrupt:input_type -> dfs.v1.PedidoReporteIncremental
+	28, // 33: dfs.v1.DataNode.Store:input_type -> dfs.v1.FragmentoStore
+	30, // 34: dfs.v1.DataNode.Read:input_type -> dfs.v1.PedidoRead
+	33, // 35: dfs.v1.DataNode.Rm:input_type -> dfs.v1.PedidoBloque
+	34, // 36: dfs.v1.DataNode.Replicate:input_type -> dfs.v1.PedidoReplicate
+	16, // 37: dfs.v1.Namenode.Put:output_type -> dfs.v1.RespuestaBloques
+	4,  // 38: dfs.v1.Namenode.Create:output_type -> dfs.v1.RespuestaCreate
+	16, // 39: dfs.v1.Namenode.AddBlock:output_type -> dfs.v1.RespuestaBloques
+	0,  // 40: dfs.v1.Namenode.Complete:output_type -> dfs.v1.Vacio
+	0,  // 41: dfs.v1.Namenode.Abandon:output_type -> dfs.v1.Vacio
+	16, // 42: dfs.v1.Namenode.Get:output_type -> dfs.v1.RespuestaBloques
+	16, // 43: dfs.v1.Namenode.Locations:output_type -> dfs.v1.RespuestaBloques
+	17, // 44: dfs.v1.Namenode.Stat:output_type -> dfs.v1.Entrada
+	18, // 45: dfs.v1.Namenode.Ls:output_type -> dfs.v1.RespuestaLs
+	16, // 46: dfs.v1.Namenode.Rm:output_type -> dfs.v1.RespuestaBloques
+	0,  // 47: dfs.v1.Namenode.Mkdir:output_type -> dfs.v1.Vacio
+	0,  // 48: dfs.v1.Namenode.Rmdir:output_type -> dfs.v1.Vacio
+	0,  // 49: dfs.v1.Namenode.Mv:output_type -> dfs.v1.Vacio
+	0,  // 50: dfs.v1.Namenode.Setrep:output_type -> dfs.v1.Vacio
+	0,  // 51: dfs.v1.Namenode.Chmod:output_type -> dfs.v1.Vacio
+	0,  // 52: dfs.v1.Namenode.Chown:output_type -> dfs.v1.Vacio
+	0,  // 53: dfs.v1.Namenode.SetQuota:output_type -> dfs.v1.Vacio
+	0,  // 54: dfs.v1.Namenode.SetSpaceQuota:output_type -> dfs.v1.Vacio
+	14, // 55: dfs.v1.Namenode.Count:output_type -> dfs.v1.RespuestaCount
+	19, // 56: dfs.v1.Namenode.BlockSize:output_type -> dfs.v1.RespuestaBlockSize
+	20, // 57: dfs.v1.Namenode.Nodes:output_type -> dfs.v1.RespuestaLineas
+	20, // 58: dfs.v1.Namenode.Fsck:output_type -> dfs.v1.RespuestaLineas
+	24, // 59: dfs.v1.Namenode.Register:output_type -> dfs.v1.RespuestaClaves
+	24, // 60: dfs.v1.Namenode.Heartbeat:output_type -> dfs.v1.RespuestaClaves
+	0,  // 61: dfs.v1.Namenode.BlockReport:output_type -> dfs.v1.Vacio
+	0,  // 62: dfs.v1.Namenode.BlockReceived:output_type -> dfs.v1.Vacio
+	0,  // 63: dfs.v1.Namenode.BlockDeleted:output_type -> dfs.v1.Vacio
+	0,  // 64: dfs.v1.Namenode.BlockCorrupt:output_type -> dfs.v1.Vacio
+	29, // 65: dfs.v1.DataNode.Store:output_type -> dfs.v1.RespuestaStore
+	32, // 66: dfs.v1.DataNode.Read:output_type -> dfs.v1.FragmentoRead
+	0,  // 67: dfs.v1.DataNode.Rm:output_type -> dfs.v1.Vacio
+	0,  // 68: dfs.v1.DataNode.Replicate:output_type -> dfs.v1.Vacio
+	37, // [37:69] is the sub-list for method output_type
+	5,  // [5:37] is the sub-list for method input_type
+	5,  // [5:5] is the sub-list for extension type_name
+	5,  // [5:5] is the sub-list for extension extendee
+	0,  // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_dfs_proto_init() }
+func file_dfs_proto_init() {
+	if File_dfs_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_dfs_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*Vacio); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dfs_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*PedidoRuta); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dfs_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*PedidoPut); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dfs_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*PedidoCreate); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dfs_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*RespuestaCreate); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dfs_proto_msgTypes[5].Exporter = func(v any, i int) any {
+			switch v := v.(*PedidoEscritura); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dfs_proto_msgTypes[6].Exporter = func(v any, i int) any {
+			switch v := v.(*PedidoLocations); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dfs_proto_msgTypes[7].Exporter = func(v any, i int) any {
+			switch v := v.(*PedidoRm); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dfs_proto_msgTypes[8].Exporter = func(v any, i int) any {
+			switch v := v.(*PedidoMkdir); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dfs_proto_msgTypes[9].Exporter = func(v any, i int) any {
+			switch v := v.(*PedidoMv); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dfs_proto_msgTypes[10].Exporter = func(v any, i int) any {
+			switch v := v.(*PedidoSetrep); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dfs_proto_msgTypes[11].Exporter = func(v any, i int) any {
+			switch v := v.(*PedidoChmod); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dfs_proto_msgTypes[12].Exporter = func(v any, i int) any {
+			switch v := v.(*PedidoChown); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dfs_proto_msgTypes[13].Exporter = func(v any, i int) any {
+			switch v := v.(*PedidoCuota); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dfs_proto_msgTypes[14].Exporter = func(v any, i int) any {
+			switch v := v.(*RespuestaCount); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dfs_proto_msgTypes[15].Exporter = func(v any, i int) any {
+			switch v := v.(*Bloque); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dfs_proto_msgTypes[16].Exporter = func(v any, i int) any {
+			switch v := v.(*RespuestaBloques); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dfs_proto_msgTypes[17].Exporter = func(v any, i int) any {
+			switch v := v.(*Entrada); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dfs_proto_msgTypes[18].Exporter = func(v any, i int) any {
+			switch v := v.(*RespuestaLs); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dfs_proto_msgTypes[19].Exporter = func(v any, i int) any {
+			switch v := v.(*RespuestaBlockSize); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dfs_proto_msgTypes[20].Exporter = func(v any, i int) any {
+			switch v := v.(*RespuestaLineas); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dfs_proto_msgTypes[21].Exporter = func(v any, i int) any {
+			switch v := v.(*PedidoRegistro); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dfs_proto_msgTypes[22].Exporter = func(v any, i int) any {
+			switch v := v.(*PedidoHeartbeat); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dfs_proto_msgTypes[23].Exporter = func(v any, i int) any {
+			switch v := v.(*ClaveDeBloques); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dfs_proto_msgTypes[24].Exporter = func(v any, i int) any {
+			switch v := v.(*RespuestaClaves); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dfs_proto_msgTypes[25].Exporter = func(v any, i int) any {
+			switch v := v.(*PedidoReporteCompleto); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dfs_proto_msgTypes[26].Exporter = func(v any, i int) any {
+			switch v := v.(*PedidoReporteIncremental); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dfs_proto_msgTypes[27].Exporter = func(v any, i int) any {
+			switch v := v.(*PedidoStore); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dfs_proto_msgTypes[28].Exporter = func(v any, i int) any {
+			switch v := v.(*FragmentoStore); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dfs_proto_msgTypes[29].Exporter = func(v any, i int) any {
+			switch v := v.(*RespuestaStore); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dfs_proto_msgTypes[30].Exporter = func(v any, i int) any {
+			switch v := v.(*PedidoRead); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dfs_proto_msgTypes[31].Exporter = func(v any, i int) any {
+			switch v := v.(*RespuestaRead); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dfs_proto_msgTypes[32].Exporter = func(v any, i int) any {
+			switch v := v.(*FragmentoRead); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dfs_proto_msgTypes[33].Exporter = func(v any, i int) any {
+			switch v := v.(*PedidoBloque); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dfs_proto_msgTypes[34].Exporter = func(v any, i int) any {
+			switch v := v.(*PedidoReplicate); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_dfs_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   35,
+			NumExtensions: 0,
+			NumServices:   2,
+		},
+		GoTypes:           file_dfs_proto_goTypes,
+		DependencyIndexes: file_dfs_proto_depIdxs,
+		MessageInfos:      file_dfs_proto_msgTypes,
+	}.Build()
+	File_dfs_proto = out.File
+	file_dfs_proto_rawDesc = nil
+	file_dfs_proto_goTypes = nil
+	file_dfs_proto_depIdxs = nil
+}
@@ -0,0 +1,1419 @@
+// Servicios gRPC del DFS: el Namenode, para el cliente y los DataNodes, y los DataNodes,
+// para leer y escribir bloques. Los mensajes son los mismos del protocolo de frames
+// (paquete protocolo) y sus campos se llaman igual que allá en JSON.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.4.0
+// - protoc             (unknown)
+// source: dfs.proto
+
+package rpc
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.62.0 or later.
+const _ = grpc.SupportPackageIsVersion8
+
+const (
+	Namenode_Put_FullMethodName           = "/dfs.v1.Namenode/Put"
+	Namenode_Create_FullMethodName        = "/dfs.v1.Namenode/Create"
+	Namenode_AddBlock_FullMethodName      = "/dfs.v1.Namenode/AddBlock"
+	Namenode_Complete_FullMethodName      = "/dfs.v1.Namenode/Complete"
+	Namenode_Abandon_FullMethodName       = "/dfs.v1.Namenode/Abandon"
+	Namenode_Get_FullMethodName           = "/dfs.v1.Namenode/Get"
+	Namenode_Locations_FullMethodName     = "/dfs.v1.Namenode/Locations"
+	Namenode_Stat_FullMethodName          = "/dfs.v1.Namenode/Stat"
+	Namenode_Ls_FullMethodName            = "/dfs.v1.Namenode/Ls"
+	Namenode_Rm_FullMethodName            = "/dfs.v1.Namenode/Rm"
+	Namenode_Mkdir_FullMethodName         = "/dfs.v1.Namenode/Mkdir"
+	Namenode_Rmdir_FullMethodName         = "/dfs.v1.Namenode/Rmdir"
+	Namenode_Mv_FullMethodName            = "/dfs.v1.Namenode/Mv"
+	Namenode_Setrep_FullMethodName        = "/dfs.v1.Namenode/Setrep"
+	Namenode_Chmod_FullMethodName         = "/dfs.v1.Namenode/Chmod"
+	Namenode_Chown_FullMethodName         = "/dfs.v1.Namenode/Chown"
+	Namenode_SetQuota_FullMethodName      = "/dfs.v1.Namenode/SetQuota"
+	Namenode_SetSpaceQuota_FullMethodName = "/dfs.v1.Namenode/SetSpaceQuota"
+	Namenode_Count_FullMethodName         = "/dfs.v1.Namenode/Count"
+	Namenode_BlockSize_FullMethodName     = "/dfs.v1.Namenode/BlockSize"
+	Namenode_Nodes_FullMethodName         = "/dfs.v1.Namenode/Nodes"
+	Namenode_Fsck_FullMethodName          = "/dfs.v1.Namenode/Fsck"
+	Namenode_Register_FullMethodName      = "/dfs.v1.Namenode/Register"
+	Namenode_Heartbeat_FullMethodName     = "/dfs.v1.Namenode/Heartbeat"
+	Namenode_BlockReport_FullMethodName   = "/dfs.v1.Namenode/BlockReport"
+	Namenode_BlockReceived_FullMethodName = "/dfs.v1.Namenode/BlockReceived"
+	Namenode_BlockDeleted_FullMethodName  = "/dfs.v1.Namenode/BlockDeleted"
+	Namenode_BlockCorrupt_FullMethodName  = "/dfs.v1.Namenode/BlockCorrupt"
+)
+
+// NamenodeClient is the client API for Namenode service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type NamenodeClient interface {
+	// Namespace y bloques, para el cliente
+	Put(ctx context.Context, in *PedidoPut, opts ...grpc.CallOption) (*RespuestaBloques, error)
+	Create(ctx context.Context, in *PedidoCreate, opts ...grpc.CallOption) (*RespuestaCreate, error)
+	AddBlock(ctx context.Context, in *PedidoEscritura, opts ...grpc.CallOption) (*RespuestaBloques, error)
+	Complete(ctx context.Context, in *PedidoEscritura, opts ...grpc.CallOption) (*Vacio, error)
+	Abandon(ctx context.Context, in *PedidoEscritura, opts ...grpc.CallOption) (*Vacio, error)
+	Get(ctx context.Context, in *PedidoRuta, opts ...grpc.CallOption) (*RespuestaBloques, error)
+	Locations(ctx context.Context, in *PedidoLocations, opts ...grpc.CallOption) (*RespuestaBloques, error)
+	Stat(ctx context.Context, in *PedidoRuta, opts ...grpc.CallOption) (*Entrada, error)
+	Ls(ctx context.Context, in *PedidoRuta, opts ...grpc.CallOption) (*RespuestaLs, error)
+	Rm(ctx context.Context, in *PedidoRm, opts ...grpc.CallOption) (*RespuestaBloques, error)
+	Mkdir(ctx context.Context, in *PedidoMkdir, opts ...grpc.CallOption) (*Vacio, error)
+	Rmdir(ctx context.Context, in *PedidoRuta, opts ...grpc.CallOption) (*Vacio, error)
+	Mv(ctx context.Context, in *PedidoMv, opts ...grpc.CallOption) (*Vacio, error)
+	Setrep(ctx context.Context, in *PedidoSetrep, opts ...grpc.CallOption) (*Vacio, error)
+	Chmod(ctx context.Context, in *PedidoChmod, opts ...grpc.CallOption) (*Vacio, error)
+	Chown(ctx context.Context, in *PedidoChown, opts ...grpc.CallOption) (*Vacio, error)
+	SetQuota(ctx context.Context, in *PedidoCuota, opts ...grpc.CallOption) (*Vacio, error)
+	SetSpaceQuota(ctx context.Context, in *PedidoCuota, opts ...grpc.CallOption) (*Vacio, error)
+	Count(ctx context.Context, in *PedidoRuta, opts ...grpc.CallOption) (*RespuestaCount, error)
+	BlockSize(ctx context.Context, in *Vacio, opts ...grpc.CallOption) (*RespuestaBlockSize, error)
+	Nodes(ctx context.Context, in *Vacio, opts ...grpc.CallOption) (*RespuestaLineas, error)
+	Fsck(ctx context.Context, in *Vacio, opts ...grpc.CallOption) (*RespuestaLineas, error)
+	// Registro, heartbeats y reportes de bloques, para los DataNodes
+	Register(ctx context.Context, in *PedidoRegistro, opts ...grpc.CallOption) (*RespuestaClaves, error)
+	Heartbeat(ctx context.Context, in *PedidoHeartbeat, opts ...grpc.CallOption) (*RespuestaClaves, error)
+	BlockReport(ctx context.Context, in *PedidoReporteCompleto, opts ...grpc.CallOption) (*Vacio, error)
+	BlockReceived(ctx context.Context, in *PedidoReporteIncremental, opts ...grpc.CallOption) (*Vacio, error)
+	BlockDeleted(ctx context.Context, in *PedidoReporteIncremental, opts ...grpc.CallOption) (*Vacio, error)
+	BlockCorrupt(ctx context.Context, in *PedidoReporteIncremental, opts ...grpc.CallOption) (*Vacio, error)
+}
+
+type namenodeClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewNamenodeClient(cc grpc.ClientConnInterface) NamenodeClient {
+	return &namenodeClient{cc}
+}
+
+func (c *namenodeClient) Put(ctx context.Context, in *PedidoPut, opts ...grpc.CallOption) (*RespuestaBloques, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RespuestaBloques)
+	err := c.cc.Invoke(ctx, Namenode_Put_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *namenodeClient) Create(ctx context.Context, in *PedidoCreate, opts ...grpc.CallOption) (*RespuestaCreate, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RespuestaCreate)
+	err := c.cc.Invoke(ctx, Namenode_Create_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *namenodeClient) AddBlock(ctx context.Context, in *PedidoEscritura, opts ...grpc.CallOption) (*RespuestaBloques, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RespuestaBloques)
+	err := c.cc.Invoke(ctx, Namenode_AddBlock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *namenodeClient) Complete(ctx context.Context, in *PedidoEscritura, opts ...grpc.CallOption) (*Vacio, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Vacio)
+	err := c.cc.Invoke(ctx, Namenode_Complete_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *namenodeClient) Abandon(ctx context.Context, in *PedidoEscritura, opts ...grpc.CallOption) (*Vacio, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Vacio)
+	err := c.cc.Invoke(ctx, Namenode_Abandon_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *namenodeClient) Get(ctx context.Context, in *PedidoRuta, opts ...grpc.CallOption) (*RespuestaBloques, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RespuestaBloques)
+	err := c.cc.Invoke(ctx, Namenode_Get_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *namenodeClient) Locations(ctx context.Context, in *PedidoLocations, opts ...grpc.CallOption) (*RespuestaBloques, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RespuestaBloques)
+	err := c.cc.Invoke(ctx, Namenode_Locations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *namenodeClient) Stat(ctx context.Context, in *PedidoRuta, opts ...grpc.CallOption) (*Entrada, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Entrada)
+	err := c.cc.Invoke(ctx, Namenode_Stat_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *namenodeClient) Ls(ctx context.Context, in *PedidoRuta, opts ...grpc.CallOption) (*RespuestaLs, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RespuestaLs)
+	err := c.cc.Invoke(ctx, Namenode_Ls_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *namenodeClient) Rm(ctx context.Context, in *PedidoRm, opts ...grpc.CallOption) (*RespuestaBloques, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RespuestaBloques)
+	err := c.cc.Invoke(ctx, Namenode_Rm_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *namenodeClient) Mkdir(ctx context.Context, in *PedidoMkdir, opts ...grpc.CallOption) (*Vacio, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Vacio)
+	err := c.cc.Invoke(ctx, Namenode_Mkdir_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *namenodeClient) Rmdir(ctx context.Context, in *PedidoRuta, opts ...grpc.CallOption) (*Vacio, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Vacio)
+	err := c.cc.Invoke(ctx, Namenode_Rmdir_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *namenodeClient) Mv(ctx context.Context, in *PedidoMv, opts ...grpc.CallOption) (*Vacio, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Vacio)
+	err := c.cc.Invoke(ctx, Namenode_Mv_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *namenodeClient) Setrep(ctx context.Context, in *PedidoSetrep, opts ...grpc.CallOption) (*Vacio, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Vacio)
+	err := c.cc.Invoke(ctx, Namenode_Setrep_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *namenodeClient) Chmod(ctx context.Context, in *PedidoChmod, opts ...grpc.CallOption) (*Vacio, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Vacio)
+	err := c.cc.Invoke(ctx, Namenode_Chmod_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *namenodeClient) Chown(ctx context.Context, in *PedidoChown, opts ...grpc.CallOption) (*Vacio, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Vacio)
+	err := c.cc.Invoke(ctx, Namenode_Chown_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *namenodeClient) SetQuota(ctx context.Context, in *PedidoCuota, opts ...grpc.CallOption) (*Vacio, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Vacio)
+	err := c.cc.Invoke(ctx, Namenode_SetQuota_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *namenodeClient) SetSpaceQuota(ctx context.Context, in *PedidoCuota, opts ...grpc.CallOption) (*Vacio, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Vacio)
+	err := c.cc.Invoke(ctx, Namenode_SetSpaceQuota_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *namenodeClient) Count(ctx context.Context, in *PedidoRuta, opts ...grpc.CallOption) (*RespuestaCount, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RespuestaCount)
+	err := c.cc.Invoke(ctx, Namenode_Count_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *namenodeClient) BlockSize(ctx context.Context, in *Vacio, opts ...grpc.CallOption) (*RespuestaBlockSize, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RespuestaBlockSize)
+	err := c.cc.Invoke(ctx, Namenode_BlockSize_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *namenodeClient) Nodes(ctx context.Context, in *Vacio, opts ...grpc.CallOption) (*RespuestaLineas, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RespuestaLineas)
+	err := c.cc.Invoke(ctx, Namenode_Nodes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *namenodeClient) Fsck(ctx context.Context, in *Vacio, opts ...grpc.CallOption) (*RespuestaLineas, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RespuestaLineas)
+	err := c.cc.Invoke(ctx, Namenode_Fsck_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *namenodeClient) Register(ctx context.Context, in *PedidoRegistro, opts ...grpc.CallOption) (*RespuestaClaves, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RespuestaClaves)
+	err := c.cc.Invoke(ctx, Namenode_Register_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *namenodeClient) Heartbeat(ctx context.Context, in *PedidoHeartbeat, opts ...grpc.CallOption) (*RespuestaClaves, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RespuestaClaves)
+	err := c.cc.Invoke(ctx, Namenode_Heartbeat_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *namenodeClient) BlockReport(ctx context.Context, in *PedidoReporteCompleto, opts ...grpc.CallOption) (*Vacio, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Vacio)
+	err := c.cc.Invoke(ctx, Namenode_BlockReport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *namenodeClient) BlockReceived(ctx context.Context, in *PedidoReporteIncremental, opts ...grpc.CallOption) (*Vacio, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Vacio)
+	err := c.cc.Invoke(ctx, Namenode_BlockReceived_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *namenodeClient) BlockDeleted(ctx context.Context, in *PedidoReporteIncremental, opts ...grpc.CallOption) (*Vacio, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Vacio)
+	err := c.cc.Invoke(ctx, Namenode_BlockDeleted_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *namenodeClient) BlockCorrupt(ctx context.Context, in *PedidoReporteIncremental, opts ...grpc.CallOption) (*Vacio, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Vacio)
+	err := c.cc.Invoke(ctx, Namenode_BlockCorrupt_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// NamenodeServer is the server API for Namenode service.
+// All implementations must embed UnimplementedNamenodeServer
+// for forward compatibility
+type NamenodeServer interface {
+	// Namespace y bloques, para el cliente
+	Put(context.Context, *PedidoPut) (*RespuestaBloques, error)
+	Create(context.Context, *PedidoCreate) (*RespuestaCreate, error)
+	AddBlock(context.Context, *PedidoEscritura) (*RespuestaBloques, error)
+	Complete(context.Context, *PedidoEscritura) (*Vacio, error)
+	Abandon(context.Context, *PedidoEscritura) (*Vacio, error)
+	Get(context.Context, *PedidoRuta) (*RespuestaBloques, error)
+	Locations(context.Context, *PedidoLocations) (*RespuestaBloques, error)
+	Stat(context.Context, *PedidoRuta) (*Entrada, error)
+	Ls(context.Context, *PedidoRuta) (*RespuestaLs, error)
+	Rm(context.Context, *PedidoRm) (*RespuestaBloques, error)
+	Mkdir(context.Context, *PedidoMkdir) (*Vacio, error)
+	Rmdir(context.Context, *PedidoRuta) (*Vacio, error)
+	Mv(context.Context, *PedidoMv) (*Vacio, error)
+	Setrep(context.Context, *PedidoSetrep) (*Vacio, error)
+	Chmod(context.Context, *PedidoChmod) (*Vacio, error)
+	Chown(context.Context, *PedidoChown) (*Vacio, error)
+	SetQuota(context.Context, *PedidoCuota) (*Vacio, error)
+	SetSpaceQuota(context.Context, *PedidoCuota) (*Vacio, error)
+	Count(context.Context, *PedidoRuta) (*RespuestaCount, error)
+	BlockSize(context.Context, *Vacio) (*RespuestaBlockSize, error)
+	Nodes(context.Context, *Vacio) (*RespuestaLineas, error)
+	Fsck(context.Context, *Vacio) (*RespuestaLineas, error)
+	// Registro, heartbeats y reportes de bloques, para los DataNodes
+	Register(context.Context, *PedidoRegistro) (*RespuestaClaves, error)
+	Heartbeat(context.Context, *PedidoHeartbeat) (*RespuestaClaves, error)
+	BlockReport(context.Context, *PedidoReporteCompleto) (*Vacio, error)
+	BlockReceived(context.Context, *PedidoReporteIncremental) (*Vacio, error)
+	BlockDeleted(context.Context, *PedidoReporteIncremental) (*Vacio, error)
+	BlockCorrupt(context.Context, *PedidoReporteIncremental) (*Vacio, error)
+	mustEmbedUnimplementedNamenodeServer()
+}
+
+// UnimplementedNamenodeServer must be embedded to have forward compatible implementations.
+type UnimplementedNamenodeServer struct {
+}
+
+func (UnimplementedNamenodeServer) Put(context.Context, *PedidoPut) (*RespuestaBloques, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Put not implemented")
+}
+func (UnimplementedNamenodeServer) Create(context.Context, *PedidoCreate) (*RespuestaCreate, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Create not implemented")
+}
+func (UnimplementedNamenodeServer) AddBlock(context.Context, *PedidoEscritura) (*RespuestaBloques, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddBlock not implemented")
+}
+func (UnimplementedNamenodeServer) Complete(context.Context, *PedidoEscritura) (*Vacio, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Complete not implemented")
+}
+func (UnimplementedNamenodeServer) Abandon(context.Context, *PedidoEscritura) (*Vacio, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Abandon not implemented")
+}
+func (UnimplementedNamenodeServer) Get(context.Context, *PedidoRuta) (*RespuestaBloques, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+}
+func (UnimplementedNamenodeServer) Locations(context.Context, *PedidoLocations) (*RespuestaBloques, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Locations not implemented")
+}
+func (UnimplementedNamenodeServer) Stat(context.Context, *PedidoRuta) (*Entrada, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Stat not implemented")
+}
+func (UnimplementedNamenodeServer) Ls(context.Context, *PedidoRuta) (*RespuestaLs, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Ls not implemented")
+}
+func (UnimplementedNamenodeServer) Rm(context.Context, *PedidoRm) (*RespuestaBloques, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Rm not implemented")
+}
+func (UnimplementedNamenodeServer) Mkdir(context.Context, *PedidoMkdir) (*Vacio, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Mkdir not implemented")
+}
+func (UnimplementedNamenodeServer) Rmdir(context.Context, *PedidoRuta) (*Vacio, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Rmdir not implemented")
+}
+func (UnimplementedNamenodeServer) Mv(context.Context, *PedidoMv) (*Vacio, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Mv not implemented")
+}
+func (UnimplementedNamenodeServer) Setrep(context.Context, *PedidoSetrep) (*Vacio, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Setrep not implemented")
+}
+func (UnimplementedNamenodeServer) Chmod(context.Context, *PedidoChmod) (*Vacio, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Chmod not implemented")
+}
+func (UnimplementedNamenodeServer) Chown(context.Context, *PedidoChown) (*Vacio, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Chown not implemented")
+}
+func (UnimplementedNamenodeServer) SetQuota(context.Context, *PedidoCuota) (*Vacio, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetQuota not implemented")
+}
+func (UnimplementedNamenodeServer) SetSpaceQuota(context.Context, *PedidoCuota) (*Vacio, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetSpaceQuota not implemented")
+}
+func (UnimplementedNamenodeServer) Count(context.Context, *PedidoRuta) (*RespuestaCount, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Count not implemented")
+}
+func (UnimplementedNamenodeServer) BlockSize(context.Context, *Vacio) (*RespuestaBlockSize, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BlockSize not implemented")
+}
+func (UnimplementedNamenodeServer) Nodes(context.Context, *Vacio) (*RespuestaLineas, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Nodes not implemented")
+}
+func (UnimplementedNamenodeServer) Fsck(context.Context, *Vacio) (*RespuestaLineas, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Fsck not implemented")
+}
+func (UnimplementedNamenodeServer) Register(context.Context, *PedidoRegistro) (*RespuestaClaves, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Register not implemented")
+}
+func (UnimplementedNamenodeServer) Heartbeat(context.Context, *PedidoHeartbeat) (*RespuestaClaves, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Heartbeat not implemented")
+}
+func (UnimplementedNamenodeServer) BlockReport(context.Context, *PedidoReporteCompleto) (*Vacio, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BlockReport not implemented")
+}
+func (UnimplementedNamenodeServer) BlockReceived(context.Context, *PedidoReporteIncremental) (*Vacio, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BlockReceived not implemented")
+}
+func (UnimplementedNamenodeServer) BlockDeleted(context.Context, *PedidoReporteIncremental) (*Vacio, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BlockDeleted not implemented")
+}
+func (UnimplementedNamenodeServer) BlockCorrupt(context.Context, *PedidoReporteIncremental) (*Vacio, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BlockCorrupt not implemented")
+}
+func (UnimplementedNamenodeServer) mustEmbedUnimplementedNamenodeServer() {}
+
+// UnsafeNamenodeServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to NamenodeServer will
+// result in compilation errors.
+type UnsafeNamenodeServer interface {
+	mustEmbedUnimplementedNamenodeServer()
+}
+
+func RegisterNamenodeServer(s grpc.ServiceRegistrar, srv NamenodeServer) {
+	s.RegisterService(&Namenode_ServiceDesc, srv)
+}
+
+func _Namenode_Put_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PedidoPut)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NamenodeServer).Put(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Namenode_Put_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NamenodeServer).Put(ctx, req.(*PedidoPut))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Namenode_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PedidoCreate)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NamenodeServer).Create(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Namenode_Create_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NamenodeServer).Create(ctx, req.(*PedidoCreate))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Namenode_AddBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PedidoEscritura)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NamenodeServer).AddBlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Namenode_AddBlock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NamenodeServer).AddBlock(ctx, req.(*PedidoEscritura))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Namenode_Complete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PedidoEscritura)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NamenodeServer).Complete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Namenode_Complete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NamenodeServer).Complete(ctx, req.(*PedidoEscritura))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Namenode_Abandon_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PedidoEscritura)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NamenodeServer).Abandon(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Namenode_Abandon_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NamenodeServer).Abandon(ctx, req.(*PedidoEscritura))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Namenode_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PedidoRuta)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NamenodeServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Namenode_Get_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NamenodeServer).Get(ctx, req.(*PedidoRuta))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Namenode_Locations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PedidoLocations)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NamenodeServer).Locations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Namenode_Locations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NamenodeServer).Locations(ctx, req.(*PedidoLocations))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Namenode_Stat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PedidoRuta)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NamenodeServer).Stat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Namenode_Stat_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NamenodeServer).Stat(ctx, req.(*PedidoRuta))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Namenode_Ls_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PedidoRuta)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NamenodeServer).Ls(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Namenode_Ls_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NamenodeServer).Ls(ctx, req.(*PedidoRuta))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Namenode_Rm_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PedidoRm)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NamenodeServer).Rm(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Namenode_Rm_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NamenodeServer).Rm(ctx, req.(*PedidoRm))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Namenode_Mkdir_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PedidoMkdir)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NamenodeServer).Mkdir(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Namenode_Mkdir_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NamenodeServer).Mkdir(ctx, req.(*PedidoMkdir))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Namenode_Rmdir_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PedidoRuta)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NamenodeServer).Rmdir(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Namenode_Rmdir_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NamenodeServer).Rmdir(ctx, req.(*PedidoRuta))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Namenode_Mv_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PedidoMv)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NamenodeServer).Mv(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Namenode_Mv_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NamenodeServer).Mv(ctx, req.(*PedidoMv))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Namenode_Setrep_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PedidoSetrep)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NamenodeServer).Setrep(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Namenode_Setrep_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NamenodeServer).Setrep(ctx, req.(*PedidoSetrep))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Namenode_Chmod_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PedidoChmod)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NamenodeServer).Chmod(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Namenode_Chmod_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NamenodeServer).Chmod(ctx, req.(*PedidoChmod))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Namenode_Chown_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PedidoChown)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NamenodeServer).Chown(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Namenode_Chown_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NamenodeServer).Chown(ctx, req.(*PedidoChown))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Namenode_SetQuota_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PedidoCuota)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NamenodeServer).SetQuota(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Namenode_SetQuota_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NamenodeServer).SetQuota(ctx, req.(*PedidoCuota))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Namenode_SetSpaceQuota_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PedidoCuota)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NamenodeServer).SetSpaceQuota(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Namenode_SetSpaceQuota_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NamenodeServer).SetSpaceQuota(ctx, req.(*PedidoCuota))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Namenode_Count_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PedidoRuta)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NamenodeServer).Count(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Namenode_Count_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NamenodeServer).Count(ctx, req.(*PedidoRuta))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Namenode_BlockSize_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Vacio)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NamenodeServer).BlockSize(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Namenode_BlockSize_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NamenodeServer).BlockSize(ctx, req.(*Vacio))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Namenode_Nodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Vacio)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NamenodeServer).Nodes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Namenode_Nodes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NamenodeServer).Nodes(ctx, req.(*Vacio))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Namenode_Fsck_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Vacio)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NamenodeServer).Fsck(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Namenode_Fsck_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NamenodeServer).Fsck(ctx, req.(*Vacio))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Namenode_Register_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PedidoRegistro)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NamenodeServer).Register(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Namenode_Register_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NamenodeServer).Register(ctx, req.(*PedidoRegistro))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Namenode_Heartbeat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PedidoHeartbeat)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NamenodeServer).Heartbeat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Namenode_Heartbeat_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NamenodeServer).Heartbeat(ctx, req.(*PedidoHeartbeat))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Namenode_BlockReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PedidoReporteCompleto)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NamenodeServer).BlockReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Namenode_BlockReport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NamenodeServer).BlockReport(ctx, req.(*PedidoReporteCompleto))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Namenode_BlockReceived_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PedidoReporteIncremental)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NamenodeServer).BlockReceived(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Namenode_BlockReceived_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NamenodeServer).BlockReceived(ctx, req.(*PedidoReporteIncremental))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Namenode_BlockDeleted_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PedidoReporteIncremental)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NamenodeServer).BlockDeleted(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Namenode_BlockDeleted_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NamenodeServer).BlockDeleted(ctx, req.(*PedidoReporteIncremental))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Namenode_BlockCorrupt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PedidoReporteIncremental)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NamenodeServer).BlockCorrupt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Namenode_BlockCorrupt_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NamenodeServer).BlockCorrupt(ctx, req.(*PedidoReporteIncremental))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Namenode_ServiceDesc is the grpc.ServiceDesc for Namenode service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Namenode_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "dfs.v1.Namenode",
+	HandlerType: (*NamenodeServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Put",
+			Handler:    _Namenode_Put_Handler,
+		},
+		{
+			MethodName: "Create",
+			Handler:    _Namenode_Create_Handler,
+		},
+		{
+			MethodName: "AddBlock",
+			Handler:    _Namenode_AddBlock_Handler,
+		},
+		{
+			MethodName: "Complete",
+			Handler:    _Namenode_Complete_Handler,
+		},
+		{
+			MethodName: "Abandon",
+			Handler:    _Namenode_Abandon_Handler,
+		},
+		{
+			MethodName: "Get",
+			Handler:    _Namenode_Get_Handler,
+		},
+		{
+			MethodName: "Locations",
+			Handler:    _Namenode_Locations_Handler,
+		},
+		{
+			MethodName: "Stat",
+			Handler:    _Namenode_Stat_Handler,
+		},
+		{
+			MethodName: "Ls",
+			Handler:    _Namenode_Ls_Handler,
+		},
+		{
+			MethodName: "Rm",
+			Handler:    _Namenode_Rm_Handler,
+		},
+		{
+			MethodName: "Mkdir",
+			Handler:    _Namenode_Mkdir_Handler,
+		},
+		{
+			MethodName: "Rmdir",
+			Handler:    _Namenode_Rmdir_Handler,
+		},
+		{
+			MethodName: "Mv",
+			Handler:    _Namenode_Mv_Handler,
+		},
+		{
+			MethodName: "Setrep",
+			Handler:    _Namenode_Setrep_Handler,
+		},
+		{
+			MethodName: "Chmod",
+			Handler:    _Namenode_Chmod_Handler,
+		},
+		{
+			MethodName: "Chown",
+			Handler:    _Namenode_Chown_Handler,
+		},
+		{
+			MethodName: "SetQuota",
+			Handler:    _Namenode_SetQuota_Handler,
+		},
+		{
+			MethodName: "SetSpaceQuota",
+			Handler:    _Namenode_SetSpaceQuota_Handler,
+		},
+		{
+			MethodName: "Count",
+			Handler:    _Namenode_Count_Handler,
+		},
+		{
+			MethodName: "BlockSize",
+			Handler:    _Namenode_BlockSize_Handler,
+		},
+		{
+			MethodName: "Nodes",
+			Handler:    _Namenode_Nodes_Handler,
+		},
+		{
+			MethodName: "Fsck",
+			Handler:    _Namenode_Fsck_Handler,
+		},
+		{
+			MethodName: "Register",
+			Handler:    _Namenode_Register_Handler,
+		},
+		{
+			MethodName: "Heartbeat",
+			Handler:    _Namenode_Heartbeat_Handler,
+		},
+		{
+			MethodName: "BlockReport",
+			Handler:    _Namenode_BlockReport_Handler,
+		},
+		{
+			MethodName: "BlockReceived",
+			Handler:    _Namenode_BlockReceived_Handler,
+		},
+		{
+			MethodName: "BlockDeleted",
+			Handler:    _Namenode_BlockDeleted_Handler,
+		},
+		{
+			MethodName: "BlockCorrupt",
+			Handler:    _Namenode_BlockCorrupt_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "dfs.proto",
+}
+
+const (
+	DataNode_Store_FullMethodName     = "/dfs.v1.DataNode/Store"
+	DataNode_Read_FullMethodName      = "/dfs.v1.DataNode/Read"
+	DataNode_Rm_FullMethodName        = "/dfs.v1.DataNode/Rm"
+	DataNode_Replicate_FullMethodName = "/dfs.v1.DataNode/Replicate"
+)
+
+// DataNodeClient is the client API for DataNode service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type DataNodeClient interface {
+	// El primer fragmento trae el pedido; los datos y después sus checksums pueden venir
+	// en ese mismo fragmento y en los siguientes
+	Store(ctx context.Context, opts ...grpc.CallOption) (DataNode_StoreClient, error)
+	// El primer fragmento trae la respuesta con el rango; después vienen los datos y sus checksums
+	Read(ctx context.Context, in *PedidoRead, opts ...grpc.CallOption) (DataNode_ReadClient, error)
+	Rm(ctx context.Context, in *PedidoBloque, opts ...grpc.CallOption) (*Vacio, error)
+	// Se contesta apenas se acepta; la copia sigue en segundo plano
+	Replicate(ctx context.Context, in *PedidoReplicate, opts ...grpc.CallOption) (*Vacio, error)
+}
+
+type dataNodeClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewDataNodeClient(cc grpc.ClientConnInterface) DataNodeClient {
+	return &dataNodeClient{cc}
+}
+
+func (c *dataNodeClient) Store(ctx context.Context, opts ...grpc.CallOption) (DataNode_StoreClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &DataNode_ServiceDesc.Streams[0], DataNode_Store_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &dataNodeStoreClient{ClientStream: stream}
+	return x, nil
+}
+
+type DataNode_StoreClient interface {
+	Send(*FragmentoStore) error
+	CloseAndRecv() (*RespuestaStore, error)
+	grpc.ClientStream
+}
+
+type dataNodeStoreClient struct {
+	grpc.ClientStream
+}
+
+func (x *dataNodeStoreClient) Send(m *FragmentoStore) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *dataNodeStoreClient) CloseAndRecv() (*RespuestaStore, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(RespuestaStore)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *dataNodeClient) Read(ctx context.Context, in *PedidoRead, opts ...grpc.CallOption) (DataNode_ReadClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &DataNode_ServiceDesc.Streams[1], DataNode_Read_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &dataNodeReadClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type DataNode_ReadClient interface {
+	Recv() (*FragmentoRead, error)
+	grpc.ClientStream
+}
+
+type dataNodeReadClient struct {
+	grpc.ClientStream
+}
+
+func (x *dataNodeReadClient) Recv() (*FragmentoRead, error) {
+	m := new(FragmentoRead)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *dataNodeClient) Rm(ctx context.Context, in *PedidoBloque, opts ...grpc.CallOption) (*Vacio, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Vacio)
+	err := c.cc.Invoke(ctx, DataNode_Rm_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataNodeClient) Replicate(ctx context.Context, in *PedidoReplicate, opts ...grpc.CallOption) (*Vacio, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Vacio)
+	err := c.cc.Invoke(ctx, DataNode_Replicate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DataNodeServer is the server API for DataNode service.
+// All implementations must embed UnimplementedDataNodeServer
+// for forward compatibility
+type DataNodeServer interface {
+	// El primer fragmento trae el pedido; los datos y después sus checksums pueden venir
+	// en ese mismo fragmento y en los siguientes
+	Store(DataNode_StoreServer) error
+	// El primer fragmento trae la respuesta con el rango; después vienen los datos y sus checksums
+	Read(*PedidoRead, DataNode_ReadServer) error
+	Rm(context.Context, *PedidoBloque) (*Vacio, error)
+	// Se contesta apenas se acepta; la copia sigue en segundo plano
+	Replicate(context.Context, *PedidoReplicate) (*Vacio, error)
+	mustEmbedUnimplementedDataNodeServer()
+}
+
+// UnimplementedDataNodeServer must be embedded to have forward compatible implementations.
+type UnimplementedDataNodeServer struct {
+}
+
+func (UnimplementedDataNodeServer) Store(DataNode_StoreServer) error {
+	return status.Errorf(codes.Unimplemented, "method Store not implemented")
+}
+func (UnimplementedDataNodeServer) Read(*PedidoRead, DataNode_ReadServer) error {
+	return status.Errorf(codes.Unimplemented, "method Read not implemented")
+}
+func (UnimplementedDataNodeServer) Rm(context.Context, *PedidoBloque) (*Vacio, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Rm not implemented")
+}
+func (UnimplementedDataNodeServer) Replicate(context.Context, *PedidoReplicate) (*Vacio, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Replicate not implemented")
+}
+func (UnimplementedDataNodeServer) mustEmbedUnimplementedDataNodeServer() {}
+
+// UnsafeDataNodeServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to DataNodeServer will
+// result in compilation errors.
+type UnsafeDataNodeServer interface {
+	mustEmbedUnimplementedDataNodeServer()
+}
+
+func RegisterDataNodeServer(s grpc.ServiceRegistrar, srv DataNodeServer) {
+	s.RegisterService(&DataNode_ServiceDesc, srv)
+}
+
+func _DataNode_Store_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(DataNodeServer).Store(&dataNodeStoreServer{ServerStream: stream})
+}
+
+type DataNode_StoreServer interface {
+	SendAndClose(*RespuestaStore) error
+	Recv() (*FragmentoStore, error)
+	grpc.ServerStream
+}
+
+type dataNodeStoreServer struct {
+	grpc.ServerStream
+}
+
+func (x *dataNodeStoreServer) SendAndClose(m *RespuestaStore) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *dataNodeStoreServer) Recv() (*FragmentoStore, error) {
+	m := new(FragmentoStore)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _DataNode_Read_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(PedidoRead)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DataNodeServer).Read(m, &dataNodeReadServer{ServerStream: stream})
+}
+
+type DataNode_ReadServer interface {
+	Send(*FragmentoRead) error
+	grpc.ServerStream
+}
+
+type dataNodeReadServer struct {
+	grpc.ServerStream
+}
+
+func (x *dataNodeReadServer) Send(m *FragmentoRead) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _DataNode_Rm_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PedidoBloque)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataNodeServer).Rm(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataNode_Rm_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataNodeServer).Rm(ctx, req.(*PedidoBloque))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataNode_Replicate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PedidoReplicate)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataNodeServer).Replicate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataNode_Replicate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataNodeServer).Replicate(ctx, req.(*PedidoReplicate))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// DataNode_ServiceDesc is the grpc.ServiceDesc for DataNode service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var DataNode_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "dfs.v1.DataNode",
+	HandlerType: (*DataNodeServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Rm",
+			Handler:    _DataNode_Rm_Handler,
+		},
+		{
+			MethodName: "Replicate",
+			Handler:    _DataNode_Replicate_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Store",
+			Handler:       _DataNode_Store_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "Read",
+			Handler:       _DataNode_Read_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "dfs.proto",
+}
@@ -0,0 +1,78 @@
+package rpc
+
+import (
+	"errors"
+	"strconv"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/UriNoHi/Distributed-file-system-DFS-/protocolo"
+)
+
+// Los errores viajan con el código gRPC más parecido, para otros clientes, y con el código
+// del protocolo en un ErrorInfo, para que del otro lado vuelvan a ser el mismo *protocolo.Error
+const dominio = "dfs"
+
+var codigosGRPC = map[protocolo.Codigo]codes.Code{
+	protocolo.CodigoInvalido:        codes.InvalidArgument,
+	protocolo.CodigoVersion:         codes.Unimplemented,
+	protocolo.CodigoNoExiste:        codes.NotFound,
+	protocolo.CodigoExiste:          codes.AlreadyExists,
+	protocolo.CodigoNoEsDirectorio:  codes.FailedPrecondition,
+	protocolo.CodigoEsDirectorio:    codes.FailedPrecondition,
+	protocolo.CodigoNoVacio:         codes.FailedPrecondition,
+	protocolo.CodigoSinDataNodes:    codes.Unavailable,
+	protocolo.CodigoNodoDesconocido: codes.FailedPrecondition,
+	protocolo.CodigoBloqueCorrupto:  codes.DataLoss,
+	protocolo.CodigoInterno:         codes.Internal,
+	protocolo.CodigoPermiso:         codes.PermissionDenied,
+	protocolo.CodigoToken:           codes.Unauthenticated,
+	protocolo.CodigoCuota:           codes.ResourceExhausted,
+}
+
+// Status convierte el error de un pedido en el error que devuelve el servidor gRPC.
+// Si err no es un *protocolo.Error se manda como interno.
+func Status(err error) error {
+	if err == nil {
+		return nil
+	}
+	var e *protocolo.Error
+	if !errors.As(err, &e) {
+		e = &protocolo.Error{Codigo: protocolo.CodigoInterno, Mensaje: err.Error()}
+	}
+	codigo, ok := codigosGRPC[e.Codigo]
+	if !ok {
+		codigo = codes.Unknown
+	}
+	st := status.New(codigo, e.Error())
+	info := &errdetails.ErrorInfo{
+		Reason:   e.Codigo.String(),
+		Domain:   dominio,
+		Metadata: map[string]string{"codigo": strconv.Itoa(int(e.Codigo))},
+	}
+	if conInfo, err := st.WithDetails(info); err == nil {
+		st = conInfo
+	}
+	return st.Err()
+}
+
+// ErrorDe convierte el error de una llamada gRPC en el *protocolo.Error que mandó el servidor.
+// Los errores del transporte, como no poder conectarse, se devuelven como están.
+func ErrorDe(err error) error {
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+	for _, detalle := range st.Details() {
+		info, ok := detalle.(*errdetails.ErrorInfo)
+		if !ok || info.Domain != dominio {
+			continue
+		}
+		if codigo, err := strconv.Atoi(info.Metadata["codigo"]); err == nil {
+			return &protocolo.Error{Codigo: protocolo.Codigo(codigo), Mensaje: st.Message()}
+		}
+	}
+	return err
+}
@@ -0,0 +1,125 @@
+// Package rpc es el transporte gRPC del DFS, la alternativa al protocolo de frames del
+// paquete protocolo. Los servicios están en dfs.proto; los mensajes son los mismos del
+// protocolo de frames, así el Namenode y los DataNodes atienden los dos con el mismo código:
+// cada llamada gRPC se convierte en un protocolo.Pedido y la respuesta vuelve convertida.
+package rpc
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative dfs.proto
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+
+	"github.com/UriNoHi/Distributed-file-system-DFS-/protocolo"
+)
+
+// claveUsuario es el metadato con el usuario que hace las llamadas, como en el saludo del
+// protocolo de frames
+const claveUsuario = "usuario"
+
+const (
+	maximoDeMensaje = 64 << 20 // como en el protocolo de frames: un reporte completo puede ser grande
+	maximoDeDatos   = 64 << 10 // los datos de un bloque se parten en fragmentos de hasta este tamaño
+)
+
+// NuevoServidor crea el servidor gRPC con los mismos límites que el protocolo de frames.
+// Con configTLS atiende solo conexiones TLS, como las de protocolo.ConfigTLSDeNodo.
+func NuevoServidor(configTLS *tls.Config) *grpc.Server {
+	opciones := []grpc.ServerOption{grpc.MaxRecvMsgSize(maximoDeMensaje), grpc.MaxSendMsgSize(maximoDeMensaje)}
+	if configTLS != nil {
+		opciones = append(opciones, grpc.Creds(credentials.NewTLS(configTLS)))
+	}
+	return grpc.NewServer(opciones...)
+}
+
+// NuevoPedido arma el pedido del protocolo de frames que equivale a una llamada gRPC, con el
+// usuario que la llamada declara en sus metadatos
+func NuevoPedido(ctx context.Context, op string, mensaje any) (*protocolo.Pedido, error) {
+	cuerpo, err := json.Marshal(mensaje)
+	if err != nil {
+		return nil, err
+	}
+	pedido := &protocolo.Pedido{Op: op, Cuerpo: cuerpo}
+	if valores := metadata.ValueFromIncomingContext(ctx, claveUsuario); len(valores) > 0 {
+		pedido.Usuario = valores[0]
+	}
+	return pedido, nil
+}
+
+// EstadoTLS devuelve el estado de la conexión TLS por la que llegó la llamada, o nil si
+// no usa TLS. Con mTLS tiene el certificado verificado del que llama.
+func EstadoTLS(ctx context.Context) *tls.ConnectionState {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok {
+		return nil
+	}
+	return &info.State
+}
+
+// Convertir copia un mensaje del protocolo de frames en el mensaje gRPC equivalente, o al
+// revés. Los campos de dfs.proto se llaman igual que los del paquete protocolo en JSON, así
+// que alcanza con pasar por JSON.
+func Convertir(desde any, hacia any) error {
+	contenido, err := json.Marshal(desde)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(contenido, hacia)
+}
+
+// metodo es la llamada gRPC que corresponde a una operación del protocolo de frames
+type metodo struct {
+	nombre    string
+	pedido    func() any
+	respuesta func() any
+}
+
+func nuevo[T any]() any { return new(T) }
+
+var metodosDelNamenode = map[string]metodo{
+	protocolo.OpPut:           {Namenode_Put_FullMethodName, nuevo[PedidoPut], nuevo[RespuestaBloques]},
+	protocolo.OpCreate:        {Namenode_Create_FullMethodName, nuevo[PedidoCreate], nuevo[RespuestaCreate]},
+	protocolo.OpAddBlock:      {Namenode_AddBlock_FullMethodName, nuevo[PedidoEscritura], nuevo[RespuestaBloques]},
+	protocolo.OpComplete:      {Namenode_Complete_FullMethodName, nuevo[PedidoEscritura], nuevo[Vacio]},
+	protocolo.OpAbandon:       {Namenode_Abandon_FullMethodName, nuevo[PedidoEscritura], nuevo[Vacio]},
+	protocolo.OpGet:           {Namenode_Get_FullMethodName, nuevo[PedidoRuta], nuevo[RespuestaBloques]},
+	protocolo.OpLocations:     {Namenode_Locations_FullMethodName, nuevo[PedidoLocations], nuevo[RespuestaBloques]},
+	protocolo.OpStat:          {Namenode_Stat_FullMethodName, nuevo[PedidoRuta], nuevo[Entrada]},
+	protocolo.OpLs:            {Namenode_Ls_FullMethodName, nuevo[PedidoRuta], nuevo[RespuestaLs]},
+	protocolo.OpRm:            {Namenode_Rm_FullMethodName, nuevo[PedidoRm], nuevo[RespuestaBloques]},
+	protocolo.OpMkdir:         {Namenode_Mkdir_FullMethodName, nuevo[PedidoMkdir], nuevo[Vacio]},
+	protocolo.OpRmdir:         {Namenode_Rmdir_FullMethodName, nuevo[PedidoRuta], nuevo[Vacio]},
+	protocolo.OpMv:            {Namenode_Mv_FullMethodName, nuevo[PedidoMv], nuevo[Vacio]},
+	protocolo.OpSetrep:        {Namenode_Setrep_FullMethodName, nuevo[PedidoSetrep], nuevo[Vacio]},
+	protocolo.OpChmod:         {Namenode_Chmod_FullMethodName, nuevo[PedidoChmod], nuevo[Vacio]},
+	protocolo.OpChown:         {Namenode_Chown_FullMethodName, nuevo[PedidoChown], nuevo[Vacio]},
+	protocolo.OpSetQuota:      {Namenode_SetQuota_FullMethodName, nuevo[PedidoCuota], nuevo[Vacio]},
+	protocolo.OpSetSpaceQuota: {Namenode_SetSpaceQuota_FullMethodName, nuevo[PedidoCuota], nuevo[Vacio]},
+	protocolo.OpCount:         {Namenode_Count_FullMethodName, nuevo[PedidoRuta], nuevo[RespuestaCount]},
+	protocolo.OpBlockSize:     {Namenode_BlockSize_FullMethodName, nuevo[Vacio], nuevo[RespuestaBlockSize]},
+	protocolo.OpNodes:         {Namenode_Nodes_FullMethodName, nuevo[Vacio], nuevo[RespuestaLineas]},
+	protocolo.OpFsck:          {Namenode_Fsck_FullMethodName, nuevo[Vacio], nuevo[RespuestaLineas]},
+
+	protocolo.OpRegister:      {Namenode_Register_FullMethodName, nuevo[PedidoRegistro], nuevo[RespuestaClaves]},
+	protocolo.OpHeartbeat:     {Namenode_Heartbeat_FullMethodName, nuevo[PedidoHeartbeat], nuevo[RespuestaClaves]},
+	protocolo.OpBlockReport:   {Namenode_BlockReport_FullMethodName, nuevo[PedidoReporteCompleto], nuevo[Vacio]},
+	protocolo.OpBlockReceived: {Namenode_BlockReceived_FullMethodName, nuevo[PedidoReporteIncremental], nuevo[Vacio]},
+	protocolo.OpBlockDeleted:  {Namenode_BlockDeleted_FullMethodName, nuevo[PedidoReporteIncremental], nuevo[Vacio]},
+	protocolo.OpBlockCorrupt:  {Namenode_BlockCorrupt_FullMethodName, nuevo[PedidoReporteIncremental], nuevo[Vacio]},
+}
+
+// Store y Read tienen datos y van por Guardar y Leer
+var metodosDelDataNode = map[string]metodo{
+	protocolo.OpRmBlock:   {DataNode_Rm_FullMethodName, nuevo[PedidoBloque], nuevo[Vacio]},
+	protocolo.OpReplicate: {DataNode_Replicate_FullMethodName, nuevo[PedidoReplicate], nuevo[Vacio]},
+}
@@ -0,0 +1,68 @@
+package protocolo
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"os"
+)
+
+// ConfigTLS arma la configuración de TLS de un nodo o de un cliente a partir de archivos PEM.
+// Sin ningún archivo devuelve nil: las conexiones van sin cifrar.
+//
+// La misma configuración sirve para escuchar y para conectarse. cert y key son el certificado
+// propio: lo presenta el servidor y, con mTLS, también quien se conecta. ca son las
+// autoridades en las que se confía; con ca el servidor además exige y verifica el certificado
+// del otro lado (mTLS), y quien se conecta verifica al servidor con ellas en lugar de las del
+// sistema. Los certificados de los DataNodes se usan para los dos lados, así que tienen que
+// servir para serverAuth y clientAuth y nombrar la dirección que anuncian.
+func ConfigTLS(cert string, key string, ca string) (*tls.Config, error) {
+	if cert == "" && key == "" && ca == "" {
+		return nil, nil
+	}
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+	if cert != "" || key != "" {
+		if cert == "" || key == "" {
+			return nil, fmt.Errorf("el certificado y su clave van juntos")
+		}
+		par, err := tls.LoadX509KeyPair(cert, key)
+		if err != nil {
+			return nil, err
+		}
+		config.Certificates = []tls.Certificate{par}
+	}
+	if ca != "" {
+		contenido, err := os.ReadFile(ca)
+		if err != nil {
+			return nil, err
+		}
+		autoridades := x509.NewCertPool()
+		if !autoridades.AppendCertsFromPEM(contenido) {
+			return nil, fmt.Errorf("%s no tiene certificados PEM", ca)
+		}
+		config.RootCAs = autoridades
+		config.ClientCAs = autoridades
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return config, nil
+}
+
+// ConfigTLSDeNodo es ConfigTLS para el Namenode y los DataNodes, que además de conectarse
+// escuchan: si hay TLS, el certificado propio es obligatorio
+func ConfigTLSDeNodo(cert string, key string, ca string) (*tls.Config, error) {
+	config, err := ConfigTLS(cert, key, ca)
+	if err == nil && config != nil && len(config.Certificates) == 0 {
+		err = fmt.Errorf("para escuchar con TLS hace falta el certificado y su clave")
+	}
+	return config, err
+}
+
+// Dial abre una conexión TCP con address; con config hace el handshake de TLS antes de volver
+func Dial(ctx context.Context, dialer *net.Dialer, address string, config *tls.Config) (net.Conn, error) {
+	if config == nil {
+		return dialer.DialContext(ctx, "tcp", address)
+	}
+	return (&tls.Dialer{NetDialer: dialer, Config: config}).DialContext(ctx, "tcp", address)
+}
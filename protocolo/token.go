@@ -0,0 +1,96 @@
+package protocolo
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Los DataNodes solo aceptan operaciones sobre un bloque con un token de acceso que dio el
+// Namenode: dice qué bloque, para qué y hasta cuándo, firmado con HMAC-SHA256. Las claves
+// con las que se firma las genera el Namenode, las cambia cada tanto y se las pasa a los
+// DataNodes en las respuestas del registro y de los heartbeats; un DataNode acepta los
+// tokens firmados con cualquier clave que todavía no venció.
+//
+// El token viaja como texto: el contenido en JSON y la firma, cada uno en base64 sin relleno
+// y separados por un punto.
+
+// Accesos que puede dar un token
+const (
+	AccesoLeer     = "leer"     // read
+	AccesoEscribir = "escribir" // store, también reenviado por el pipeline
+	AccesoBorrar   = "borrar"   // rm
+	AccesoReplicar = "replicar" // replicate: copiar el bloque a otro DataNode; solo lo pide el Namenode
+)
+
+// ClaveDeBloques es una clave para firmar tokens. Expira es unix en segundos: desde ahí
+// ningún token firmado con ella sirve.
+type ClaveDeBloques struct {
+	ID     int64  `json:"id"`
+	Clave  []byte `json:"clave"`
+	Expira int64  `json:"expira"`
+}
+
+// TokenDeBloque es el contenido de un token. Expira es unix en segundos.
+type TokenDeBloque struct {
+	Bloque  string `json:"bloque"`
+	Acceso  string `json:"acceso"`
+	Expira  int64  `json:"expira"`
+	Clave   int64  `json:"clave"`             // ID de la clave con la que se firmó
+	Usuario string `json:"usuario,omitempty"` // a quién se le dio, solo para los logs
+}
+
+var codificacion = base64.RawURLEncoding
+
+// FirmarToken arma el texto del token firmado con la clave
+func FirmarToken(token TokenDeBloque, clave ClaveDeBloques) string {
+	token.Clave = clave.ID
+	contenido, _ := json.Marshal(token)
+	return codificacion.EncodeToString(contenido) + "." + codificacion.EncodeToString(firma(contenido, clave.Clave))
+}
+
+// VerificarToken comprueba que el token sea para bloque y acceso, que no haya vencido y que
+// lo haya firmado alguna de las claves. Los errores son *Error con CodigoToken.
+func VerificarToken(texto string, bloque string, acceso string, claves map[int64]ClaveDeBloques, ahora time.Time) (TokenDeBloque, error) {
+	var token TokenDeBloque
+	if texto == "" {
+		return token, Errorf(CodigoToken, "falta el token de acceso al bloque")
+	}
+	parteContenido, parteFirma, ok := strings.Cut(texto, ".")
+	contenido, err := codificacion.DecodeString(parteContenido)
+	if !ok || err != nil {
+		return token, Errorf(CodigoToken, "token mal formado")
+	}
+	firmaRecibida, err := codificacion.DecodeString(parteFirma)
+	if err != nil || json.Unmarshal(contenido, &token) != nil {
+		return token, Errorf(CodigoToken, "token mal formado")
+	}
+	clave, ok := claves[token.Clave]
+	if !ok || ahora.Unix() >= clave.Expira {
+		return token, Errorf(CodigoToken, "el token está firmado con una clave desconocida o vencida")
+	}
+	if !hmac.Equal(firmaRecibida, firma(contenido, clave.Clave)) {
+		return token, Errorf(CodigoToken, "firma del token inválida")
+	}
+	if ahora.Unix() >= token.Expira {
+		return token, Errorf(CodigoToken, "el token venció")
+	}
+	if token.Bloque != bloque || token.Acceso != acceso {
+		return token, Errorf(CodigoToken, "el token es para %s %s, no para %s %s", token.Acceso, token.Bloque, acceso, bloque)
+	}
+	return token, nil
+}
+
+func firma(contenido []byte, clave []byte) []byte {
+	mac := hmac.New(sha256.New, clave)
+	mac.Write(contenido)
+	return mac.Sum(nil)
+}
+
+func (t TokenDeBloque) String() string {
+	return fmt.Sprintf("%s %s de %s hasta %s", t.Acceso, t.Bloque, t.Usuario, time.Unix(t.Expira, 0).Format(time.TimeOnly))
+}
@@ -0,0 +1,73 @@
+package protocolo
+
+import (
+	"testing"
+	"time"
+)
+
+func claveDePrueba(id int64, expira time.Time) ClaveDeBloques {
+	return ClaveDeBloques{ID: id, Clave: []byte("clave-de-prueba-de-32-bytes-----"), Expira: expira.Unix()}
+}
+
+func TestVerificarToken(t *testing.T) {
+	ahora := time.Now()
+	clave := claveDePrueba(1, ahora.Add(time.Hour))
+	claves := map[int64]ClaveDeBloques{clave.ID: clave}
+	texto := FirmarToken(TokenDeBloque{Bloque: "blk_1_1", Acceso: AccesoLeer, Expira: ahora.Add(10 * time.Minute).Unix(), Usuario: "ana"}, clave)
+
+	token, err := VerificarToken(texto, "blk_1_1", AccesoLeer, claves, ahora)
+	if err != nil {
+		t.Fatalf("token válido rechazado: %v", err)
+	}
+	if token.Usuario != "ana" || token.Clave != clave.ID {
+		t.Errorf("token verificado: %+v", token)
+	}
+
+	otraClave := claveDePrueba(2, ahora.Add(time.Hour))
+	otraClave.Clave = []byte("otra-clave-de-prueba-de-32-bytes")
+	casos := []struct {
+		nombre string
+		texto  string
+		bloque string
+		acceso string
+		claves map[int64]ClaveDeBloques
+		ahora  time.Time
+	}{
+		{"sin token", "", "blk_1_1", AccesoLeer, claves, ahora},
+		{"mal formado", "abc", "blk_1_1", AccesoLeer, claves, ahora},
+		{"otro bloque", texto, "blk_2_1", AccesoLeer, claves, ahora},
+		{"otro acceso", texto, "blk_1_1", AccesoEscribir, claves, ahora},
+		{"leer no alcanza para replicar", texto, "blk_1_1", AccesoReplicar, claves, ahora},
+		{"vencido", texto, "blk_1_1", AccesoLeer, claves, ahora.Add(11 * time.Minute)},
+		{"clave desconocida", texto, "blk_1_1", AccesoLeer, map[int64]ClaveDeBloques{}, ahora},
+		{"clave vencida", texto, "blk_1_1", AccesoLeer, claves, ahora.Add(2 * time.Hour)},
+		{"firmado con otra clave", FirmarToken(TokenDeBloque{Bloque: "blk_1_1", Acceso: AccesoLeer, Expira: ahora.Add(time.Minute).Unix()}, otraClave),
+			"blk_1_1", AccesoLeer, map[int64]ClaveDeBloques{2: {ID: 2, Clave: clave.Clave, Expira: otraClave.Expira}}, ahora},
+		{"firma cambiada", texto + "x", "blk_1_1", AccesoLeer, claves, ahora},
+	}
+	for _, caso := range casos {
+		_, err := VerificarToken(caso.texto, caso.bloque, caso.acceso, caso.claves, caso.ahora)
+		if err == nil || CodigoDe(err) != CodigoToken {
+			t.Errorf("%s: error %v, se esperaba uno con CodigoToken", caso.nombre, err)
+		}
+	}
+}
+
+// Después de rotar, los tokens firmados con la clave anterior siguen valiendo mientras
+// la clave no venza
+func TestTokenConClaveAnterior(t *testing.T) {
+	ahora := time.Now()
+	anterior := claveDePrueba(1, ahora.Add(10*time.Minute))
+	nueva := claveDePrueba(2, ahora.Add(time.Hour))
+	nueva.Clave = []byte("clave-nueva-de-prueba-de-32-byte")
+	claves := map[int64]ClaveDeBloques{anterior.ID: anterior, nueva.ID: nueva}
+	texto := FirmarToken(TokenDeBloque{Bloque: "blk_1_1", Acceso: AccesoReplicar, Expira: ahora.Add(5 * time.Minute).Unix()}, anterior)
+
+	if _, err := VerificarToken(texto, "blk_1_1", AccesoReplicar, claves, ahora); err != nil {
+		t.Fatalf("token con la clave anterior rechazado: %v", err)
+	}
+	delete(claves, anterior.ID)
+	if _, err := VerificarToken(texto, "blk_1_1", AccesoReplicar, claves, ahora); err == nil {
+		t.Fatal("se aceptó un token firmado con una clave descartada")
+	}
+}